@@ -0,0 +1,154 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrAccountDecoderNotFound = errors.New("account decoder not found")
+
+// AccountDecoder decodes the data of an account into its typed state.
+type AccountDecoder func(data []byte) (interface{}, error)
+
+// AccountLayout describes one kind of account owned by a program, and how
+// to recognize it from its data: by a discriminator found at
+// DiscriminatorOffset, by its exact Size, or by both.
+type AccountLayout struct {
+	// Name identifies the layout among the layouts of its program (e.g. "Mint").
+	Name string
+
+	Discriminator       []byte
+	DiscriminatorOffset int
+
+	// Size is the exact length of the account data, zero for any length.
+	Size int
+
+	Decode AccountDecoder
+}
+
+// Matches returns true if `data` has the discriminator and the size of the layout.
+func (layout AccountLayout) Matches(data []byte) bool {
+	if layout.Size != 0 && len(data) != layout.Size {
+		return false
+	}
+	if len(layout.Discriminator) != 0 {
+		end := layout.DiscriminatorOffset + len(layout.Discriminator)
+		if end > len(data) || !bytes.Equal(data[layout.DiscriminatorOffset:end], layout.Discriminator) {
+			return false
+		}
+	}
+	return true
+}
+
+// moreSpecificThan orders the layouts matching the same data: a layout
+// checking both a discriminator and a size wins over one checking only a
+// discriminator, longer discriminators win over shorter ones, and any
+// discriminator wins over a size alone.
+func (layout AccountLayout) moreSpecificThan(other AccountLayout) bool {
+	if len(layout.Discriminator) != 0 && len(other.Discriminator) != 0 {
+		if (layout.Size != 0) != (other.Size != 0) {
+			return layout.Size != 0
+		}
+		return len(layout.Discriminator) > len(other.Discriminator)
+	}
+	if (len(layout.Discriminator) != 0) != (len(other.Discriminator) != 0) {
+		return len(layout.Discriminator) != 0
+	}
+	return layout.Size != 0 && other.Size == 0
+}
+
+var accountDecoderRegistry = newAccountDecoderRegistry()
+
+type accountRegistry struct {
+	mu      *sync.RWMutex
+	layouts map[PublicKey][]AccountLayout
+}
+
+func newAccountDecoderRegistry() *accountRegistry {
+	return &accountRegistry{
+		mu:      &sync.RWMutex{},
+		layouts: make(map[PublicKey][]AccountLayout),
+	}
+}
+
+func (reg *accountRegistry) Register(programID PublicKey, layout AccountLayout) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, prev := range reg.layouts[programID] {
+		if prev.Name != layout.Name {
+			continue
+		}
+		// If it's the same function, then OK (tollerate multiple calls with same params).
+		if isSameFunction(prev.Decode, layout.Decode) {
+			return
+		}
+		panic(fmt.Sprintf("unable to re-register account decoder %q for program %s", layout.Name, programID))
+	}
+	reg.layouts[programID] = append(reg.layouts[programID], layout)
+}
+
+func (reg *accountRegistry) Find(programID PublicKey, data []byte) (AccountLayout, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	var found AccountLayout
+	var ok bool
+	for _, layout := range reg.layouts[programID] {
+		if !layout.Matches(data) {
+			continue
+		}
+		if !ok || layout.moreSpecificThan(found) {
+			found, ok = layout, true
+		}
+	}
+	return found, ok
+}
+
+// RegisterAccountDecoder registers an account layout of the program `programID`.
+// Registering the same name again with the same decoder is a no-op, with
+// another decoder it panics.
+func RegisterAccountDecoder(programID PublicKey, layout AccountLayout) {
+	if layout.Name == "" {
+		panic("account layout name is required")
+	}
+	if layout.Decode == nil {
+		panic(fmt.Sprintf("account layout %q has no decoder", layout.Name))
+	}
+	accountDecoderRegistry.Register(programID, layout)
+}
+
+// FindAccountLayout returns the most specific layout of the program `owner`
+// matching `data`.
+func FindAccountLayout(owner PublicKey, data []byte) (AccountLayout, bool) {
+	return accountDecoderRegistry.Find(owner, data)
+}
+
+// DecodeAccount decodes the data of an account owned by `owner` with the
+// most specific registered layout matching it.
+func DecodeAccount(owner PublicKey, data []byte) (interface{}, error) {
+	layout, found := accountDecoderRegistry.Find(owner, data)
+	if !found {
+		return nil, ErrAccountDecoderNotFound
+	}
+	return layout.Decode(data)
+}
@@ -0,0 +1,51 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAccountDecoder(t *testing.T) {
+	programID := PublicKey{1, 2, 3}
+
+	decodeAs := func(name string) AccountDecoder {
+		return func(data []byte) (interface{}, error) {
+			return name, nil
+		}
+	}
+	sized, discriminated, both := decodeAs("sized"), decodeAs("discriminated"), decodeAs("both")
+
+	assert.NotPanics(t, func() {
+		RegisterAccountDecoder(programID, AccountLayout{Name: "sized", Size: 4, Decode: sized})
+		RegisterAccountDecoder(programID, AccountLayout{Name: "sized", Size: 4, Decode: sized})
+		RegisterAccountDecoder(programID, AccountLayout{Name: "discriminated", Discriminator: []byte{7}, DiscriminatorOffset: 1, Decode: discriminated})
+		RegisterAccountDecoder(programID, AccountLayout{Name: "both", Discriminator: []byte{7}, DiscriminatorOffset: 1, Size: 3, Decode: both})
+	})
+	assert.Panics(t, func() {
+		RegisterAccountDecoder(programID, AccountLayout{Name: "sized", Size: 4, Decode: func(data []byte) (interface{}, error) {
+			return nil, nil
+		}})
+	})
+
+	tests := []struct {
+		data   []byte
+		expect interface{}
+	}{
+		{[]byte{0, 0, 0, 0}, "sized"},
+		{[]byte{0, 7, 0, 0}, "discriminated"},
+		{[]byte{0, 7, 0, 0, 0}, "discriminated"},
+		{[]byte{0, 7, 0}, "both"},
+	}
+	for _, test := range tests {
+		obj, err := DecodeAccount(programID, test.data)
+		require.NoError(t, err)
+		assert.Equal(t, test.expect, obj, "data %v", test.data)
+	}
+
+	_, err := DecodeAccount(programID, []byte{0, 0})
+	assert.Equal(t, ErrAccountDecoderNotFound, err)
+	_, err = DecodeAccount(PublicKey{4, 5, 6}, []byte{0, 0, 0, 0})
+	assert.Equal(t, ErrAccountDecoderNotFound, err)
+}
@@ -17,9 +17,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/xmcontinue/solana-go"
 	"github.com/xmcontinue/solana-go/rpc"
+	"github.com/xmcontinue/solana-go/text"
 
 	"github.com/spf13/viper"
 	"github.com/xmcontinue/solana-go/vault"
@@ -46,6 +49,33 @@ func getClient() *rpc.Client {
 	return api
 }
 
+// outputFormat returns the format selected with the global --output flag.
+func outputFormat() text.Format {
+	format, err := text.ParseFormat(viper.GetString("global-output"))
+	if err != nil {
+		return text.FormatText
+	}
+	return format
+}
+
+// structuredOutput returns true if the output must be rendered with
+// printStructured rather than as the human readable output of the command.
+func structuredOutput() bool {
+	return outputFormat() != text.FormatText
+}
+
+// printStructured prints `v` in the --output format.
+func printStructured(v interface{}) error {
+	return text.Render(os.Stdout, outputFormat(), v)
+}
+
+// errUnsupportedOutput is returned by the commands that only have a human
+// readable output, e.g. the interactive ones, when --output selects another
+// format.
+func errUnsupportedOutput() error {
+	return fmt.Errorf("unsupported --output %s for this command", outputFormat())
+}
+
 func sanitizeAPIURL(input string) string {
 	switch input {
 	case "devnet":
@@ -85,7 +115,7 @@ func setupWallet() (*vault.Vault, error) {
 		return nil, fmt.Errorf("loading vault: %w", err)
 	}
 
-	boxer, err := vault.SecretBoxerForType(v.SecretBoxWrap, viper.GetString("global-kms-gcp-keypath"))
+	boxer, err := vault.SecretBoxerForType(v.SecretBoxWrap, boxerKeypath(v.SecretBoxWrap))
 	if err != nil {
 		return nil, fmt.Errorf("secret boxer: %w", err)
 	}
@@ -96,3 +126,55 @@ func setupWallet() (*vault.Vault, error) {
 
 	return v, nil
 }
+
+// boxerKeypath returns the key path flag relevant to the provided vault wrap type.
+func boxerKeypath(wrapType string) string {
+	switch wrapType {
+	case "age-x25519":
+		return viper.GetString("global-age-identity-file")
+	case "kms-aws":
+		return viper.GetString("global-kms-aws-key-id")
+	case "vault-transit":
+		return viper.GetString("global-vault-transit-key")
+	case "kms-local":
+		return viper.GetString("global-kms-local-key-file")
+	}
+	return viper.GetString("global-kms-gcp-keypath")
+}
+
+// boxerKeypathFlag returns the name of the flag read by boxerKeypath.
+func boxerKeypathFlag(wrapType string) string {
+	switch wrapType {
+	case "age-x25519":
+		return "--age-identity-file"
+	case "kms-aws":
+		return "--kms-aws-key-id"
+	case "vault-transit":
+		return "--vault-transit-key"
+	case "kms-local":
+		return "--kms-local-key-file"
+	}
+	return "--kms-gcp-keypath"
+}
+
+func importKeygenDirectory(v *vault.Vault, dir string) ([]solana.PublicKey, error) {
+	res, err := v.ImportKeygenDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range res.Duplicates {
+		fmt.Printf("- Skipped %q: key already in vault\n", file)
+	}
+	skipped := make([]string, 0, len(res.Skipped))
+	for file := range res.Skipped {
+		skipped = append(skipped, file)
+	}
+	sort.Strings(skipped)
+	for _, file := range skipped {
+		fmt.Printf("- Skipped %q: %s\n", file, res.Skipped[file])
+	}
+	for _, pubKey := range res.Imported {
+		fmt.Printf("- Imported keypair %s\n", pubKey)
+	}
+	return res.Imported, nil
+}
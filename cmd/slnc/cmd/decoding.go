@@ -15,48 +15,28 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
-
-	bin "github.com/gagliardetto/binary"
+	"errors"
 
 	"github.com/xmcontinue/solana-go"
-	"github.com/xmcontinue/solana-go/programs/token"
+
+	// Register the account decoders of the supported programs.
+	_ "github.com/xmcontinue/solana-go/programs/address-lookup-table"
+	_ "github.com/xmcontinue/solana-go/programs/config"
+	_ "github.com/xmcontinue/solana-go/programs/feature"
+	_ "github.com/xmcontinue/solana-go/programs/serum"
+	_ "github.com/xmcontinue/solana-go/programs/system"
+	_ "github.com/xmcontinue/solana-go/programs/token"
+	_ "github.com/xmcontinue/solana-go/programs/token-lending"
+	_ "github.com/xmcontinue/solana-go/programs/token-metadata"
+	_ "github.com/xmcontinue/solana-go/programs/token-swap"
 )
 
+// decode decodes the data of an account with the decoder registered for its
+// owner program; it returns nil if there is none.
 func decode(owner solana.PublicKey, data []byte) (interface{}, error) {
-	bdx, _ := solana.PublicKeyFromBase58("BdxDnkFufu8tjAE5gdPkWdjGfQ3Lz2v6ozfiBDMKxDFW")
-	tkn, _ := solana.PublicKeyFromBase58("TokenSVp5gheXUvJ6jGWGeCsgPKgnE3YgdGKRVCMY9o")
-	switch owner {
-	case bdx, tkn:
-		return decodeAsToken(data)
-	}
-	return nil, nil
-}
-
-func decodeAsToken(data []byte) (out interface{}, err error) {
-
-	switch len(data) {
-	case 120:
-		var tokenAcct token.Account
-
-		if err := bin.NewBinDecoder(data).Decode(&tokenAcct); err != nil {
-			return nil, fmt.Errorf("failed unpacking: %w", err)
-		}
-
-		return tokenAcct, nil
-	case 40:
-		var mint token.Mint
-		if err := bin.NewBinDecoder(data).Decode(&mint); err != nil {
-			log.Fatalln("failed unpack", err)
-		}
-
-		return mint, nil
-
-		// cnt, _ := json.MarshalIndent(mint, "", "  ")
-		// fmt.Println(string(cnt))
-	case 7777:
-		// decode the Multisig struct
+	obj, err := solana.DecodeAccount(owner, data)
+	if errors.Is(err, solana.ErrAccountDecoderNotFound) {
+		return nil, nil
 	}
-	return
+	return obj, err
 }
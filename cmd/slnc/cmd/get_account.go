@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
 )
 
 var getAccountCmd = &cobra.Command{
@@ -41,6 +42,17 @@ var getAccountCmd = &cobra.Command{
 		}
 
 		acct := resp.Value
+		if structuredOutput() {
+			obj, err := decode(acct.Owner, acct.Data.GetBinary())
+			if err != nil {
+				return err
+			}
+			return printStructured(struct {
+				Account *rpc.Account
+				Decoded interface{}
+			}{acct, obj})
+		}
+
 		var data []byte
 		if data, err = json.MarshalIndent(acct, "", "  "); err != nil {
 			return fmt.Errorf("unable to marshall account information: %w", err)
@@ -45,6 +45,10 @@ var getBalanceCmd = &cobra.Command{
 			return fmt.Errorf("account not found")
 		}
 
+		if structuredOutput() {
+			return printStructured(struct{ Lamports uint64 }{resp.Value})
+		}
+
 		fmt.Println(resp.Value, "lamports")
 
 		return nil
@@ -44,6 +44,10 @@ var getConfirmedBlockCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return printStructured(resp)
+		}
+
 		cnt, _ := json.MarshalIndent(resp, "", "  ")
 		fmt.Println(string(cnt))
 
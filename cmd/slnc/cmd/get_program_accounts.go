@@ -23,6 +23,7 @@ import (
 	"os"
 
 	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
 	"github.com/xmcontinue/solana-go/text"
 
 	"github.com/spf13/cobra"
@@ -47,12 +48,31 @@ var getProgramAccountsCmd = &cobra.Command{
 			return fmt.Errorf("program account not found")
 		}
 
+		if structuredOutput() {
+			type programAccount struct {
+				Address solana.PublicKey
+				Account *rpc.Account
+				Decoded interface{}
+			}
+
+			var out []programAccount
+			for _, keyedAcct := range resp {
+				obj, err := decode(keyedAcct.Account.Owner, keyedAcct.Account.Data.GetBinary())
+				if err != nil {
+					return fmt.Errorf("unable to decode account %s: %w", keyedAcct.Pubkey, err)
+				}
+				out = append(out, programAccount{keyedAcct.Pubkey, keyedAcct.Account, obj})
+			}
+			return printStructured(out)
+		}
+
 		for _, keyedAcct := range resp {
 			acct := keyedAcct.Account
+			fmt.Printf("Account %s:\n", keyedAcct.Pubkey)
 
 			obj, err := decode(acct.Owner, acct.Data.GetBinary())
 			if err != nil {
-				return err
+				return fmt.Errorf("unable to decode account %s: %w", keyedAcct.Pubkey, err)
 			}
 
 			if obj != nil {
@@ -61,7 +81,7 @@ var getProgramAccountsCmd = &cobra.Command{
 					return err
 				}
 				fmt.Printf("Data %T: %s\n", obj, string(cnt))
-				return nil
+				continue
 			}
 
 			if err := text.NewEncoder(os.Stdout).Encode(acct, nil); err != nil {
@@ -38,6 +38,10 @@ var getRecentBlockhashCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return printStructured(resp.Value)
+		}
+
 		cnt, _ := json.MarshalIndent(resp.Value, "", "  ")
 		fmt.Println(string(cnt))
 
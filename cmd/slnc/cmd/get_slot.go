@@ -37,6 +37,10 @@ var getSlotCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return printStructured(struct{ Slot uint64 }{resp})
+		}
+
 		fmt.Println(resp)
 
 		return nil
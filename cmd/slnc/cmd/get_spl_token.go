@@ -58,6 +58,25 @@ var getSPLTokenCmd = &cobra.Command{
 			return fmt.Errorf("program account not found")
 		}
 
+		if structuredOutput() {
+			type splToken struct {
+				Address  solana.PublicKey
+				Owner    solana.PublicKey
+				Lamports uint64
+				Mint     *token.Mint
+			}
+
+			var out []splToken
+			for _, keyedAcct := range resp {
+				var mint *token.Mint
+				if err := bin.NewBinDecoder(keyedAcct.Account.Data.GetBinary()).Decode(&mint); err != nil {
+					return fmt.Errorf("unable to decode mint %s: %w", keyedAcct.Pubkey, err)
+				}
+				out = append(out, splToken{keyedAcct.Pubkey, keyedAcct.Account.Owner, keyedAcct.Account.Lamports, mint})
+			}
+			return printStructured(out)
+		}
+
 		for _, keyedAcct := range resp {
 			acct := keyedAcct.Account
 			// fmt.Println("Data len:", len(acct.Data), keyedAcct.Pubkey)
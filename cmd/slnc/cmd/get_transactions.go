@@ -23,8 +23,12 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/cpi"
+	"github.com/xmcontinue/solana-go/explain"
+	_ "github.com/xmcontinue/solana-go/programs/associated-token-account"
 	_ "github.com/xmcontinue/solana-go/programs/serum"
 	_ "github.com/xmcontinue/solana-go/programs/system"
 	_ "github.com/xmcontinue/solana-go/programs/token"
@@ -57,35 +61,92 @@ var getTransactionsCmd = &cobra.Command{
 			return fmt.Errorf("unable to retrieve confirmed transaction signatures for account: %w", err)
 		}
 
+		var out []*transactionOutput
 		for _, cs := range csList {
-			fmt.Println("-----------------------------------------------------------------------------------------------")
-			text.EncoderColorCyan.Print("Transaction: ")
-			fmt.Println(cs.Signature)
+			if !structuredOutput() {
+				fmt.Println("-----------------------------------------------------------------------------------------------")
+				text.EncoderColorCyan.Print("Transaction: ")
+				fmt.Println(cs.Signature)
 
-			text.EncoderColorGreen.Print("Slot: ")
-			fmt.Println(cs.Slot)
-			text.EncoderColorGreen.Print("Memo: ")
-			fmt.Println(cs.Memo)
+				text.EncoderColorGreen.Print("Slot: ")
+				fmt.Println(cs.Slot)
+				text.EncoderColorGreen.Print("Memo: ")
+				fmt.Println(cs.Memo)
+			}
 
 			ct, err := client.GetConfirmedTransaction(ctx, cs.Signature)
 			if err != nil {
 				return fmt.Errorf("unable to get confirmed transaction with signature %q: %w", cs.Signature, err)
 			}
+			entry := &transactionOutput{Signature: cs.Signature, Slot: cs.Slot, Memo: cs.Memo}
+			out = append(out, entry)
+
+			if viper.GetBool("get-transactions-cmd-explain") {
+				statements, err := explain.Explain(ct.MustGetTransaction(), ct.Meta, nil)
+				if err != nil {
+					return fmt.Errorf("unable to explain transaction %q: %w", cs.Signature, err)
+				}
+				if structuredOutput() {
+					entry.Statements = statements
+					continue
+				}
+				for _, statement := range statements {
+					fmt.Println("-", statement)
+				}
+				continue
+			}
+
+			if viper.GetBool("get-transactions-cmd-calls") {
+				tree, err := cpi.Build(ct.MustGetTransaction(), ct.Meta)
+				if err != nil {
+					return fmt.Errorf("unable to build call tree of transaction %q: %w", cs.Signature, err)
+				}
+				if structuredOutput() {
+					entry.Calls = tree
+					continue
+				}
+				if _, err := tree.EncodeTree(text.NewTreeEncoder(os.Stdout, text.Bold("CALLS"))); err != nil {
+					return err
+				}
+				continue
+			}
 
 			if ct.Meta.Err != nil {
 				return fmt.Errorf("unable to get confirmed transaction with signature %q: %s", cs.Signature, ct.Meta.Err)
 			}
 
+			if structuredOutput() {
+				entry.Transaction = ct.MustGetTransaction()
+				continue
+			}
 			_, err = ct.MustGetTransaction().EncodeTree(text.NewTreeEncoder(os.Stdout, text.Bold("INSTRUCTIONS")))
 			if err != nil {
 				panic(err)
 			}
 		}
 
+		if structuredOutput() {
+			return printStructured(out)
+		}
 		return nil
 	},
 }
 
+// transactionOutput is the structured output of `get transactions`: the
+// statements with --explain, the call tree with --calls, and the
+// transaction otherwise.
+type transactionOutput struct {
+	Signature   solana.Signature
+	Slot        uint64
+	Memo        *string
+	Statements  []string
+	Calls       *cpi.Tree
+	Transaction *solana.Transaction
+}
+
 func init() {
 	getCmd.AddCommand(getTransactionsCmd)
+
+	getTransactionsCmd.Flags().Bool("explain", false, "Print plain statements explaining the transactions instead of their instructions")
+	getTransactionsCmd.Flags().Bool("calls", false, "Print the tree of program invocations of the transactions, with the compute units they consumed and the one that failed")
 }
@@ -38,6 +38,10 @@ var isBlockhashValidCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return printStructured(struct{ Valid bool }{resp.Value})
+		}
+
 		fmt.Println(resp.Value)
 
 		return nil
@@ -56,6 +56,10 @@ var requestCmd = &cobra.Command{
 			return fmt.Errorf("airdrop request failed: %w", err)
 		}
 
+		if structuredOutput() {
+			return printStructured(struct{ Signature solana.Signature }{airDrop})
+		}
+
 		fmt.Println("Air drop succeeded, transaction hash:", airDrop)
 		return nil
 	},
@@ -25,6 +25,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+
+	"github.com/xmcontinue/solana-go/text"
 )
 
 // Version represents the cmd command version
@@ -55,10 +57,16 @@ func init() {
 	RootCmd.PersistentFlags().StringP("rpc-url", "u", defaultRPCURL, "API endpoint of eos.io blockchain node")
 	RootCmd.PersistentFlags().StringSliceP("http-header", "H", []string{}, "HTTP header to add to JSON-RPC requests")
 	RootCmd.PersistentFlags().StringP("kms-gcp-keypath", "", "", "Path to the cryptoKeys within a keyRing on GCP")
+	RootCmd.PersistentFlags().StringP("kms-aws-key-id", "", "", "AWS KMS key ID, ARN or alias used by kms-aws vaults. Region and credentials are read from the standard AWS_* environment variables")
+	RootCmd.PersistentFlags().StringP("vault-transit-key", "", "", "HashiCorp Vault Transit key (<key> or <mount>/<key>) used by vault-transit vaults. Server and token are read from VAULT_ADDR and VAULT_TOKEN")
+	RootCmd.PersistentFlags().StringP("kms-local-key-file", "", "", "File holding the base64-encoded master key of kms-local vaults")
+	RootCmd.PersistentFlags().StringP("age-identity-file", "", "", "File holding the age X25519 identities (AGE-SECRET-KEY-1...) and recipients (age1...) of an age-x25519 vault")
+	RootCmd.PersistentFlags().StringP("output", "o", "text", "Output format, one of text, json, yaml or table")
 
 	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		SetupLogger()
-		return nil
+		_, err := text.ParseFormat(viper.GetString("global-output"))
+		return err
 	}
 }
 
@@ -60,6 +60,15 @@ var serumGetMarketCmd = &cobra.Command{
 		}
 		totalSize := new(big.Float).Add(askSize, bidSize)
 
+		if structuredOutput() {
+			return printStructured(struct {
+				Name   string
+				Market serum.MarketV2
+				Asks   []orderBookLevel
+				Bids   []orderBookLevel
+			}{market.Name, market.MarketV2, orderBookLevels(asks), orderBookLevels(bids)})
+		}
+
 		output := []string{
 			"Price | Quantity | Depth",
 			"Asks",
@@ -93,6 +102,18 @@ type orderBookEntry struct {
 	quantity *big.Float
 }
 
+type orderBookLevel struct {
+	Price    string
+	Quantity string
+}
+
+func orderBookLevels(entries []*orderBookEntry) (out []orderBookLevel) {
+	for _, entry := range entries {
+		out = append(out, orderBookLevel{Price: entry.price.String(), Quantity: entry.quantity.String()})
+	}
+	return
+}
+
 func getOrderBook(ctx context.Context, market *serum.MarketMeta, cli *rpc.Client, address solana.PublicKey, desc bool) (out []*orderBookEntry, totalSize *big.Float, err error) {
 	var o serum.Orderbook
 	if err := cli.GetAccountDataInto(ctx, address, &o); err != nil {
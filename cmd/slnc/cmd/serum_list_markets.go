@@ -19,27 +19,79 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ryanuber/columnize"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/xmcontinue/solana-go"
 	"github.com/xmcontinue/solana-go/programs/serum"
 )
 
 var serumListMarketsCmd = &cobra.Command{
 	Use:   "markets",
 	Short: "Get serum markets",
-	Args:  cobra.ExactArgs(0),
+	Long: `Get serum markets.
+
+Markets are discovered on-chain for the given DEX program, and cached
+locally for --max-age. Use --known to list the markets bundled with
+the binary instead; these only have a name and an address.`,
+	Args: cobra.ExactArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool("serum-list-markets-cmd-known") {
+			markets, err := serum.KnownMarket()
+			if err != nil {
+				return fmt.Errorf("unable to retrieve markets: %w", err)
+			}
+			return printKnownMarkets(markets)
+		}
 
-		markets, err := serum.KnownMarket()
+		programID, err := solana.PublicKeyFromBase58(viper.GetString("serum-list-markets-cmd-program-id"))
 		if err != nil {
-			return fmt.Errorf("unable to retrieve markets: %w", err)
+			return fmt.Errorf("decoding program id: %w", err)
+		}
+
+		cachePath := viper.GetString("serum-list-markets-cmd-cache-file")
+		if cachePath == "" {
+			cachePath, err = serum.DefaultMarketsCachePath(programID)
+			if err != nil {
+				return err
+			}
+		}
+
+		maxAge := viper.GetDuration("serum-list-markets-cmd-max-age")
+		if viper.GetBool("serum-list-markets-cmd-refresh") {
+			maxAge = 0
+		}
+
+		markets, err := serum.DiscoverMarkets(cmd.Context(), getClient(), programID, cachePath, maxAge)
+		if err != nil {
+			return fmt.Errorf("unable to discover markets: %w", err)
+		}
+
+		if structuredOutput() {
+			type marketRow struct {
+				Name      string
+				Address   solana.PublicKey
+				BaseMint  solana.PublicKey
+				QuoteMint solana.PublicKey
+			}
+
+			rows := make([]marketRow, 0, len(markets))
+			for _, market := range markets {
+				rows = append(rows, marketRow{market.Name, market.Address, market.MarketV2.BaseMint, market.MarketV2.QuoteMint})
+			}
+			return printStructured(rows)
 		}
 
-		out := []string{"Pairs | Market Address"}
+		out := []string{"Pairs | Market Address | Base Mint | Quote Mint"}
 
 		for _, market := range markets {
-			out = append(out, fmt.Sprintf("%s | %s ", market.Name, market.Address.String()))
+			name := market.Name
+			if name == "" {
+				name = "-"
+			}
+			out = append(out, fmt.Sprintf("%s | %s | %s | %s", name, market.Address, market.MarketV2.BaseMint, market.MarketV2.QuoteMint))
 		}
 
 		fmt.Println(columnize.Format(out, nil))
@@ -48,6 +100,38 @@ var serumListMarketsCmd = &cobra.Command{
 	},
 }
 
+// printKnownMarkets prints the markets bundled with the binary, which
+// have no on-chain data: only their name and address are known.
+func printKnownMarkets(markets []*serum.MarketMeta) error {
+	if structuredOutput() {
+		type marketRow struct {
+			Name    string
+			Address solana.PublicKey
+		}
+
+		rows := make([]marketRow, 0, len(markets))
+		for _, market := range markets {
+			rows = append(rows, marketRow{market.Name, market.Address})
+		}
+		return printStructured(rows)
+	}
+
+	out := []string{"Pairs | Market Address"}
+	for _, market := range markets {
+		out = append(out, fmt.Sprintf("%s | %s", market.Name, market.Address))
+	}
+
+	fmt.Println(columnize.Format(out, nil))
+
+	return nil
+}
+
 func init() {
 	serumListCmd.AddCommand(serumListMarketsCmd)
+
+	serumListMarketsCmd.Flags().String("program-id", serum.DEXProgramIDV3.String(), "DEX program ID whose markets are listed")
+	serumListMarketsCmd.Flags().String("cache-file", "", "Markets cache file (defaults to a file in the user cache directory)")
+	serumListMarketsCmd.Flags().Duration("max-age", 24*time.Hour, "Maximum age of the markets cache before it is refreshed from chain")
+	serumListMarketsCmd.Flags().Bool("refresh", false, "Ignore the markets cache and refresh it from chain")
+	serumListMarketsCmd.Flags().Bool("known", false, "List the markets bundled with the binary instead of discovering them on-chain")
 }
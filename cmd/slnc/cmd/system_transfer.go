@@ -37,6 +37,10 @@ var systemTransferCmd = &cobra.Command{
 		to := args[1]
 		amount := args[2]
 
+		if structuredOutput() {
+			return printStructured(struct{ From, To, Amount string }{from, to, amount})
+		}
+
 		fmt.Println(from, to, amount)
 
 		_ = client
@@ -56,6 +56,10 @@ var tokenGetMintCmd = &cobra.Command{
 			return nil
 		}
 
+		if structuredOutput() {
+			return printStructured(mint)
+		}
+
 		var out []string
 
 		out = append(out, fmt.Sprintf("Supply | %d", mint.Supply))
@@ -36,6 +36,10 @@ var tokenListMintsCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("unable to retrieve mints: %w", err)
 		}
+		if structuredOutput() {
+			return printStructured(mints)
+		}
+
 		out := []string{"Mint | Decimals | Supply | Token Authority | Freeze Authority"}
 		for _, m := range mints {
 			line := []string{
@@ -52,6 +52,10 @@ var tokenRegistryGetCmd = &cobra.Command{
 			return fmt.Errorf("unable to retrieve token registry entry for mint %q: %w", pubKey.String(), err)
 		}
 
+		if structuredOutput() {
+			return printStructured(t)
+		}
+
 		err = text.NewEncoder(os.Stdout).Encode(t, nil)
 		if err != nil {
 			return fmt.Errorf("unable to text encode token registry entry: %w", err)
@@ -40,6 +40,10 @@ var tokenRegistryListCmd = &cobra.Command{
 			return fmt.Errorf("unable to retrieve entries: %w", err)
 		}
 
+		if structuredOutput() {
+			return printStructured(entries)
+		}
+
 		out := []string{"Is Initialized | Mint Address | Registration Authority | Logo | Name | Symbol | Website"}
 
 		for _, e := range entries {
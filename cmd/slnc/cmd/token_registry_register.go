@@ -152,6 +152,14 @@ var tokenRegistryRegisterCmd = &cobra.Command{
 			return fmt.Errorf("unable to send transaction: %w", err)
 		}
 
+		if structuredOutput() {
+			return printStructured(struct {
+				Signature        solana.Signature
+				MintAddress      solana.PublicKey
+				TokenMetaAddress solana.PublicKey
+			}{trxHash, tokenAddress, tokenMetaAccount.PublicKey()})
+		}
+
 		fmt.Printf("Token Register successfully, with transaction hash: %s\n", trxHash)
 		fmt.Printf("  Mint Address Registerd: %s\n", tokenAddress.String())
 		fmt.Printf("  Token Registry Meta Address: %s\n", tokenMetaAccount.PublicKey().String())
@@ -32,6 +32,9 @@ var vaultAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add private keys to an existing vault taking input from the shell",
 	Run: func(cmd *cobra.Command, args []string) {
+		if structuredOutput() {
+			errorCheck("checking output format", errUnsupportedOutput())
+		}
 
 		walletFile := viper.GetString("global-vault-file")
 
@@ -41,7 +44,7 @@ var vaultAddCmd = &cobra.Command{
 			fmt.Errorf("unable to load vault file: %w", err)
 		}
 
-		boxer, err := vault.SecretBoxerForType(v.SecretBoxWrap, viper.GetString("global-kms-gcp-keypath"))
+		boxer, err := vault.SecretBoxerForType(v.SecretBoxWrap, boxerKeypath(v.SecretBoxWrap))
 		if err != nil {
 			fmt.Errorf("unable to intiate boxer: %w", err)
 		}
@@ -53,15 +56,28 @@ var vaultAddCmd = &cobra.Command{
 
 		v.PrintPublicKeys()
 
-		privateKeys, err := capturePrivateKeys()
-		if err != nil {
-			fmt.Errorf("failed to enter private keys: %w", err)
-		}
-
 		var newKeys []solana.PublicKey
-		for _, privateKey := range privateKeys {
-			v.AddPrivateKey(privateKey)
-			newKeys = append(newKeys, privateKey.PublicKey())
+		if importDir := viper.GetString("vault-add-cmd-import-dir"); importDir != "" {
+			newKeys, err = importKeygenDirectory(v, importDir)
+			errorCheck("import keygen directory", err)
+		} else if viper.GetBool("vault-add-cmd-mnemonic") {
+			privateKeys, err := captureMnemonicKeys(viper.GetStringSlice("vault-add-cmd-derivation-path"))
+			errorCheck("derive keys from mnemonic", err)
+
+			for _, privateKey := range privateKeys {
+				v.AddPrivateKey(privateKey)
+				newKeys = append(newKeys, privateKey.PublicKey())
+			}
+		} else {
+			privateKeys, err := capturePrivateKeys()
+			if err != nil {
+				fmt.Errorf("failed to enter private keys: %w", err)
+			}
+
+			for _, privateKey := range privateKeys {
+				v.AddPrivateKey(privateKey)
+				newKeys = append(newKeys, privateKey.PublicKey())
+			}
 		}
 
 		err = v.Seal(boxer)
@@ -80,6 +96,10 @@ var vaultAddCmd = &cobra.Command{
 
 func init() {
 	vaultCmd.AddCommand(vaultAddCmd)
+
+	vaultAddCmd.Flags().BoolP("mnemonic", "", false, "Derive the keys to add from a BIP39 seed phrase (as used by Phantom or Solflare), inputted on the command line")
+	vaultAddCmd.Flags().StringSliceP("derivation-path", "", []string{solana.SolanaDerivationPath(0).String()}, "SLIP-0010 derivation paths of the keys to derive with --mnemonic. Repeat or comma-separate to derive several keys")
+	vaultAddCmd.Flags().StringP("import-dir", "", "", "Import every *.json keypair file (as written by solana-keygen) of this directory instead of prompting for private keys")
 }
 
 func capturePrivateKeys() (out []solana.PrivateKey, err error) {
@@ -130,6 +150,47 @@ func capturePrivateKey(isFirst bool) (privateKey solana.PrivateKey, err error) {
 	return key, nil
 }
 
+func captureMnemonicKeys(derivationPaths []string) (out []solana.PrivateKey, err error) {
+	var paths []solana.DerivationPath
+	for _, derivationPath := range derivationPaths {
+		path, err := solana.ParseDerivationPath(derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no derivation path")
+	}
+
+	mnemonic, err := cli.GetPassword("Paste your seed phrase: ")
+	if err != nil {
+		return nil, fmt.Errorf("get seed phrase: %s", err)
+	}
+	if err := solana.ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	passphrase, err := cli.GetPassword("Enter the seed phrase passphrase, or hit ENTER if there is none: ")
+	if err != nil {
+		return nil, fmt.Errorf("get seed phrase passphrase: %s", err)
+	}
+
+	seed, err := solana.MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		privateKey, err := solana.PrivateKeyFromSeedPath(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("- Derived private key corresponding to %s at %s\n", privateKey.PublicKey().String(), path)
+		out = append(out, privateKey)
+	}
+	return out, nil
+}
+
 func vaultWrittenReport(walletFile string, newKeys []solana.PublicKey, totalKeys int) {
 	fmt.Println("")
 	fmt.Printf("Wallet file %q written to disk.\n", walletFile)
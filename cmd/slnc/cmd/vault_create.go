@@ -48,8 +48,32 @@ You can create a Google Cloud Platform KMS-wrapped vault with:
 
     cmd vault create --keys=2 --vault-type=kms-gcp --kms-gcp-keypath projects/.../locations/.../keyRings/.../cryptoKeys/name
 
+You can create a vault wrapped by AWS KMS, HashiCorp Vault Transit or a
+local master key file (created if missing) with:
+
+    cmd vault create --keys=2 --vault-type=kms-aws --kms-aws-key-id alias/my-key
+    cmd vault create --keys=2 --vault-type=vault-transit --vault-transit-key my-key
+    cmd vault create --keys=2 --vault-type=kms-local --kms-local-key-file ./master.key
+
+You can create a vault stored as a Web3-style scrypt keystore with:
+
+    cmd vault create --keys=2 --vault-type=keystore-scrypt
+
+You can create a vault sealed for age X25519 recipients with:
+
+    age-keygen -o key.txt
+    cmd vault create --keys=2 --vault-type=age-x25519 --age-identity-file key.txt
+
+You can import all the keypairs of a solana-keygen directory with:
+
+    cmd vault create --import-dir ~/.config/solana
+
 You can then use this vault for the different cmd operations.`,
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if structuredOutput() {
+			return errUnsupportedOutput()
+		}
+
 		walletFile := viper.GetString("global-vault-file")
 
 		if _, err := os.Stat(walletFile); err == nil {
@@ -65,13 +89,26 @@ You can then use this vault for the different cmd operations.`,
 			return fmt.Errorf("missing parameter: --kms-gcp-keypath is required with --vault-type=kms-gcp")
 		}
 
+		ageIdentityFile := viper.GetString("global-age-identity-file")
+		if wrapType == "age-x25519" && ageIdentityFile == "" {
+			return fmt.Errorf("missing parameter: --age-identity-file is required with --vault-type=age-x25519")
+		}
+
 		v := vault.NewVault()
 		v.Comment = viper.GetString("vault-create-cmd-comment")
 
 		var newKeys []solana.PublicKey
 
 		doImport := viper.GetBool("vault-create-cmd-import")
-		if doImport {
+		if importDir := viper.GetString("vault-create-cmd-import-dir"); importDir != "" {
+			newKeys, err = importKeygenDirectory(v, importDir)
+			if err != nil {
+				return fmt.Errorf("failed importing keygen directory: %w", err)
+			}
+
+			fmt.Printf("Imported %d keys.\n", len(newKeys))
+
+		} else if doImport {
 			privateKeys, err := capturePrivateKeys()
 			if err != nil {
 				return fmt.Errorf("failed enterign private key: %w", err)
@@ -123,8 +160,48 @@ You can then use this vault for the different cmd operations.`,
 				boxer = vault.NewPassphraseBoxer(password)
 			}
 
+		case "keystore-scrypt":
+			fmt.Println("")
+			fmt.Println("You will be asked to provide a passphrase to secure your newly created vault.")
+			fmt.Println("Make sure you make it long and strong.")
+			fmt.Println("")
+			if envVal := os.Getenv("SLNC_GLOBAL_INSECURE_VAULT_PASSPHRASE"); envVal != "" {
+				boxer = vault.NewKeystoreBoxer(envVal)
+			} else {
+				password, err := cli.GetEncryptPassphrase()
+				if err != nil {
+					return fmt.Errorf("failed to get password input: %w", err)
+				}
+
+				boxer = vault.NewKeystoreBoxer(password)
+			}
+
+		case "kms-aws", "vault-transit", "kms-local":
+			keypath := boxerKeypath(wrapType)
+			if keypath == "" {
+				return fmt.Errorf("missing parameter: %s is required with --vault-type=%s", boxerKeypathFlag(wrapType), wrapType)
+			}
+			if wrapType == "kms-local" {
+				if _, err := os.Stat(keypath); os.IsNotExist(err) {
+					fmt.Printf("Generating new master key file %q, back it up!\n", keypath)
+					if err := vault.GenerateLocalMasterKeyFile(keypath); err != nil {
+						return fmt.Errorf("failed to generate master key file: %w", err)
+					}
+				}
+			}
+			boxer, err = vault.SecretBoxerForType(wrapType, keypath)
+			if err != nil {
+				return err
+			}
+
+		case "age-x25519":
+			boxer, err = vault.NewX25519BoxerFromFile(ageIdentityFile)
+			if err != nil {
+				return fmt.Errorf("failed to load age identity file: %w", err)
+			}
+
 		default:
-			fmt.Printf(`Invalid vault type: %q, please use one of: "passphrase", "kms-gcp"\n`, wrapType)
+			fmt.Printf(`Invalid vault type: %q, please use one of: "passphrase", "kms-gcp", "kms-aws", "vault-transit", "kms-local", "keystore-scrypt", "age-x25519"\n`, wrapType)
 			os.Exit(1)
 		}
 
@@ -147,5 +224,6 @@ func init() {
 	vaultCreateCmd.Flags().IntP("keys", "k", 0, "Number of keypairs to create")
 	vaultCreateCmd.Flags().BoolP("import", "i", false, "Whether to import keys instead of creating them. This takes precedence over --keys, and private keys will be inputted on the command line.")
 	vaultCreateCmd.Flags().StringP("comment", "", "", "Comment field in the vault's json file.")
-	vaultCreateCmd.Flags().StringP("vault-type", "t", "passphrase", "Vault type. One of: passphrase, kms-gcp")
+	vaultCreateCmd.Flags().StringP("vault-type", "t", "passphrase", "Vault type. One of: passphrase, kms-gcp, kms-aws, vault-transit, kms-local, keystore-scrypt, age-x25519")
+	vaultCreateCmd.Flags().StringP("import-dir", "", "", "Import every *.json keypair file (as written by solana-keygen) of this directory. This takes precedence over --import and --keys.")
 }
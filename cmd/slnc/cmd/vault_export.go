@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/xmcontinue/solana-go"
 )
 
 // vaultExportCommand represents the export command
@@ -28,10 +30,24 @@ var vaultExportCommand = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		vault := mustGetWallet()
 
+		if structuredOutput() {
+			var keys []exportedKey
+			for _, key := range vault.KeyBag {
+				keys = append(keys, exportedKey{PublicKey: key.PublicKey(), PrivateKey: key.String()})
+			}
+			errorCheck("printing private keys", printStructured(keys))
+			return
+		}
+
 		vault.PrintPrivateKeys()
 	},
 }
 
+type exportedKey struct {
+	PublicKey  solana.PublicKey
+	PrivateKey string
+}
+
 func init() {
 	vaultCmd.AddCommand(vaultExportCommand)
 }
@@ -0,0 +1,157 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/vanity"
+	"github.com/xmcontinue/solana-go/vault"
+)
+
+var vaultGrindCmd = &cobra.Command{
+	Use:   "grind",
+	Short: "Search for vanity keypairs and add them to the vault, or for vanity CreateWithSeed addresses",
+	Long: `Search for vanity keypairs and add them to the vault, or for vanity CreateWithSeed addresses.
+
+Find a keypair whose address starts with "abc", in any case, and add it to the vault:
+
+    slnc vault grind --prefix abc --ignore-case
+
+Find a seed for which CreateWithSeed(base, seed, owner) ends with "xyz":
+
+    slnc vault grind --suffix xyz --seed-base <base> --seed-owner <owner>
+
+Hit Ctrl-C to stop early; keypairs found so far are still added to the vault.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := vanity.Options{
+			Matcher: vanity.Matcher{
+				Prefix:     viper.GetString("vault-grind-cmd-prefix"),
+				Suffix:     viper.GetString("vault-grind-cmd-suffix"),
+				IgnoreCase: viper.GetBool("vault-grind-cmd-ignore-case"),
+			},
+			Count:            viper.GetInt("vault-grind-cmd-count"),
+			Workers:          viper.GetInt("vault-grind-cmd-workers"),
+			ProgressInterval: 5 * time.Second,
+		}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+		opts.OnProgress = func(p vanity.Progress) {
+			fmt.Fprintf(os.Stderr, "Searched %d addresses in %s (%.0f/s, one match expected every %s), found %d/%d\n",
+				p.Attempts,
+				p.Elapsed.Round(time.Second),
+				p.Rate(),
+				opts.EstimatedDuration(p.Rate()).Round(time.Second),
+				p.Found,
+				opts.Count,
+			)
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+
+		seedBase := viper.GetString("vault-grind-cmd-seed-base")
+		if seedBase != "" {
+			base, err := solana.PublicKeyFromBase58(seedBase)
+			if err != nil {
+				return fmt.Errorf("invalid seed base: %w", err)
+			}
+			owner, err := solana.PublicKeyFromBase58(viper.GetString("vault-grind-cmd-seed-owner"))
+			if err != nil {
+				return fmt.Errorf("invalid seed owner: %w", err)
+			}
+
+			results, err := vanity.GrindSeeds(ctx, base, owner, opts)
+			if structuredOutput() {
+				if printErr := printStructured(results); printErr != nil {
+					return printErr
+				}
+				return err
+			}
+			for _, result := range results {
+				fmt.Printf("- Seed %q creates %s\n", result.Seed, result.Address)
+			}
+			return err
+		}
+
+		walletFile := viper.GetString("global-vault-file")
+		v, err := vault.NewVaultFromWalletFile(walletFile)
+		if err != nil {
+			return fmt.Errorf("unable to load vault file: %w", err)
+		}
+		boxer, err := vault.SecretBoxerForType(v.SecretBoxWrap, boxerKeypath(v.SecretBoxWrap))
+		if err != nil {
+			return fmt.Errorf("unable to intiate boxer: %w", err)
+		}
+		if err := v.Open(boxer); err != nil {
+			return fmt.Errorf("unable to open vault: %w", err)
+		}
+
+		privateKeys, grindErr := vanity.GrindKeypairs(ctx, opts)
+		if len(privateKeys) == 0 {
+			return grindErr
+		}
+
+		var newKeys []solana.PublicKey
+		for _, privateKey := range privateKeys {
+			newKeys = append(newKeys, v.AddPrivateKey(privateKey))
+		}
+		if err := v.Seal(boxer); err != nil {
+			return fmt.Errorf("failed to seal vault: %w", err)
+		}
+		if err := v.WriteToFile(walletFile); err != nil {
+			return fmt.Errorf("failed to write vault file: %w", err)
+		}
+
+		if structuredOutput() {
+			if err := printStructured(struct {
+				WalletFile string
+				AddedKeys  []solana.PublicKey
+				TotalKeys  int
+			}{walletFile, newKeys, len(v.KeyBag)}); err != nil {
+				return err
+			}
+			return grindErr
+		}
+
+		vaultWrittenReport(walletFile, newKeys, len(v.KeyBag))
+		return grindErr
+	},
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultGrindCmd)
+
+	vaultGrindCmd.Flags().String("prefix", "", "Prefix of the addresses to find")
+	vaultGrindCmd.Flags().String("suffix", "", "Suffix of the addresses to find")
+	vaultGrindCmd.Flags().Bool("ignore-case", false, "Match the prefix and suffix case-insensitively")
+	vaultGrindCmd.Flags().Int("count", 1, "Number of addresses to find")
+	vaultGrindCmd.Flags().Int("workers", 0, "Number of parallel workers, defaults to the number of CPUs")
+	vaultGrindCmd.Flags().String("seed-base", "", "Search CreateWithSeed seeds for this base account instead of keypairs")
+	vaultGrindCmd.Flags().String("seed-owner", solana.SystemProgramID.String(), "Owner program of the CreateWithSeed addresses, with --seed-base")
+}
@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/xmcontinue/solana-go"
 )
 
 // vaultListCmd represents the list command
@@ -34,6 +36,15 @@ wallet, unless you check with the "list" command.
 	Run: func(cmd *cobra.Command, args []string) {
 		vault := mustGetWallet()
 
+		if structuredOutput() {
+			var publicKeys []solana.PublicKey
+			for _, key := range vault.KeyBag {
+				publicKeys = append(publicKeys, key.PublicKey())
+			}
+			errorCheck("printing public keys", printStructured(publicKeys))
+			return
+		}
+
 		vault.PrintPublicKeys()
 	},
 }
@@ -0,0 +1,261 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpi rebuilds the tree of the cross-program invocations of a
+// transaction from the inner instructions and the logs of its metadata.
+package cpi
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/treeout"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/programlog"
+	"github.com/xmcontinue/solana-go/rpc"
+	"github.com/xmcontinue/solana-go/text"
+)
+
+// Node is an instruction executed by a transaction: one of its instructions,
+// or an instruction invoked by a program through a cross-program invocation.
+type Node struct {
+	ProgramID solana.PublicKey
+
+	// Depth is 1 for the instructions of the transaction, 2 for the ones they
+	// invoke, and so on.
+	Depth int
+
+	// Index of the instruction of the transaction the node belongs to.
+	Index int
+
+	Accounts []*solana.AccountMeta
+	Data     []byte
+
+	// Decoded is the instruction decoded through `solana.DecodeInstruction`,
+	// DecodeErr is set if that failed.
+	Decoded   interface{}
+	DecodeErr error
+
+	// Invocation is the invocation of the program in the logs, with its logs
+	// and events. It is nil when the logs don't cover the instruction, either
+	// because they are truncated or because the instruction was not
+	// executed, e.g. after a failure. The fields below are then unset.
+	Invocation *programlog.Invocation
+
+	// ComputeUnits consumed by the program, including the programs it invoked.
+	ComputeUnits *uint64
+
+	// Failed is true if the instruction failed, Error being the reason logged
+	// by the runtime, e.g. "custom program error: 0x1".
+	Failed bool
+	Error  string
+
+	Children []*Node
+}
+
+// Tree is the call tree of a transaction.
+type Tree struct {
+	Instructions []*Node
+
+	// LogsTruncated is true when the runtime truncated the logs, in which
+	// case the depth of the inner instructions past the truncation is unknown
+	// and they are attached to their top-level instruction.
+	LogsTruncated bool
+}
+
+// Build returns the call tree of `tx` from the inner instructions and the log
+// messages of `meta`.
+func Build(tx *solana.Transaction, meta *rpc.TransactionMeta) (*Tree, error) {
+	if tx == nil || meta == nil {
+		return nil, fmt.Errorf("transaction and metadata are required")
+	}
+
+	accounts := meta.AccountMetas(tx)
+	logs := programlog.Parse(meta.LogMessages)
+	tree := &Tree{LogsTruncated: logs.Truncated}
+
+	nextInvocation := 0
+	for i, compiled := range tx.Message.Instructions {
+		node, err := newNode(i, 1, compiled, accounts)
+		if err != nil {
+			return nil, err
+		}
+
+		var inv *programlog.Invocation
+		if nextInvocation < len(logs.Invocations) && logs.Invocations[nextInvocation].ProgramID.Equals(node.ProgramID) {
+			inv = logs.Invocations[nextInvocation]
+			nextInvocation++
+			node.setLogged(inv)
+		}
+
+		if err := node.addInnerInstructions(meta, accounts, inv); err != nil {
+			return nil, err
+		}
+		tree.Instructions = append(tree.Instructions, node)
+	}
+
+	return tree, nil
+}
+
+func newNode(index, depth int, compiled solana.CompiledInstruction, accounts []*solana.AccountMeta) (*Node, error) {
+	if int(compiled.ProgramIDIndex) >= len(accounts) {
+		return nil, fmt.Errorf("instruction %d: program index %d out of range", index, compiled.ProgramIDIndex)
+	}
+
+	node := &Node{
+		ProgramID: accounts[compiled.ProgramIDIndex].PublicKey,
+		Depth:     depth,
+		Index:     index,
+		Data:      compiled.Data,
+	}
+	for _, accountIndex := range compiled.Accounts {
+		if int(accountIndex) >= len(accounts) {
+			return nil, fmt.Errorf("instruction %d: account index %d out of range", index, accountIndex)
+		}
+		node.Accounts = append(node.Accounts, accounts[accountIndex])
+	}
+	node.Decoded, node.DecodeErr = solana.DecodeInstruction(node.ProgramID, node.Accounts, node.Data)
+
+	return node, nil
+}
+
+func (n *Node) setLogged(inv *programlog.Invocation) {
+	n.Invocation = inv
+	n.ComputeUnits = inv.ComputeUnits
+	n.Failed = inv.Failed
+	n.Error = inv.Error
+}
+
+// addInnerInstructions attaches the inner instructions of the top-level
+// instruction `n`, at the depth of the matching invocation of the logs.
+func (n *Node) addInnerInstructions(meta *rpc.TransactionMeta, accounts []*solana.AccountMeta, inv *programlog.Invocation) error {
+	var logged []*programlog.Invocation
+	if inv != nil {
+		logged = inv.Flatten()
+	}
+
+	stack := []*Node{n}
+	matching := true
+	for _, inner := range meta.InnerInstructions {
+		if int(inner.Index) != n.Index {
+			continue
+		}
+
+		for j, compiled := range inner.Instructions {
+			node, err := newNode(n.Index, 2, compiled, accounts)
+			if err != nil {
+				return err
+			}
+
+			matching = matching && j < len(logged) && logged[j].ProgramID.Equals(node.ProgramID)
+			if matching {
+				node.Depth = logged[j].Depth
+				node.setLogged(logged[j])
+			}
+
+			for len(stack) > 1 && stack[len(stack)-1].Depth >= node.Depth {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1]
+			node.Depth = parent.Depth + 1
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+		}
+	}
+
+	return nil
+}
+
+// Walk calls `f` on the nodes of the tree, depth first in the order of
+// execution, until it returns false.
+func (t *Tree) Walk(f func(node *Node) bool) {
+	var walk func(nodes []*Node) bool
+	walk = func(nodes []*Node) bool {
+		for _, node := range nodes {
+			if !f(node) || !walk(node.Children) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(t.Instructions)
+}
+
+// Failed returns the deepest failed node, i.e. the nested call that made the
+// transaction fail, or nil.
+func (t *Tree) Failed() *Node {
+	var failed *Node
+	t.Walk(func(node *Node) bool {
+		if node.Failed && (failed == nil || node.Depth > failed.Depth) {
+			failed = node
+		}
+		return true
+	})
+	return failed
+}
+
+// EncodeToTree renders the call tree, each node with its decoded instruction.
+func (t *Tree) EncodeToTree(parent treeout.Branches) {
+	if t.LogsTruncated {
+		parent.Child(text.RedBG("logs truncated, the depth of some invocations is unknown"))
+	}
+	for _, node := range t.Instructions {
+		node.EncodeToTree(parent)
+	}
+}
+
+// EncodeTree writes the call tree to `encoder`.
+func (t *Tree) EncodeTree(encoder *text.TreeEncoder) (int, error) {
+	t.EncodeToTree(encoder)
+	return encoder.WriteString(encoder.Tree.String())
+}
+
+func (n *Node) EncodeToTree(parent treeout.Branches) {
+	header := text.Sf("%s invoke [%d]", text.ColorizeBG(n.ProgramID.String()), n.Depth)
+	if n.ComputeUnits != nil {
+		header += text.Sf(" consumed %d compute units", *n.ComputeUnits)
+	}
+	switch {
+	case n.Failed:
+		reason := n.Error
+		if programErr, ok := n.Invocation.ProgramError(); ok {
+			reason += " (" + programErr.Name + ")"
+		}
+		header += " " + text.RedBG("failed: "+reason)
+	case n.Invocation != nil:
+		header += " " + text.Lime("success")
+	}
+
+	parent.Child(header).ParentFunc(func(nodeBranch treeout.Branches) {
+		if enToTree, ok := n.Decoded.(text.EncodableToTree); ok {
+			enToTree.EncodeToTree(nodeBranch)
+		} else {
+			nodeBranch.Child(text.Sf("data[len=%v bytes]", len(n.Data))).ParentFunc(func(dataBranch treeout.Branches) {
+				dataBranch.Child(bin.FormatByteSlice(n.Data))
+			})
+		}
+
+		if len(n.Children) > 0 {
+			nodeBranch.Child(text.Sf("Invocations[len=%v]", len(n.Children))).ParentFunc(func(invocationsBranch treeout.Branches) {
+				for _, child := range n.Children {
+					child.EncodeToTree(invocationsBranch)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpi
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/treeout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/programs/system"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+var (
+	payer     = solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	recipient = solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	programA  = solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	programB  = solana.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+	programC  = solana.MustPublicKeyFromBase58("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+)
+
+// testTransaction returns a transaction calling A then C, A invoking a
+// transfer of the System program, then B which invokes another transfer.
+func testTransaction(t *testing.T) (*solana.Transaction, []rpc.InnerInstruction) {
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(programA, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(recipient).WRITE(),
+			solana.Meta(solana.SystemProgramID),
+			solana.Meta(programB),
+		}, []byte{1}),
+		solana.NewInstruction(programC, nil, []byte{2}),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	transfer, err := system.NewTransferInstruction(1, payer, recipient).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err := transfer.Data()
+	require.NoError(t, err)
+
+	index := func(pubkey solana.PublicKey) uint16 {
+		for i, key := range tx.Message.AccountKeys {
+			if key.Equals(pubkey) {
+				return uint16(i)
+			}
+		}
+		t.Fatalf("account %s not found", pubkey)
+		return 0
+	}
+	transferInst := solana.CompiledInstruction{
+		ProgramIDIndex: index(solana.SystemProgramID),
+		Accounts:       []uint16{index(payer), index(recipient)},
+		Data:           data,
+	}
+
+	return tx, []rpc.InnerInstruction{{
+		Index: 0,
+		Instructions: []solana.CompiledInstruction{
+			transferInst,
+			{ProgramIDIndex: index(programB), Accounts: []uint16{index(payer), index(recipient)}, Data: []byte{3}},
+			transferInst,
+		},
+	}}
+}
+
+func TestBuild(t *testing.T) {
+	tx, inner := testTransaction(t)
+	meta := &rpc.TransactionMeta{
+		InnerInstructions: inner,
+		LogMessages: []string{
+			"Program " + programA.String() + " invoke [1]",
+			"Program log: Instruction: Pay",
+			"Program 11111111111111111111111111111111 invoke [2]",
+			"Program 11111111111111111111111111111111 success",
+			"Program " + programB.String() + " invoke [2]",
+			"Program 11111111111111111111111111111111 invoke [3]",
+			"Transfer: insufficient lamports 0, need 1",
+			"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+			"Program " + programB.String() + " consumed 1200 of 195000 compute units",
+			"Program " + programB.String() + " failed: custom program error: 0x1",
+			"Program " + programA.String() + " consumed 5000 of 200000 compute units",
+			"Program " + programA.String() + " failed: custom program error: 0x1",
+		},
+	}
+
+	tree, err := Build(tx, meta)
+	require.NoError(t, err)
+	require.False(t, tree.LogsTruncated)
+	require.Len(t, tree.Instructions, 2)
+
+	a := tree.Instructions[0]
+	assert.Equal(t, programA, a.ProgramID)
+	assert.Equal(t, 1, a.Depth)
+	assert.NotNil(t, a.Invocation)
+	assert.True(t, a.Failed)
+	assert.Equal(t, "custom program error: 0x1", a.Error)
+	require.NotNil(t, a.ComputeUnits)
+	assert.Equal(t, uint64(5000), *a.ComputeUnits)
+	assert.Error(t, a.DecodeErr)
+	require.Len(t, a.Children, 2)
+
+	transfer := a.Children[0]
+	assert.Equal(t, solana.SystemProgramID, transfer.ProgramID)
+	assert.Equal(t, 2, transfer.Depth)
+	assert.NotNil(t, transfer.Invocation)
+	assert.False(t, transfer.Failed)
+	assert.Nil(t, transfer.ComputeUnits)
+	require.IsType(t, &system.Instruction{}, transfer.Decoded)
+	assert.Equal(t, uint64(1), *transfer.Decoded.(*system.Instruction).Impl.(*system.Transfer).Lamports)
+
+	b := a.Children[1]
+	assert.Equal(t, programB, b.ProgramID)
+	assert.Equal(t, 2, b.Depth)
+	assert.Equal(t, uint64(1200), *b.ComputeUnits)
+	assert.True(t, b.Failed)
+	require.Len(t, b.Children, 1)
+	assert.Equal(t, 3, b.Children[0].Depth)
+	assert.Equal(t, 0, b.Children[0].Index)
+
+	assert.Same(t, b.Children[0], tree.Failed())
+
+	c := tree.Instructions[1]
+	assert.Equal(t, programC, c.ProgramID)
+	assert.Equal(t, 1, c.Index)
+	assert.Nil(t, c.Invocation)
+	assert.Empty(t, c.Children)
+
+	root := treeout.New("Tree")
+	tree.EncodeToTree(root)
+	rendered := root.String()
+	assert.Contains(t, rendered, "consumed 1200 compute units")
+	assert.Contains(t, rendered, "failed: custom program error: 0x1 (ResultWithNegativeLamports)")
+	assert.Contains(t, rendered, "Lamports")
+}
+
+func TestBuild_TruncatedLogs(t *testing.T) {
+	tx, inner := testTransaction(t)
+	meta := &rpc.TransactionMeta{
+		InnerInstructions: inner,
+		LogMessages: []string{
+			"Program " + programA.String() + " invoke [1]",
+			"Program 11111111111111111111111111111111 invoke [2]",
+			"Program 11111111111111111111111111111111 success",
+			"Log truncated",
+		},
+	}
+
+	tree, err := Build(tx, meta)
+	require.NoError(t, err)
+	require.True(t, tree.LogsTruncated)
+
+	a := tree.Instructions[0]
+	assert.NotNil(t, a.Invocation)
+	assert.False(t, a.Failed)
+	// Past the truncation, the inner instructions are attached to A.
+	require.Len(t, a.Children, 3)
+	assert.NotNil(t, a.Children[0].Invocation)
+	assert.Nil(t, a.Children[1].Invocation)
+	assert.Nil(t, a.Children[2].Invocation)
+	assert.Nil(t, tree.Failed())
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tx, _ := testTransaction(t)
+	_, err := Build(tx, nil)
+	require.Error(t, err)
+
+	_, err = Build(tx, &rpc.TransactionMeta{InnerInstructions: []rpc.InnerInstruction{
+		{Index: 0, Instructions: []solana.CompiledInstruction{{ProgramIDIndex: 42}}},
+	}})
+	require.Error(t, err)
+}
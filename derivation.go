@@ -0,0 +1,133 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SLIP-0010 ed25519 key derivation, see https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+// Only hardened derivation is defined for ed25519.
+
+// HardenedOffset is added to an index to make it hardened.
+const HardenedOffset uint32 = 0x80000000
+
+// SolanaCoinType is the SLIP-0044 coin type of Solana.
+const SolanaCoinType = 501
+
+// DerivationPath is a list of child indexes, from the master key.
+type DerivationPath []uint32
+
+// SolanaDerivationPath returns the m/44'/501'/account'/0' path used by
+// Phantom, Solflare and `solana-keygen` (`prompt://?key=account/0`).
+func SolanaDerivationPath(account uint32) DerivationPath {
+	return DerivationPath{
+		44 + HardenedOffset,
+		SolanaCoinType + HardenedOffset,
+		account + HardenedOffset,
+		0 + HardenedOffset,
+	}
+}
+
+// ParseDerivationPath parses a path like m/44'/501'/0'/0'. Hardened indexes
+// are marked with a trailing ' or h. The leading "m/" is optional.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	path = strings.TrimSpace(path)
+	if path == "m" || path == "" {
+		return DerivationPath{}, nil
+	}
+	path = strings.TrimPrefix(path, "m/")
+
+	parts := strings.Split(path, "/")
+	out := make(DerivationPath, len(parts))
+	for i, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		idx, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(idx) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid derivation path %q: invalid index %q", path, parts[i])
+		}
+		out[i] = uint32(idx)
+		if hardened {
+			out[i] += HardenedOffset
+		}
+	}
+	return out, nil
+}
+
+func (p DerivationPath) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, idx := range p {
+		b.WriteString("/")
+		if idx >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(idx-HardenedOffset), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(idx), 10))
+		}
+	}
+	return b.String()
+}
+
+// DeriveEd25519Key derives the SLIP-0010 ed25519 private key seed and
+// chain code at `path` from the BIP39 `seed`. All indexes of `path` must
+// be hardened.
+func DeriveEd25519Key(seed []byte, path DerivationPath) (key []byte, chainCode []byte, err error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, nil, fmt.Errorf("invalid seed length: %d bytes, expected 16 to 64", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode = sum[:32], sum[32:]
+
+	for _, idx := range path {
+		if idx < HardenedOffset {
+			return nil, nil, fmt.Errorf("invalid derivation path %s: ed25519 only supports hardened indexes", path)
+		}
+
+		data := make([]byte, 1+32+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[33:], idx)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return key, chainCode, nil
+}
+
+// PrivateKeyFromSeedPath derives the keypair at `path` from the BIP39 `seed`.
+func PrivateKeyFromSeedPath(seed []byte, path DerivationPath) (PrivateKey, error) {
+	key, _, err := DeriveEd25519Key(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return PrivateKey(ed25519.NewKeyFromSeed(key)), nil
+}
@@ -75,6 +75,9 @@ func (k Kind) String() string {
 type Path cmp.Path
 
 func (pa Path) SliceIndex() (int, bool) {
+	if len(pa) == 0 {
+		return 0, false
+	}
 	last := pa[len(pa)-1]
 	if slcIdx, ok := last.(cmp.SliceIndex); ok {
 		xkey, ykey := slcIdx.SplitKeys()
@@ -96,7 +99,7 @@ func (pa Path) SliceIndex() (int, bool) {
 }
 
 func (pa Path) String() string {
-	if len(pa) == 1 {
+	if len(pa) <= 1 {
 		return ""
 	}
 
@@ -108,6 +111,37 @@ type Event struct {
 	Kind Kind
 	Old  reflect.Value
 	New  reflect.Value
+
+	// The steps of `Path` are reused by the comparison once the event has been
+	// reported, so what is needed from them is captured beforehand.
+	captured      bool
+	pathString    string
+	sliceIndex    int
+	hasSliceIndex bool
+}
+
+func newEvent(kind Kind, path cmp.Path, old, new reflect.Value) Event {
+	event := Event{Kind: kind, Path: copyPath(path), Old: old, New: new, captured: true}
+	event.pathString = event.Path.String()
+	event.sliceIndex, event.hasSliceIndex = event.Path.SliceIndex()
+	return event
+}
+
+// PathString returns the string representation of the path of the event,
+// it remains valid after the diff completed, unlike `Path`.
+func (p *Event) PathString() string {
+	if p.captured {
+		return p.pathString
+	}
+	return p.Path.String()
+}
+
+// SliceIndex is `Path.SliceIndex()`, it remains valid after the diff completed.
+func (p *Event) SliceIndex() (int, bool) {
+	if p.captured {
+		return p.sliceIndex, p.hasSliceIndex
+	}
+	return p.Path.SliceIndex()
 }
 
 // Match currently simply ensure that `pattern` parameter is the start of the path string
@@ -121,7 +155,7 @@ func (p *Event) Match(pattern string) (match bool, matches []string) {
 
 func (p *Event) RawMatch(rawPattern string) (match bool, matches []string) {
 	regex := regexp.MustCompile(rawPattern)
-	regexMatch := regex.FindAllStringSubmatch(p.Path.String(), 1)
+	regexMatch := regex.FindAllStringSubmatch(p.PathString(), 1)
 	if len(regexMatch) != 1 {
 		return false, nil
 	}
@@ -160,7 +194,7 @@ func (p *Event) Element() reflect.Value {
 func (p *Event) String() string {
 	path := ""
 	if len(p.Path) > 1 {
-		path = " @ " + p.Path.String()
+		path = " @ " + p.PathString()
 	}
 
 	return fmt.Sprintf("%s => %s (%s%s)", reflectValueToString(p.Old), reflectValueToString(p.New), p.Kind, path)
@@ -233,7 +267,7 @@ func (r *diffReporter) Report(rs cmp.Result) {
 			}
 
 			// Left is not set but right is, we have added "right"
-			r.notify(Event{Kind: KindAdded, Path: Path(r.path), New: vRight})
+			r.notify(newEvent(KindAdded, r.path, reflect.Value{}, vRight))
 			return
 		}
 
@@ -243,7 +277,7 @@ func (r *diffReporter) Report(rs cmp.Result) {
 			}
 
 			// Left is set but right is not, we have removed "left"
-			r.notify(Event{Kind: KindRemoved, Path: Path(r.path), Old: vLeft})
+			r.notify(newEvent(KindRemoved, r.path, vLeft, reflect.Value{}))
 			return
 		}
 
@@ -253,8 +287,8 @@ func (r *diffReporter) Report(rs cmp.Result) {
 				zlog.Debug("array changed event, splitting in removed, added", zap.Stringer("path", r.path))
 			}
 
-			r.notify(Event{Kind: KindRemoved, Path: Path(r.path), Old: vLeft})
-			r.notify(Event{Kind: KindAdded, Path: Path(r.path), New: vRight})
+			r.notify(newEvent(KindRemoved, r.path, vLeft, reflect.Value{}))
+			r.notify(newEvent(KindAdded, r.path, reflect.Value{}, vRight))
 			return
 		}
 
@@ -262,7 +296,7 @@ func (r *diffReporter) Report(rs cmp.Result) {
 			zlog.Debug("changed event", zap.Stringer("path", r.path))
 		}
 
-		r.notify(Event{Kind: KindChanged, Path: Path(r.path), Old: vLeft, New: vRight})
+		r.notify(newEvent(KindChanged, r.path, vLeft, vRight))
 	}
 }
 
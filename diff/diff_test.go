@@ -191,10 +191,23 @@ func TestDiff_EventMatch(t *testing.T) {
 	}
 }
 
-// There is something inherently broken with this, the accumulation seems to broke leading to incorrect
-// results. I assume it's a Golang thing related to slice and struct as value versus pointers. It works
-// only single event but starts to act weirdly when there > 1, like the Event's Path is all wrong. It's
-// better to try to avoid it when possible.
+func TestDiff_AccumulatedEventPaths(t *testing.T) {
+	events := accumulateDiff(
+		&topStruct{Literal: "a", Child: &childStruct{Array: []string{"1"}}},
+		&topStruct{Literal: "b", Child: &childStruct{Array: []string{"1", "2"}}},
+	)
+
+	var paths []string
+	for _, event := range events {
+		paths = append(paths, event.PathString())
+	}
+	assert.Equal(t, []string{"Literal", "Child.Array[?->1]"}, paths)
+
+	index, ok := events[1].SliceIndex()
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+}
+
 func accumulateDiff(left, right interface{}) (out []Event) {
 	Diff(left, right, OnEvent(func(event Event) {
 		out = append(out, event)
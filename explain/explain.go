@@ -0,0 +1,317 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package explain turns a transaction and its metadata into plain statements
+// such as "A transferred 1.5 SOL to B", for people who don't read instruction
+// trees. Program packages register an `Explainer` for their instructions.
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// Options of `Explain`, a nil `*Options` is valid.
+type Options struct {
+	// Labels replace the base58 representation of well known accounts and
+	// mints in the statements, e.g. the USDC mint labeled "USDC".
+	Labels map[solana.PublicKey]string
+}
+
+var defaultLabels = map[solana.PublicKey]string{
+	solana.SolMint:                            "SOL",
+	solana.SystemProgramID:                    "the System program",
+	solana.TokenProgramID:                     "the Token program",
+	solana.Token2022ProgramID:                 "the Token-2022 program",
+	solana.SPLAssociatedTokenAccountProgramID: "the Associated Token Account program",
+	solana.MemoProgramID:                      "the Memo program",
+	solana.VoteProgramID:                      "the Vote program",
+	solana.StakeProgramID:                     "the Stake program",
+}
+
+// Instruction is an instruction of the explained transaction, either a
+// top-level one or one of its inner instructions.
+type Instruction struct {
+	// Index of the top-level instruction.
+	Index     int
+	ProgramID solana.PublicKey
+	Accounts  []*solana.AccountMeta
+	Data      []byte
+
+	// Decoded is the instruction decoded through `solana.DecodeInstruction`,
+	// nil if there is no decoder for the program or if decoding failed.
+	Decoded interface{}
+}
+
+// Context gives explainers access to the transaction, to the balance changes
+// recorded in its metadata and to the formatting of accounts and amounts.
+type Context struct {
+	Transaction *solana.Transaction
+
+	// Meta is nil when the transaction metadata is not available, in which
+	// case all the balance deltas are zero.
+	Meta *rpc.TransactionMeta
+
+	// Changes are the balance changes recorded in `Meta`, empty without it.
+	Changes *rpc.BalanceChanges
+
+	// Accounts are the accounts of the transaction, including the ones loaded
+	// from address lookup tables, in the order of the balances of `Meta`.
+	Accounts []*solana.AccountMeta
+
+	labels map[solana.PublicKey]string
+}
+
+// NewContext returns the Context of the transaction, `meta` and `opts` can be nil.
+func NewContext(tx *solana.Transaction, meta *rpc.TransactionMeta, opts *Options) (*Context, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+
+	ctx := &Context{
+		Transaction: tx,
+		Meta:        meta,
+		labels:      make(map[solana.PublicKey]string, len(defaultLabels)),
+	}
+	for pubkey, label := range defaultLabels {
+		ctx.labels[pubkey] = label
+	}
+	if opts != nil {
+		for pubkey, label := range opts.Labels {
+			ctx.labels[pubkey] = label
+		}
+	}
+
+	ctx.Changes = &rpc.BalanceChanges{}
+	if meta == nil {
+		ctx.Accounts = (&rpc.TransactionMeta{}).AccountMetas(tx)
+	} else {
+		ctx.Accounts = meta.AccountMetas(tx)
+		changes, err := meta.BalanceChanges(tx)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Changes = changes
+	}
+
+	return ctx, nil
+}
+
+// Label returns the label of `pubkey`, or its base58 representation.
+func (c *Context) Label(pubkey solana.PublicKey) string {
+	if label, ok := c.labels[pubkey]; ok {
+		return label
+	}
+	return pubkey.String()
+}
+
+// FeePayer returns the account that paid the fees of the transaction.
+func (c *Context) FeePayer() solana.PublicKey {
+	if len(c.Accounts) == 0 {
+		return solana.PublicKey{}
+	}
+	return c.Accounts[0].PublicKey
+}
+
+func (c *Context) accountIndex(account solana.PublicKey) int {
+	for i, meta := range c.Accounts {
+		if meta.PublicKey.Equals(account) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LamportsDelta returns the change of the lamports balance of `account`,
+// without the fee paid by the fee payer.
+func (c *Context) LamportsDelta(account solana.PublicKey) int64 {
+	return c.Changes.AccountDelta(account)
+}
+
+// TokenAccount is a token account of the transaction, as described by the
+// token balances of its metadata.
+type TokenAccount struct {
+	Address  solana.PublicKey
+	Mint     solana.PublicKey
+	Owner    solana.PublicKey
+	Decimals uint8
+}
+
+// TokenAccount returns the mint, owner and decimals of the token account
+// `account`, it returns false if the metadata holds no balance for it.
+func (c *Context) TokenAccount(account solana.PublicKey) (TokenAccount, bool) {
+	if c.Meta == nil {
+		return TokenAccount{}, false
+	}
+	idx := c.accountIndex(account)
+	for _, balances := range [][]rpc.TokenBalance{c.Meta.PostTokenBalances, c.Meta.PreTokenBalances} {
+		for _, balance := range balances {
+			if int(balance.AccountIndex) != idx {
+				continue
+			}
+			out := TokenAccount{Address: account, Mint: balance.Mint}
+			if balance.Owner != nil {
+				out.Owner = *balance.Owner
+			}
+			if balance.UiTokenAmount != nil {
+				out.Decimals = balance.UiTokenAmount.Decimals
+			}
+			return out, true
+		}
+	}
+	return TokenAccount{}, false
+}
+
+// DescribeSwap describes the balance changes of `owner` as a swap, e.g.
+// "10 USDC for 0.05 SOL", it returns false if they aren't exactly one
+// decrease and one increase. Native SOL counts as a token, to cover the
+// wrapped SOL accounts created and closed within the transaction.
+func (c *Context) DescribeSwap(owner solana.PublicKey) (string, bool) {
+	deltas := c.Changes.TokensOf(owner)
+
+	hasSOL := false
+	for _, delta := range deltas {
+		hasSOL = hasSOL || delta.Mint.Equals(solana.SolMint)
+	}
+	if lamports := c.LamportsDelta(owner); !hasSOL && lamports != 0 {
+		deltas = append(deltas, rpc.TokenBalanceChange{Owner: owner, Mint: solana.SolMint, Decimals: 9, Delta: big.NewInt(lamports)})
+	}
+
+	if len(deltas) != 2 || deltas[0].Delta.Sign() == deltas[1].Delta.Sign() {
+		return "", false
+	}
+	sent, received := deltas[0], deltas[1]
+	if sent.Delta.Sign() > 0 {
+		sent, received = received, sent
+	}
+
+	return fmt.Sprintf("%s for %s",
+		c.FormatToken(new(big.Int).Neg(sent.Delta), sent.Decimals, sent.Mint),
+		c.FormatToken(received.Delta, received.Decimals, received.Mint),
+	), true
+}
+
+// FormatSOL formats `lamports` as an amount of SOL, e.g. "1.5 SOL".
+func (c *Context) FormatSOL(lamports uint64) string {
+	return solana.FormatUiAmount(new(big.Int).SetUint64(lamports), 9) + " SOL"
+}
+
+// FormatToken formats `amount` of the smallest units of `mint`, e.g. "10 USDC".
+func (c *Context) FormatToken(amount *big.Int, decimals uint8, mint solana.PublicKey) string {
+	return solana.FormatUiAmount(amount, decimals) + " " + c.Label(mint)
+}
+
+func (c *Context) instruction(index int, compiled solana.CompiledInstruction) (*Instruction, error) {
+	if int(compiled.ProgramIDIndex) >= len(c.Accounts) {
+		return nil, fmt.Errorf("instruction %d: program index %d out of range", index, compiled.ProgramIDIndex)
+	}
+
+	inst := &Instruction{
+		Index:     index,
+		ProgramID: c.Accounts[compiled.ProgramIDIndex].PublicKey,
+		Data:      compiled.Data,
+	}
+	for _, accountIndex := range compiled.Accounts {
+		if int(accountIndex) >= len(c.Accounts) {
+			return nil, fmt.Errorf("instruction %d: account index %d out of range", index, accountIndex)
+		}
+		inst.Accounts = append(inst.Accounts, c.Accounts[accountIndex])
+	}
+
+	if decoded, err := solana.DecodeInstruction(inst.ProgramID, inst.Accounts, inst.Data); err == nil {
+		inst.Decoded = decoded
+	}
+
+	return inst, nil
+}
+
+func (c *Context) explain(inst *Instruction) []string {
+	explainer, found := explainerRegistry.Get(inst.ProgramID)
+	if !found {
+		return nil
+	}
+	return explainer(c, inst)
+}
+
+// Explain returns the statements describing the transaction, in the order of
+// its instructions, followed by the fee and, if it failed, its error.
+//
+// An instruction of a program without an `Explainer` is explained by its inner
+// instructions, or by a generic statement when they don't explain anything either.
+func Explain(tx *solana.Transaction, meta *rpc.TransactionMeta, opts *Options) ([]string, error) {
+	ctx, err := NewContext(tx, meta, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for i, compiled := range tx.Message.Instructions {
+		inst, err := ctx.instruction(i, compiled)
+		if err != nil {
+			return nil, err
+		}
+
+		statements := ctx.explain(inst)
+		if len(statements) == 0 {
+			statements, err = ctx.explainInner(i)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(statements) == 0 {
+			statements = []string{fmt.Sprintf("instruction #%d called %s", i+1, ctx.Label(inst.ProgramID))}
+		}
+		out = append(out, statements...)
+	}
+
+	if meta != nil {
+		out = append(out, fmt.Sprintf("%s paid %s in fees", ctx.Label(ctx.FeePayer()), ctx.FormatSOL(meta.Fee)))
+		if txErr, err := meta.TransactionError(tx); err != nil {
+			cnt, _ := json.Marshal(meta.Err)
+			out = append(out, fmt.Sprintf("the transaction failed: %s", cnt))
+		} else if txErr != nil {
+			out = append(out, fmt.Sprintf("the transaction failed: %s", txErr))
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Context) explainInner(index int) (out []string, err error) {
+	if c.Meta == nil {
+		return nil, nil
+	}
+
+	for _, inner := range c.Meta.InnerInstructions {
+		if int(inner.Index) != index {
+			continue
+		}
+		for _, compiled := range inner.Instructions {
+			inst, err := c.instruction(index, compiled)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, c.explain(inst)...)
+		}
+	}
+
+	return out, nil
+}
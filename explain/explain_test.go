@@ -0,0 +1,206 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+var (
+	payer      = solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	recipient  = solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	usdc       = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	testProgID = solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	unknownID  = solana.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+)
+
+func init() {
+	RegisterExplainer(testProgID, func(ctx *Context, inst *Instruction) []string {
+		from, to := inst.Accounts[0].PublicKey, inst.Accounts[1].PublicKey
+		return []string{fmt.Sprintf("%s paid %s", ctx.Label(to), ctx.FormatSOL(uint64(ctx.LamportsDelta(from)*-1)))}
+	})
+}
+
+func TestRegisterExplainer(t *testing.T) {
+	explainer := func(ctx *Context, inst *Instruction) []string { return nil }
+	programID := solana.MustPublicKeyFromBase58("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+
+	RegisterExplainer(programID, explainer)
+	RegisterExplainer(programID, explainer)
+	assert.Panics(t, func() {
+		RegisterExplainer(programID, func(ctx *Context, inst *Instruction) []string { return nil })
+	})
+}
+
+func TestExplain(t *testing.T) {
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(testProgID, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(recipient).WRITE(),
+		}, []byte{1}),
+		solana.NewInstruction(unknownID, nil, []byte{2}),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+	require.Equal(t, solana.PublicKeySlice{payer, recipient, testProgID, unknownID}, solana.PublicKeySlice(tx.Message.AccountKeys))
+
+	meta := &rpc.TransactionMeta{
+		Fee:          5000,
+		PreBalances:  []uint64{3000005000, 0, 1, 1},
+		PostBalances: []uint64{1500000000, 1500000000, 1, 1},
+		Err:          map[string]interface{}{"InstructionError": []interface{}{1, "InvalidAccountData"}},
+	}
+
+	statements, err := Explain(tx, meta, &Options{Labels: map[solana.PublicKey]string{payer: "alice", recipient: "bob"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"bob paid 1.5 SOL",
+		"instruction #2 called " + unknownID.String(),
+		"alice paid 0.000005 SOL in fees",
+		"the transaction failed: Error processing Instruction 1: InvalidAccountData",
+	}, statements)
+
+	statements, err = Explain(tx, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		recipient.String() + " paid 0 SOL",
+		"instruction #2 called " + unknownID.String(),
+	}, statements)
+
+	_, err = Explain(tx, &rpc.TransactionMeta{PostBalances: []uint64{1}}, nil)
+	require.Error(t, err)
+}
+
+func TestExplain_InnerInstructions(t *testing.T) {
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(unknownID, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(recipient).WRITE(),
+			solana.Meta(testProgID),
+		}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	meta := &rpc.TransactionMeta{
+		PreBalances:  []uint64{2000000000, 0, 1, 1},
+		PostBalances: []uint64{1000000000, 1000000000, 1, 1},
+		InnerInstructions: []rpc.InnerInstruction{
+			{Index: 0, Instructions: []solana.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint16{0, 1}}}},
+		},
+	}
+	require.Equal(t, testProgID, tx.Message.AccountKeys[2])
+
+	statements, err := Explain(tx, meta, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		recipient.String() + " paid 1 SOL",
+		payer.String() + " paid 0 SOL in fees",
+	}, statements)
+}
+
+func TestContext_LoadedAddresses(t *testing.T) {
+	table := solana.MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(testProgID, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(recipient).WRITE(),
+			solana.Meta(usdc),
+		}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer), solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{
+		table: {recipient, usdc},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, solana.PublicKeySlice{payer, testProgID}, solana.PublicKeySlice(tx.Message.AccountKeys))
+
+	meta := &rpc.TransactionMeta{
+		PreBalances:     []uint64{10, 1, 0, 7},
+		PostBalances:    []uint64{5, 1, 5, 7},
+		LoadedAddresses: rpc.LoadedAddresses{Writable: solana.PublicKeySlice{recipient}, ReadOnly: solana.PublicKeySlice{usdc}},
+	}
+
+	ctx, err := NewContext(tx, meta, nil)
+	require.NoError(t, err)
+	require.Len(t, ctx.Accounts, 4)
+	assert.Equal(t, &solana.AccountMeta{PublicKey: payer, IsSigner: true, IsWritable: true}, ctx.Accounts[0])
+	assert.Equal(t, &solana.AccountMeta{PublicKey: testProgID}, ctx.Accounts[1])
+	assert.Equal(t, &solana.AccountMeta{PublicKey: recipient, IsWritable: true}, ctx.Accounts[2])
+	assert.Equal(t, &solana.AccountMeta{PublicKey: usdc}, ctx.Accounts[3])
+	assert.Equal(t, int64(-5), ctx.LamportsDelta(payer))
+	assert.Equal(t, int64(5), ctx.LamportsDelta(recipient))
+	assert.Equal(t, int64(0), ctx.LamportsDelta(usdc))
+
+	// Resolved lookups are already part of the account keys.
+	require.NoError(t, tx.Message.ResolveLookups())
+	ctx, err = NewContext(tx, meta, nil)
+	require.NoError(t, err)
+	require.Len(t, ctx.Accounts, 4)
+	assert.Equal(t, int64(5), ctx.LamportsDelta(recipient))
+}
+
+func tokenBalance(index uint16, owner, mint solana.PublicKey, amount string, decimals uint8) rpc.TokenBalance {
+	return rpc.TokenBalance{
+		AccountIndex:  index,
+		Owner:         &owner,
+		Mint:          mint,
+		UiTokenAmount: &rpc.UiTokenAmount{Amount: amount, Decimals: decimals},
+	}
+}
+
+func TestContext_Tokens(t *testing.T) {
+	usdcAccount := solana.MustPublicKeyFromBase58("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(testProgID, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(usdcAccount).WRITE(),
+		}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	meta := &rpc.TransactionMeta{
+		Fee:               5000,
+		PreBalances:       []uint64{1000005000, 2039280, 1},
+		PostBalances:      []uint64{1050000000, 2039280, 1},
+		PreTokenBalances:  []rpc.TokenBalance{tokenBalance(1, payer, usdc, "25000000", 6)},
+		PostTokenBalances: []rpc.TokenBalance{tokenBalance(1, payer, usdc, "15000000", 6)},
+	}
+	ctx, err := NewContext(tx, meta, &Options{Labels: map[solana.PublicKey]string{usdc: "USDC"}})
+	require.NoError(t, err)
+
+	account, found := ctx.TokenAccount(usdcAccount)
+	require.True(t, found)
+	assert.Equal(t, TokenAccount{Address: usdcAccount, Mint: usdc, Owner: payer, Decimals: 6}, account)
+	_, found = ctx.TokenAccount(payer)
+	assert.False(t, found)
+
+	assert.Equal(t, int64(50000000), ctx.LamportsDelta(payer))
+	assert.Equal(t, big.NewInt(-10000000), ctx.Changes.TokenDelta(payer, usdc))
+
+	swap, ok := ctx.DescribeSwap(payer)
+	require.True(t, ok)
+	assert.Equal(t, "10 USDC for 0.05 SOL", swap)
+
+	_, ok = ctx.DescribeSwap(recipient)
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// Explainer turns one instruction of a transaction into plain statements
+// like "A transferred 1.5 SOL to B". It returns nil when it has nothing
+// to say about the instruction, in which case a generic statement is used.
+type Explainer func(ctx *Context, inst *Instruction) []string
+
+var explainerRegistry = &registry{
+	mu:         &sync.RWMutex{},
+	explainers: make(map[solana.PublicKey]Explainer),
+}
+
+type registry struct {
+	mu         *sync.RWMutex
+	explainers map[solana.PublicKey]Explainer
+}
+
+func (reg *registry) Get(programID solana.PublicKey) (Explainer, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	explainer, ok := reg.explainers[programID]
+	return explainer, ok
+}
+
+// RegisterExplainer registers the explanation hook of the instructions of `programID`.
+// Registering the same hook twice is tolerated, registering another hook for the
+// same program panics, like `solana.RegisterInstructionDecoder`.
+func RegisterExplainer(programID solana.PublicKey, explainer Explainer) {
+	if explainer == nil {
+		panic(fmt.Sprintf("nil explainer for program %s", programID))
+	}
+
+	explainerRegistry.mu.Lock()
+	defer explainerRegistry.mu.Unlock()
+
+	if prev, has := explainerRegistry.explainers[programID]; has {
+		if reflect.ValueOf(prev).Pointer() == reflect.ValueOf(explainer).Pointer() {
+			return
+		}
+		panic(fmt.Sprintf("unable to re-register explainer for program %s", programID))
+	}
+	explainerRegistry.explainers[programID] = explainer
+}
@@ -28,6 +28,7 @@ import (
 	"io/ioutil"
 	"math"
 	"sort"
+	"strconv"
 
 	"filippo.io/edwards25519"
 	"github.com/mr-tron/base58"
@@ -648,6 +649,23 @@ func FindAssociatedTokenAddress(
 	return findAssociatedTokenAddressAndBumpSeed(
 		wallet,
 		mint,
+		TokenProgramID,
+		SPLAssociatedTokenAccountProgramID,
+	)
+}
+
+// FindAssociatedTokenAddressWithTokenProgram returns the associated token account address
+// of the provided wallet and mint, for mints owned by the provided token program
+// (e.g. Token2022ProgramID).
+func FindAssociatedTokenAddressWithTokenProgram(
+	wallet PublicKey,
+	mint PublicKey,
+	tokenProgramID PublicKey,
+) (PublicKey, uint8, error) {
+	return findAssociatedTokenAddressAndBumpSeed(
+		wallet,
+		mint,
+		tokenProgramID,
 		SPLAssociatedTokenAccountProgramID,
 	)
 }
@@ -655,11 +673,12 @@ func FindAssociatedTokenAddress(
 func findAssociatedTokenAddressAndBumpSeed(
 	walletAddress PublicKey,
 	splTokenMintAddress PublicKey,
+	tokenProgramID PublicKey,
 	programID PublicKey,
 ) (PublicKey, uint8, error) {
 	return FindProgramAddress([][]byte{
 		walletAddress[:],
-		TokenProgramID[:],
+		tokenProgramID[:],
 		splTokenMintAddress[:],
 	},
 		programID,
@@ -675,3 +694,36 @@ func FindTokenMetadataAddress(mint PublicKey) (PublicKey, uint8, error) {
 	}
 	return FindProgramAddress(seed, TokenMetadataProgramID)
 }
+
+// FindTokenMasterEditionAddress returns the token metadata master edition program-derived address
+// given a SPL token mint address.
+func FindTokenMasterEditionAddress(mint PublicKey) (PublicKey, uint8, error) {
+	seed := [][]byte{
+		[]byte("metadata"),
+		TokenMetadataProgramID[:],
+		mint[:],
+		[]byte("edition"),
+	}
+	return FindProgramAddress(seed, TokenMetadataProgramID)
+}
+
+// FindTokenEditionAddress returns the token metadata edition program-derived address
+// given the SPL token mint address of a printed edition.
+// Master editions and printed editions share the same derivation.
+func FindTokenEditionAddress(mint PublicKey) (PublicKey, uint8, error) {
+	return FindTokenMasterEditionAddress(mint)
+}
+
+// FindTokenEditionMarkerAddress returns the token metadata edition marker program-derived address
+// given the SPL token mint address of a master edition and the number of a printed edition.
+func FindTokenEditionMarkerAddress(masterMint PublicKey, edition uint64) (PublicKey, uint8, error) {
+	// Each edition marker tracks 248 editions.
+	seed := [][]byte{
+		[]byte("metadata"),
+		TokenMetadataProgramID[:],
+		masterMint[:],
+		[]byte("edition"),
+		[]byte(strconv.FormatUint(edition/248, 10)),
+	}
+	return FindProgramAddress(seed, TokenMetadataProgramID)
+}
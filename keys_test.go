@@ -618,3 +618,85 @@ func TestFindTokenMetadataAddress(t *testing.T) {
 	assert.Equal(t, metadataPDA, MustPublicKeyFromBase58("GfihrEYCPrvUyrMyMQPdhGEStxa9nKEK2Wfn9iK4AZq2"))
 	assert.Equal(t, bumpSeed, uint8(0xfd))
 }
+
+func TestFindTokenMasterEditionAddress(t *testing.T) {
+	mint := MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	masterEditionPDA, bumpSeed, err := FindTokenMasterEditionAddress(mint)
+	require.NoError(t, err)
+
+	expected, err := CreateProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			TokenMetadataProgramID[:],
+			mint[:],
+			[]byte("edition"),
+			{bumpSeed},
+		},
+		TokenMetadataProgramID,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, masterEditionPDA)
+
+	editionPDA, _, err := FindTokenEditionAddress(mint)
+	require.NoError(t, err)
+	assert.Equal(t, masterEditionPDA, editionPDA)
+
+	metadataPDA, _, err := FindTokenMetadataAddress(mint)
+	require.NoError(t, err)
+	assert.NotEqual(t, metadataPDA, masterEditionPDA)
+}
+
+func TestFindTokenEditionMarkerAddress(t *testing.T) {
+	mint := MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+
+	first, _, err := FindTokenEditionMarkerAddress(mint, 1)
+	require.NoError(t, err)
+	sameMarker, _, err := FindTokenEditionMarkerAddress(mint, 247)
+	require.NoError(t, err)
+	nextMarker, bumpSeed, err := FindTokenEditionMarkerAddress(mint, 248)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, sameMarker)
+	assert.NotEqual(t, first, nextMarker)
+
+	expected, err := CreateProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			TokenMetadataProgramID[:],
+			mint[:],
+			[]byte("edition"),
+			[]byte("1"),
+			{bumpSeed},
+		},
+		TokenMetadataProgramID,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, nextMarker)
+}
+
+func TestFindAssociatedTokenAddressWithTokenProgram(t *testing.T) {
+	wallet := MustPublicKeyFromBase58("9Nxgxdr7iFMRmz7zUpbHHxnbMXxZojabGvWtTzHCbnT9")
+	mint := MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+
+	legacy, _, err := FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+	withTokenProgram, _, err := FindAssociatedTokenAddressWithTokenProgram(wallet, mint, TokenProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, withTokenProgram)
+
+	token2022, bumpSeed, err := FindAssociatedTokenAddressWithTokenProgram(wallet, mint, Token2022ProgramID)
+	require.NoError(t, err)
+	assert.NotEqual(t, legacy, token2022)
+
+	expected, err := CreateProgramAddress(
+		[][]byte{
+			wallet[:],
+			Token2022ProgramID[:],
+			mint[:],
+			{bumpSeed},
+		},
+		SPLAssociatedTokenAccountProgramID,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expected, token2022)
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	crypto_rand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP39 mnemonics, see https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+// Only the english wordlist is supported.
+
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+var bip39WordIndex = func() map[string]int {
+	out := make(map[string]int, len(bip39EnglishWordlist))
+	for idx, word := range bip39EnglishWordlist {
+		out[word] = idx
+	}
+	return out
+}()
+
+// NewMnemonic generates a random mnemonic of `words` words, which must
+// be one of 12, 15, 18, 21 or 24.
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", fmt.Errorf("invalid mnemonic length: %d words, expected 12, 15, 18, 21 or 24", words)
+	}
+	entropy := make([]byte, words*4/3)
+	if _, err := crypto_rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return NewMnemonicFromEntropy(entropy)
+}
+
+// NewMnemonicFromEntropy encodes `entropy` (16 to 32 bytes, in 4 bytes
+// increments) as a mnemonic.
+func NewMnemonicFromEntropy(entropy []byte) (string, error) {
+	entropyBits := len(entropy) * 8
+	if entropyBits < 128 || entropyBits > 256 || entropyBits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy length: %d bits, expected 128 to 256 bits, in 32 bits increments", entropyBits)
+	}
+	checksumBits := entropyBits / 32
+	hash := sha256.Sum256(entropy)
+
+	// The entropy is followed by the first bits of its hash, and
+	// split in groups of 11 bits, each indexing a word.
+	bits := append(append([]byte{}, entropy...), hash[0])
+	words := make([]string, (entropyBits+checksumBits)/11)
+	for i := range words {
+		idx := 0
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			idx = idx<<1 | int(bits[bit/8]>>(7-uint(bit%8))&1)
+		}
+		words[i] = bip39EnglishWordlist[idx]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy validates `mnemonic`, including its checksum,
+// and returns the entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: %d words, expected 12, 15, 18, 21 or 24", ErrInvalidMnemonic, len(words))
+	}
+
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+
+	bits := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		idx, ok := bip39WordIndex[strings.ToLower(word)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		for j := 0; j < 11; j++ {
+			if idx>>(10-uint(j))&1 == 1 {
+				bit := i*11 + j
+				bits[bit/8] |= 1 << (7 - uint(bit%8))
+			}
+		}
+	}
+
+	entropy := bits[:entropyBits/8]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xff << (8 - uint(checksumBits)))
+	if bits[entropyBits/8]&mask != hash[0]&mask {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic returns an error wrapping ErrInvalidMnemonic if
+// `mnemonic` is not a valid BIP39 english mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed validates `mnemonic` and returns the 64 bytes BIP39
+// seed derived from it and the optional `passphrase`.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(normalized), []byte(salt), 2048, 64, sha512.New), nil
+}
+
+// PrivateKeyFromMnemonic derives the keypair at `path` from `mnemonic` and
+// the optional `passphrase`, the way Phantom and Solflare do. Use
+// SolanaDerivationPath(0) for the first account of such wallets.
+func PrivateKeyFromMnemonic(mnemonic string, passphrase string, path DerivationPath) (PrivateKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return PrivateKeyFromSeedPath(seed, path)
+}
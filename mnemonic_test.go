@@ -0,0 +1,186 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+func TestMnemonic_TrezorVectors(t *testing.T) {
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			entropy:  "808080808080808080808080808080808080808080808080",
+			mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+			seed:     "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+		},
+		{
+			entropy:  "18a2e1d81b8ecfb2a333adcb0c17a5b9eb76cc5d05db91a4",
+			mnemonic: "board blade invite damage undo sun mimic interest slam gaze truly inherit resist great inject rocket museum chief",
+			seed:     "f84521c777a13b61564234bf8f8b62b3afce27fc4062b51bb5e62bdfecb23864ee6ecf07c1d5a97c0834307c5c852d8ceb88e7c97923c0a3b496bedd4e5f88a9",
+		},
+		{
+			entropy:  "15da872c95a13dd738fbf50e427583ad61f18fd99f628c417a61cf8343c90419",
+			mnemonic: "beyond stage sleep clip because twist token leaf atom beauty genius food business side grid unable middle armed observe pair crouch tonight away coconut",
+			seed:     "b15509eaa2d09d3efd3e006ef42151b30367dc6e3aa5e44caba3fe4d3e352e65101fbdb86a96776b91946ff06f8eac594dc6ee1d3e82a42dfe1b40fef6bcc3fd",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.entropy, func(t *testing.T) {
+			entropy, err := hex.DecodeString(test.entropy)
+			require.NoError(t, err)
+
+			mnemonic, err := NewMnemonicFromEntropy(entropy)
+			require.NoError(t, err)
+			assert.Equal(t, test.mnemonic, mnemonic)
+
+			decoded, err := MnemonicToEntropy(mnemonic)
+			require.NoError(t, err)
+			assert.Equal(t, entropy, decoded)
+
+			seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+			require.NoError(t, err)
+			assert.Equal(t, test.seed, hex.EncodeToString(seed))
+		})
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	invalid := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow yellow",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice caged above",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo why",
+		"jello better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+		"dignity pass list indicate nasty",
+	}
+	for _, mnemonic := range invalid {
+		err := ValidateMnemonic(mnemonic)
+		require.Error(t, err, mnemonic)
+		assert.True(t, errors.Is(err, ErrInvalidMnemonic))
+	}
+
+	mnemonic, err := NewMnemonic(24)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	require.NoError(t, ValidateMnemonic(mnemonic))
+
+	_, err = NewMnemonic(13)
+	require.Error(t, err)
+}
+
+// Test vector 1 for ed25519 from https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func TestDeriveEd25519Key_SLIP10Vectors(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path      string
+		chainCode string
+		key       string
+	}{
+		{"m", "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0H", "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0H/1H", "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0H/1H/2H", "2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := ParseDerivationPath(test.path)
+			require.NoError(t, err)
+
+			key, chainCode, err := DeriveEd25519Key(seed, path)
+			require.NoError(t, err)
+			assert.Equal(t, test.key, hex.EncodeToString(key))
+			assert.Equal(t, test.chainCode, hex.EncodeToString(chainCode))
+		})
+	}
+
+	_, _, err = DeriveEd25519Key(seed, DerivationPath{0})
+	require.Error(t, err)
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := ParseDerivationPath("m/44'/501'/3'/0'")
+	require.NoError(t, err)
+	assert.Equal(t, SolanaDerivationPath(3), path)
+	assert.Equal(t, "m/44'/501'/3'/0'", path.String())
+
+	path, err = ParseDerivationPath("44h/501h")
+	require.NoError(t, err)
+	assert.Equal(t, DerivationPath{44 + HardenedOffset, 501 + HardenedOffset}, path)
+
+	path, err = ParseDerivationPath("m")
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	for _, invalid := range []string{"m/", "m/a'", "m/2147483648", "m/44'//0'"} {
+		_, err := ParseDerivationPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPrivateKeyFromMnemonic(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	first, err := PrivateKeyFromMnemonic(mnemonic, "", SolanaDerivationPath(0))
+	require.NoError(t, err)
+	second, err := PrivateKeyFromMnemonic(mnemonic, "", SolanaDerivationPath(1))
+	require.NoError(t, err)
+	withPassphrase, err := PrivateKeyFromMnemonic(mnemonic, "passphrase", SolanaDerivationPath(0))
+	require.NoError(t, err)
+
+	assert.Len(t, first, 64)
+	assert.NotEqual(t, first.PublicKey(), second.PublicKey())
+	assert.NotEqual(t, first.PublicKey(), withPassphrase.PublicKey())
+
+	// Derivation is deterministic.
+	again, err := PrivateKeyFromMnemonic(mnemonic, "", SolanaDerivationPath(0))
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	_, err = PrivateKeyFromMnemonic("abandon abandon", "", SolanaDerivationPath(0))
+	require.Error(t, err)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ProgramError is a custom error of a program, the `Custom` variant of an
+// instruction error, e.g. "custom program error: 0x1" in the logs.
+type ProgramError struct {
+	Code    uint32
+	Name    string
+	Message string
+}
+
+func (e *ProgramError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s (0x%x)", e.Name, e.Code)
+	}
+	return fmt.Sprintf("%s (0x%x): %s", e.Name, e.Code, e.Message)
+}
+
+// Is reports whether `target` is a *ProgramError with the same code and
+// name, so that the errors resolved from a transaction match the sentinels
+// of the program packages with errors.Is.
+func (e *ProgramError) Is(target error) bool {
+	t, ok := target.(*ProgramError)
+	return ok && t != nil && e.Code == t.Code && e.Name == t.Name
+}
+
+var programErrorRegistry = newProgramErrorRegistry()
+
+type errorRegistry struct {
+	mu     *sync.RWMutex
+	errors map[PublicKey]map[uint32]ProgramError
+}
+
+func newProgramErrorRegistry() *errorRegistry {
+	return &errorRegistry{
+		mu:     &sync.RWMutex{},
+		errors: make(map[PublicKey]map[uint32]ProgramError),
+	}
+}
+
+func (reg *errorRegistry) Register(programID PublicKey, errs []ProgramError) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	byCode, ok := reg.errors[programID]
+	if !ok {
+		byCode = make(map[uint32]ProgramError, len(errs))
+		reg.errors[programID] = byCode
+	}
+	for _, err := range errs {
+		if prev, ok := byCode[err.Code]; ok && prev != err {
+			panic(fmt.Sprintf("unable to re-register error %d of program %s", err.Code, programID))
+		}
+	}
+	for _, err := range errs {
+		byCode[err.Code] = err
+	}
+}
+
+func (reg *errorRegistry) Get(programID PublicKey, code uint32) (ProgramError, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	err, ok := reg.errors[programID][code]
+	return err, ok
+}
+
+// RegisterProgramErrors registers the custom errors of the program `programID`.
+// Registering an error again with the same name and message is a no-op,
+// with another name or message it panics.
+func RegisterProgramErrors(programID PublicKey, errs ...ProgramError) {
+	for _, err := range errs {
+		if err.Name == "" {
+			panic(fmt.Sprintf("error %d of program %s has no name", err.Code, programID))
+		}
+	}
+	programErrorRegistry.Register(programID, errs)
+}
+
+// LookupProgramError returns the registered custom error `code` of the
+// program `programID`.
+func LookupProgramError(programID PublicKey, code uint32) (*ProgramError, bool) {
+	err, ok := programErrorRegistry.Get(programID, code)
+	if !ok {
+		return nil, false
+	}
+	return &err, true
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterProgramErrors(t *testing.T) {
+	programID := MustPublicKeyFromBase58("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+	errs := []ProgramError{
+		{Code: 6000, Name: "SlippageExceeded", Message: "Slippage tolerance exceeded"},
+		{Code: 6001, Name: "Paused"},
+	}
+
+	RegisterProgramErrors(programID, errs...)
+	assert.NotPanics(t, func() {
+		RegisterProgramErrors(programID, errs[0])
+	})
+	assert.Panics(t, func() {
+		RegisterProgramErrors(programID, ProgramError{Code: 6000, Name: "Other"})
+	})
+	assert.Panics(t, func() {
+		RegisterProgramErrors(programID, ProgramError{Code: 6002})
+	})
+
+	err, ok := LookupProgramError(programID, 6000)
+	require.True(t, ok)
+	assert.Equal(t, "SlippageExceeded (0x1770): Slippage tolerance exceeded", err.Error())
+	err, ok = LookupProgramError(programID, 6001)
+	require.True(t, ok)
+	assert.Equal(t, "Paused (0x1771)", err.Error())
+
+	// The looked up errors are copies, matched on their code and name.
+	assert.True(t, errors.Is(fmt.Errorf("swap: %w", err), &ProgramError{Code: 6001, Name: "Paused"}))
+	assert.False(t, errors.Is(err, &ProgramError{Code: 6000, Name: "SlippageExceeded"}))
+	assert.False(t, errors.Is(err, &ProgramError{Code: 6001, Name: "Other"}))
+
+	_, ok = LookupProgramError(programID, 6002)
+	assert.False(t, ok)
+	_, ok = LookupProgramError(SystemProgramID, 6000)
+	assert.False(t, ok)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package programlog
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+var ErrEventDecoderNotFound = errors.New("event decoder not found")
+
+// DiscriminatorSize is the size of the discriminator of Anchor events.
+const DiscriminatorSize = 8
+
+// EventDecoder decodes the data of an event, without its discriminator.
+type EventDecoder func(data []byte) (interface{}, error)
+
+// Event is an event emitted by a program through a "Program data:" log.
+type Event struct {
+	ProgramID     solana.PublicKey
+	Name          string
+	Discriminator [DiscriminatorSize]byte
+
+	// Data is the logged payload, including the discriminator.
+	Data []byte
+
+	// Value is the decoded event, Err is set if decoding failed.
+	Value interface{}
+	Err   error
+}
+
+// EventDiscriminator returns the discriminator of the Anchor event `name`,
+// the first 8 bytes of the SHA-256 of "event:<name>".
+func EventDiscriminator(name string) (out [DiscriminatorSize]byte) {
+	sum := sha256.Sum256([]byte("event:" + name))
+	copy(out[:], sum[:DiscriminatorSize])
+	return
+}
+
+type eventDecoder struct {
+	name   string
+	decode EventDecoder
+
+	// typ is the type of the events registered with `RegisterAnchorEvent`.
+	typ reflect.Type
+}
+
+func (d eventDecoder) same(other eventDecoder) bool {
+	if d.name != other.name {
+		return false
+	}
+	if d.typ != nil || other.typ != nil {
+		return d.typ == other.typ
+	}
+	return isSameFunction(d.decode, other.decode)
+}
+
+var eventDecoderRegistry = newEventDecoderRegistry()
+
+type decoderRegistry struct {
+	mu       *sync.RWMutex
+	decoders map[solana.PublicKey]map[[DiscriminatorSize]byte]eventDecoder
+}
+
+func newEventDecoderRegistry() *decoderRegistry {
+	return &decoderRegistry{
+		mu:       &sync.RWMutex{},
+		decoders: make(map[solana.PublicKey]map[[DiscriminatorSize]byte]eventDecoder),
+	}
+}
+
+func (reg *decoderRegistry) Register(programID solana.PublicKey, discriminator [DiscriminatorSize]byte, decoder eventDecoder) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	byDiscriminator, ok := reg.decoders[programID]
+	if !ok {
+		byDiscriminator = make(map[[DiscriminatorSize]byte]eventDecoder)
+		reg.decoders[programID] = byDiscriminator
+	}
+	if prev, ok := byDiscriminator[discriminator]; ok {
+		// If it's the same decoder, then OK (tollerate multiple calls with same params).
+		if prev.same(decoder) {
+			return
+		}
+		panic(fmt.Sprintf("unable to re-register event decoder %q for program %s", decoder.name, programID))
+	}
+	byDiscriminator[discriminator] = decoder
+}
+
+func (reg *decoderRegistry) Get(programID solana.PublicKey, discriminator [DiscriminatorSize]byte) (eventDecoder, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	decoder, ok := reg.decoders[programID][discriminator]
+	return decoder, ok
+}
+
+func isSameFunction(f1 interface{}, f2 interface{}) bool {
+	return reflect.ValueOf(f1).Pointer() == reflect.ValueOf(f2).Pointer()
+}
+
+// RegisterEventDecoder registers the decoder of the event `name` of the
+// program `programID`, recognized by its discriminator. Registering the same
+// decoder again is a no-op, registering another one for the same
+// discriminator panics.
+func RegisterEventDecoder(programID solana.PublicKey, name string, discriminator [DiscriminatorSize]byte, decoder EventDecoder) {
+	if decoder == nil {
+		panic(fmt.Sprintf("event %q has no decoder", name))
+	}
+	eventDecoderRegistry.Register(programID, discriminator, eventDecoder{name: name, decode: decoder})
+}
+
+// RegisterAnchorEvent registers the Anchor event `name` of the program
+// `programID`, decoded with Borsh into a new value of the type of `event`,
+// e.g. `RegisterAnchorEvent(programID, "SwapEvent", SwapEvent{})`. The
+// decoded values are pointers to that type.
+func RegisterAnchorEvent(programID solana.PublicKey, name string, event interface{}) {
+	typ := reflect.TypeOf(event)
+	if typ == nil {
+		panic(fmt.Sprintf("event %q has no type", name))
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	decode := func(data []byte) (interface{}, error) {
+		value := reflect.New(typ).Interface()
+		if err := bin.NewBorshDecoder(data).Decode(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	eventDecoderRegistry.Register(programID, EventDiscriminator(name), eventDecoder{name: name, decode: decode, typ: typ})
+}
+
+// DecodeEvent decodes the payload of a "Program data:" log of the program
+// `programID` with the decoder registered for its discriminator.
+func DecodeEvent(programID solana.PublicKey, data []byte) (*Event, error) {
+	if len(data) < DiscriminatorSize {
+		return nil, ErrEventDecoderNotFound
+	}
+	event := &Event{ProgramID: programID, Data: data}
+	copy(event.Discriminator[:], data)
+
+	decoder, found := eventDecoderRegistry.Get(programID, event.Discriminator)
+	if !found {
+		return nil, ErrEventDecoderNotFound
+	}
+	event.Name = decoder.name
+	event.Value, event.Err = decoder.decode(data[DiscriminatorSize:])
+	if event.Err != nil {
+		event.Err = fmt.Errorf("unable to decode event %s: %w", decoder.name, event.Err)
+	}
+	return event, nil
+}
+
+// decodeEvent decodes the fields of a "Program data:" log, Anchor logging
+// each event as a single field.
+func decodeEvent(programID solana.PublicKey, fields [][]byte) (*Event, bool) {
+	if len(fields) != 1 {
+		return nil, false
+	}
+	event, err := DecodeEvent(programID, fields[0])
+	return event, err == nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package programlog parses the log messages of transactions, as found in
+// `TransactionMeta.LogMessages` and in the results of the logs subscriptions,
+// into the invocations of programs with their logs, their data, the compute
+// units they consumed and the reason of their failure.
+package programlog
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+var (
+	invokeLogRegex   = regexp.MustCompile(`^Program (\w+) invoke \[(\d+)\]$`)
+	consumedLogRegex = regexp.MustCompile(`^Program (\w+) consumed (\d+) of (\d+) compute units$`)
+	successLogRegex  = regexp.MustCompile(`^Program (\w+) success$`)
+	failedLogRegex   = regexp.MustCompile(`^Program (\w+) failed: (.*)$`)
+	returnLogRegex   = regexp.MustCompile(`^Program return: (\w+) (\S*)$`)
+	customErrorRegex = regexp.MustCompile(`custom program error: 0x([0-9a-fA-F]+)$`)
+)
+
+const (
+	logPrefix    = "Program log: "
+	dataPrefix   = "Program data: "
+	truncatedLog = "Log truncated"
+)
+
+// Invocation is the execution of a program, by a transaction or by another
+// program through a cross-program invocation.
+type Invocation struct {
+	ProgramID solana.PublicKey
+
+	// Depth is 1 for the instructions of the transaction, 2 for the
+	// invocations they make, and so on.
+	Depth int
+
+	// Logs are the messages logged by the program with `msg!`, without the
+	// "Program log: " prefix.
+	Logs []string
+
+	// Data are the payloads logged by the program with `sol_log_data`, each
+	// entry holding the decoded base64 fields of one "Program data:" line.
+	Data [][][]byte
+
+	// Events are the payloads of `Data` decoded by a registered event decoder.
+	Events []*Event
+
+	// ReturnData is the data set by the program with `set_return_data`.
+	ReturnData []byte
+
+	// Messages are the lines of the invocation the parser doesn't know about,
+	// e.g. the ones logged by the runtime or by native programs.
+	Messages []string
+
+	// ComputeUnits consumed by the program, including the programs it invoked,
+	// out of ComputeUnitsLimit. They are nil for native programs which don't
+	// log them.
+	ComputeUnits      *uint64
+	ComputeUnitsLimit *uint64
+
+	Succeeded bool
+	Failed    bool
+
+	// Error is the reason of the failure, e.g. "custom program error: 0x1",
+	// and CustomError its code when it is a custom program error.
+	Error       string
+	CustomError *uint32
+
+	Invocations []*Invocation
+}
+
+// ProgramError returns the registered custom error the invocation failed with.
+func (inv *Invocation) ProgramError() (*solana.ProgramError, bool) {
+	if inv.CustomError == nil {
+		return nil, false
+	}
+	return solana.LookupProgramError(inv.ProgramID, *inv.CustomError)
+}
+
+// Flatten returns the invocations made by `inv`, recursively, in the order
+// of execution.
+func (inv *Invocation) Flatten() (out []*Invocation) {
+	for _, child := range inv.Invocations {
+		out = append(out, child)
+		out = append(out, child.Flatten()...)
+	}
+	return
+}
+
+// Logs are the parsed log messages of a transaction.
+type Logs struct {
+	// Invocations are the instructions of the transaction that were executed.
+	Invocations []*Invocation
+
+	// Truncated is true when the runtime truncated the logs because they
+	// exceeded its limit, the invocations then miss their last lines.
+	Truncated bool
+}
+
+// Parse parses the log messages of a transaction.
+func Parse(logs []string) *Logs {
+	out := &Logs{}
+
+	var stack []*Invocation
+	for _, line := range logs {
+		if line == truncatedLog {
+			out.Truncated = true
+			break
+		}
+
+		if match := invokeLogRegex.FindStringSubmatch(line); match != nil {
+			programID, err := solana.PublicKeyFromBase58(match[1])
+			if err == nil {
+				depth, _ := strconv.Atoi(match[2])
+				inv := &Invocation{ProgramID: programID, Depth: depth}
+
+				// The depth of the logs is authoritative, realign on it in
+				// case a result line is missing.
+				for len(stack) > 0 && stack[len(stack)-1].Depth >= depth {
+					stack = stack[:len(stack)-1]
+				}
+				if len(stack) == 0 {
+					out.Invocations = append(out.Invocations, inv)
+				} else {
+					parent := stack[len(stack)-1]
+					parent.Invocations = append(parent.Invocations, inv)
+				}
+				stack = append(stack, inv)
+				continue
+			}
+		}
+
+		if len(stack) == 0 {
+			continue
+		}
+		current := stack[len(stack)-1]
+
+		if current.parseResult(line) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		current.parseLine(line)
+	}
+
+	return out
+}
+
+// parseResult parses the line ending the invocation, if it is one.
+func (inv *Invocation) parseResult(line string) bool {
+	if match := successLogRegex.FindStringSubmatch(line); match != nil && match[1] == inv.ProgramID.String() {
+		inv.Succeeded = true
+		return true
+	}
+
+	if match := failedLogRegex.FindStringSubmatch(line); match != nil && match[1] == inv.ProgramID.String() {
+		inv.Failed = true
+		inv.Error = match[2]
+		if match := customErrorRegex.FindStringSubmatch(inv.Error); match != nil {
+			if code, err := strconv.ParseUint(match[1], 16, 32); err == nil {
+				customError := uint32(code)
+				inv.CustomError = &customError
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func (inv *Invocation) parseLine(line string) {
+	switch {
+	case strings.HasPrefix(line, logPrefix):
+		inv.Logs = append(inv.Logs, strings.TrimPrefix(line, logPrefix))
+		return
+
+	case strings.HasPrefix(line, dataPrefix):
+		var fields [][]byte
+		for _, field := range strings.Fields(strings.TrimPrefix(line, dataPrefix)) {
+			data, err := base64.StdEncoding.DecodeString(field)
+			if err != nil {
+				inv.Messages = append(inv.Messages, line)
+				return
+			}
+			fields = append(fields, data)
+		}
+		inv.Data = append(inv.Data, fields)
+		if event, ok := decodeEvent(inv.ProgramID, fields); ok {
+			inv.Events = append(inv.Events, event)
+		}
+		return
+	}
+
+	if match := consumedLogRegex.FindStringSubmatch(line); match != nil && match[1] == inv.ProgramID.String() {
+		consumed, err1 := strconv.ParseUint(match[2], 10, 64)
+		limit, err2 := strconv.ParseUint(match[3], 10, 64)
+		if err1 == nil && err2 == nil {
+			inv.ComputeUnits = &consumed
+			inv.ComputeUnitsLimit = &limit
+			return
+		}
+	}
+
+	if match := returnLogRegex.FindStringSubmatch(line); match != nil && match[1] == inv.ProgramID.String() {
+		if data, err := base64.StdEncoding.DecodeString(match[2]); err == nil {
+			inv.ReturnData = data
+			return
+		}
+	}
+
+	inv.Messages = append(inv.Messages, line)
+}
+
+// Walk calls `f` on the invocations, depth first in the order of execution,
+// until it returns false.
+func (l *Logs) Walk(f func(inv *Invocation) bool) {
+	var walk func(invocations []*Invocation) bool
+	walk = func(invocations []*Invocation) bool {
+		for _, inv := range invocations {
+			if !f(inv) || !walk(inv.Invocations) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(l.Invocations)
+}
+
+// Events returns the events of all the invocations, in the order of the invocations.
+func (l *Logs) Events() (out []*Event) {
+	l.Walk(func(inv *Invocation) bool {
+		out = append(out, inv.Events...)
+		return true
+	})
+	return
+}
+
+// Failed returns the deepest failed invocation, i.e. the one that made the
+// transaction fail, or nil.
+func (l *Logs) Failed() *Invocation {
+	var failed *Invocation
+	l.Walk(func(inv *Invocation) bool {
+		if inv.Failed && (failed == nil || inv.Depth > failed.Depth) {
+			failed = inv
+		}
+		return true
+	})
+	return failed
+}
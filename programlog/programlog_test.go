@@ -0,0 +1,176 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package programlog
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/programs/token"
+)
+
+var (
+	swapProgramID = solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	user          = solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+)
+
+type swapEvent struct {
+	User      solana.PublicKey
+	AmountIn  uint64
+	AmountOut uint64
+}
+
+func init() {
+	RegisterAnchorEvent(swapProgramID, "SwapEvent", swapEvent{})
+}
+
+func eventData(t *testing.T, name string, event interface{}) string {
+	buf := new(bytes.Buffer)
+	discriminator := EventDiscriminator(name)
+	buf.Write(discriminator[:])
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestParse(t *testing.T) {
+	event := swapEvent{User: user, AmountIn: 10, AmountOut: 20}
+	logs := Parse([]string{
+		"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+		"Program ComputeBudget111111111111111111111111111111 success",
+		"Program " + swapProgramID.String() + " invoke [1]",
+		"Program log: Instruction: Swap",
+		"Program data: " + eventData(t, "SwapEvent", event),
+		"Program data: " + eventData(t, "OtherEvent", event),
+		"Program data: aGVsbG8= d29ybGQ=",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program log: Instruction: Transfer",
+		"Program log: Error: insufficient funds",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 4645 of 180000 compute units",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1",
+		"Program return: " + swapProgramID.String() + " AQ==",
+		"Program " + swapProgramID.String() + " consumed 24000 of 200000 compute units",
+		"Program " + swapProgramID.String() + " failed: custom program error: 0x1",
+	})
+	require.False(t, logs.Truncated)
+	require.Len(t, logs.Invocations, 2)
+
+	budget := logs.Invocations[0]
+	assert.Equal(t, solana.ComputeBudget, budget.ProgramID)
+	assert.True(t, budget.Succeeded)
+	assert.Nil(t, budget.ComputeUnits)
+
+	swap := logs.Invocations[1]
+	assert.Equal(t, 1, swap.Depth)
+	assert.Equal(t, []string{"Instruction: Swap"}, swap.Logs)
+	require.Len(t, swap.Data, 3)
+	assert.Equal(t, [][]byte{[]byte("hello"), []byte("world")}, swap.Data[2])
+	assert.Equal(t, []byte{1}, swap.ReturnData)
+	assert.Equal(t, uint64(24000), *swap.ComputeUnits)
+	assert.Equal(t, uint64(200000), *swap.ComputeUnitsLimit)
+	assert.True(t, swap.Failed)
+	assert.Equal(t, uint32(1), *swap.CustomError)
+	assert.Empty(t, swap.Messages)
+
+	require.Len(t, swap.Events, 1)
+	assert.Equal(t, "SwapEvent", swap.Events[0].Name)
+	assert.Equal(t, &event, swap.Events[0].Value)
+	assert.NoError(t, swap.Events[0].Err)
+	assert.Equal(t, swap.Events, logs.Events())
+
+	require.Len(t, swap.Invocations, 1)
+	transfer := swap.Invocations[0]
+	assert.Equal(t, solana.TokenProgramID, transfer.ProgramID)
+	assert.Equal(t, 2, transfer.Depth)
+	assert.Equal(t, []string{"Instruction: Transfer", "Error: insufficient funds"}, transfer.Logs)
+	assert.Equal(t, "custom program error: 0x1", transfer.Error)
+	assert.Same(t, transfer, logs.Failed())
+	assert.Equal(t, []*Invocation{transfer}, swap.Flatten())
+
+	programErr, ok := transfer.ProgramError()
+	require.True(t, ok)
+	assert.Equal(t, token.Errors[1], *programErr)
+	assert.Equal(t, "InsufficientFunds (0x1): Insufficient funds", programErr.Error())
+	_, ok = swap.ProgramError()
+	assert.False(t, ok)
+}
+
+func TestParse_Truncated(t *testing.T) {
+	logs := Parse([]string{
+		"Program " + swapProgramID.String() + " invoke [1]",
+		"Program log: Instruction: Swap",
+		"Log truncated",
+	})
+	require.True(t, logs.Truncated)
+	require.Len(t, logs.Invocations, 1)
+	assert.False(t, logs.Invocations[0].Succeeded)
+	assert.False(t, logs.Invocations[0].Failed)
+	assert.Nil(t, logs.Failed())
+}
+
+func TestParse_MissingResult(t *testing.T) {
+	// A new invocation at depth 1 closes the previous ones.
+	logs := Parse([]string{
+		"Program " + swapProgramID.String() + " invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program 11111111111111111111111111111111 invoke [1]",
+		"Transfer: insufficient lamports 0, need 1",
+		"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+	})
+	require.Len(t, logs.Invocations, 2)
+	assert.Len(t, logs.Invocations[0].Invocations, 1)
+	assert.Equal(t, []string{"Transfer: insufficient lamports 0, need 1"}, logs.Invocations[1].Messages)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(eventData(t, "SwapEvent", swapEvent{}))
+	require.NoError(t, err)
+
+	_, err = DecodeEvent(user, data)
+	assert.Equal(t, ErrEventDecoderNotFound, err)
+	_, err = DecodeEvent(swapProgramID, data[:4])
+	assert.Equal(t, ErrEventDecoderNotFound, err)
+
+	event, err := DecodeEvent(swapProgramID, data[:20])
+	require.NoError(t, err)
+	assert.Equal(t, "SwapEvent", event.Name)
+	assert.Error(t, event.Err)
+}
+
+func TestRegisterAnchorEvent(t *testing.T) {
+	assert.NotPanics(t, func() {
+		RegisterAnchorEvent(swapProgramID, "SwapEvent", &swapEvent{})
+	})
+	assert.Panics(t, func() {
+		RegisterAnchorEvent(swapProgramID, "SwapEvent", struct{ Amount uint64 }{})
+	})
+
+	decoder := func(data []byte) (interface{}, error) { return data, nil }
+	RegisterEventDecoder(user, "Raw", [DiscriminatorSize]byte{1}, decoder)
+	assert.NotPanics(t, func() {
+		RegisterEventDecoder(user, "Raw", [DiscriminatorSize]byte{1}, decoder)
+	})
+	assert.Panics(t, func() {
+		RegisterEventDecoder(user, "Raw", [DiscriminatorSize]byte{1}, func(data []byte) (interface{}, error) { return nil, nil })
+	})
+}
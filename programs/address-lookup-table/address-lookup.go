@@ -11,6 +11,33 @@ import (
 	"github.com/xmcontinue/solana-go/rpc"
 )
 
+var ProgramID = solana.AddressLookupTableProgramID
+
+// SetProgramID sets the program ID whose accounts are decoded as lookup tables.
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+	registerAccountDecoders(ProgramID)
+}
+
+func init() {
+	registerAccountDecoders(ProgramID)
+}
+
+// LookupTableTypeIndex is the type index of initialized lookup tables; uninitialized ones have 0.
+const LookupTableTypeIndex = 1
+
+func registerAccountDecoders(programID solana.PublicKey) {
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:          "AddressLookupTable",
+		Discriminator: []byte{LookupTableTypeIndex, 0, 0, 0},
+		Decode:        decodeAddressLookupTableAccount,
+	})
+}
+
+func decodeAddressLookupTableAccount(data []byte) (interface{}, error) {
+	return DecodeAddressLookupTableState(data)
+}
+
 // The serialized size of lookup table metadata.
 const (
 	LOOKUP_TABLE_META_SIZE     = 56
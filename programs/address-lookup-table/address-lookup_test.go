@@ -21,6 +21,10 @@ func TestDecodeTable(t *testing.T) {
 	table, err := DecodeAddressLookupTableState(tableAccountBytes)
 	require.NoError(t, err)
 
+	obj, err := solana.DecodeAccount(ProgramID, tableAccountBytes)
+	require.NoError(t, err)
+	require.Equal(t, table, obj)
+
 	require.Equal(t, uint64(math.MaxUint64), table.DeactivationSlot)
 	require.Equal(t, uint64(154742572), table.LastExtendedSlot)
 	require.Equal(t, uint8(232), table.LastExtendedSlotStartIndex)
@@ -29,6 +29,9 @@ type Create struct {
 	Wallet solana.PublicKey `bin:"-" borsh_skip:"true"`
 	Mint   solana.PublicKey `bin:"-" borsh_skip:"true"`
 
+	// The token program of the mint; defaults to solana.TokenProgramID.
+	TokenProgram solana.PublicKey `bin:"-" borsh_skip:"true"`
+
 	// [0] = [WRITE, SIGNER] Payer
 	// ··········· Funding account
 	//
@@ -45,7 +48,7 @@ type Create struct {
 	// ··········· System program ID
 	//
 	// [5] = [] TokenProgram
-	// ··········· SPL token program ID
+	// ··········· SPL token program ID (or the Token-2022 program ID)
 	//
 	// [6] = [] SysVarRent
 	// ··········· SysVarRentPubkey
@@ -73,12 +76,32 @@ func (inst *Create) SetMint(mint solana.PublicKey) *Create {
 	return inst
 }
 
+// SetTokenProgram sets the token program of the mint;
+// use solana.Token2022ProgramID for Token-2022 mints.
+func (inst *Create) SetTokenProgram(tokenProgram solana.PublicKey) *Create {
+	inst.TokenProgram = tokenProgram
+	return inst
+}
+
+func (inst *Create) SetAccounts(accounts []*solana.AccountMeta) error {
+	if len(accounts) < 6 {
+		return fmt.Errorf("not enough accounts: expected at least 6, got %d", len(accounts))
+	}
+	inst.AccountMetaSlice = accounts
+	inst.Payer = accounts[0].PublicKey
+	inst.Wallet = accounts[2].PublicKey
+	inst.Mint = accounts[3].PublicKey
+	inst.TokenProgram = accounts[5].PublicKey
+	return nil
+}
+
 func (inst Create) Build() *Instruction {
 
 	// Find the associatedTokenAddress;
-	associatedTokenAddress, _, _ := solana.FindAssociatedTokenAddress(
+	associatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithTokenProgram(
 		inst.Wallet,
 		inst.Mint,
+		tokenProgramOrDefault(inst.TokenProgram),
 	)
 
 	keys := []*solana.AccountMeta{
@@ -108,7 +131,7 @@ func (inst Create) Build() *Instruction {
 			IsWritable: false,
 		},
 		{
-			PublicKey:  solana.TokenProgramID,
+			PublicKey:  tokenProgramOrDefault(inst.TokenProgram),
 			IsSigner:   false,
 			IsWritable: false,
 		},
@@ -123,7 +146,7 @@ func (inst Create) Build() *Instruction {
 
 	return &Instruction{BaseVariant: bin.BaseVariant{
 		Impl:   inst,
-		TypeID: bin.NoTypeIDDefaultID,
+		TypeID: bin.TypeIDFromUint8(Instruction_Create),
 	}}
 }
 
@@ -147,9 +170,10 @@ func (inst *Create) Validate() error {
 	if inst.Mint.IsZero() {
 		return errors.New("Mint not set")
 	}
-	_, _, err := solana.FindAssociatedTokenAddress(
+	_, _, err := solana.FindAssociatedTokenAddressWithTokenProgram(
 		inst.Wallet,
 		inst.Mint,
+		tokenProgramOrDefault(inst.TokenProgram),
 	)
 	if err != nil {
 		return fmt.Errorf("error while FindAssociatedTokenAddress: %w", err)
@@ -169,7 +193,7 @@ func (inst *Create) EncodeToTree(parent treeout.Branches) {
 					instructionBranch.Child("Params[len=0]").ParentFunc(func(paramsBranch treeout.Branches) {})
 
 					// Accounts of the instruction:
-					instructionBranch.Child("Accounts[len=7").ParentFunc(func(accountsBranch treeout.Branches) {
+					instructionBranch.Child("Accounts[len=7]").ParentFunc(func(accountsBranch treeout.Branches) {
 						accountsBranch.Child(format.Meta("                 payer", inst.AccountMetaSlice.Get(0)))
 						accountsBranch.Child(format.Meta("associatedTokenAddress", inst.AccountMetaSlice.Get(1)))
 						accountsBranch.Child(format.Meta("                wallet", inst.AccountMetaSlice.Get(2)))
@@ -200,3 +224,12 @@ func NewCreateInstruction(
 		SetWallet(walletAddress).
 		SetMint(splTokenMintAddress)
 }
+
+// tokenProgramOrDefault returns the provided token program,
+// or the Token program if it is not set.
+func tokenProgramOrDefault(tokenProgram solana.PublicKey) solana.PublicKey {
+	if tokenProgram.IsZero() {
+		return solana.TokenProgramID
+	}
+	return tokenProgram
+}
@@ -0,0 +1,211 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package associatedtokenaccount
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	treeout "github.com/gagliardetto/treeout"
+	solana "github.com/xmcontinue/solana-go"
+	format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Transfers from and closes a nested associated token account: an
+// associated token account owned by an associated token account.
+//
+// The tokens are moved from the nested associated token account to the wallet's
+// associated token account, and the nested account lamports are moved to the wallet.
+//
+// Note: Nested token accounts are an anti-pattern, and almost always created
+// unintentionally, so this instruction should only be used to recover from errors.
+type RecoverNested struct {
+	// The mint of the tokens held by the nested account.
+	NestedMint solana.PublicKey `bin:"-" borsh_skip:"true"`
+	// The mint of the owner associated token account, that owns the nested account.
+	OwnerMint solana.PublicKey `bin:"-" borsh_skip:"true"`
+	Wallet    solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// The token program of the mints; defaults to solana.TokenProgramID.
+	TokenProgram solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// [0] = [WRITE] NestedAssociatedTokenAccount
+	// ··········· Nested associated token account, must be owned by the owner associated token account
+	//
+	// [1] = [] NestedTokenMint
+	// ··········· Token mint for the nested associated token account
+	//
+	// [2] = [WRITE] DestinationAssociatedTokenAccount
+	// ··········· Wallet's associated token account
+	//
+	// [3] = [] OwnerAssociatedTokenAccount
+	// ··········· Owner associated token account address, must be owned by the wallet
+	//
+	// [4] = [] OwnerTokenMint
+	// ··········· Token mint for the owner associated token account
+	//
+	// [5] = [WRITE, SIGNER] Wallet
+	// ··········· Wallet address for the owner associated token account
+	//
+	// [6] = [] TokenProgram
+	// ··········· SPL token program ID (or the Token-2022 program ID)
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRecoverNestedInstructionBuilder creates a new `RecoverNested` instruction builder.
+func NewRecoverNestedInstructionBuilder() *RecoverNested {
+	nd := &RecoverNested{}
+	return nd
+}
+
+func (inst *RecoverNested) SetNestedMint(nestedMint solana.PublicKey) *RecoverNested {
+	inst.NestedMint = nestedMint
+	return inst
+}
+
+func (inst *RecoverNested) SetOwnerMint(ownerMint solana.PublicKey) *RecoverNested {
+	inst.OwnerMint = ownerMint
+	return inst
+}
+
+func (inst *RecoverNested) SetWallet(wallet solana.PublicKey) *RecoverNested {
+	inst.Wallet = wallet
+	return inst
+}
+
+// SetTokenProgram sets the token program of the mints;
+// use solana.Token2022ProgramID for Token-2022 mints.
+func (inst *RecoverNested) SetTokenProgram(tokenProgram solana.PublicKey) *RecoverNested {
+	inst.TokenProgram = tokenProgram
+	return inst
+}
+
+func (inst *RecoverNested) SetAccounts(accounts []*solana.AccountMeta) error {
+	if len(accounts) < 7 {
+		return fmt.Errorf("not enough accounts: expected at least 7, got %d", len(accounts))
+	}
+	inst.AccountMetaSlice = accounts
+	inst.NestedMint = accounts[1].PublicKey
+	inst.OwnerMint = accounts[4].PublicKey
+	inst.Wallet = accounts[5].PublicKey
+	inst.TokenProgram = accounts[6].PublicKey
+	return nil
+}
+
+// addresses derives the owner, nested and destination associated token accounts.
+func (inst RecoverNested) addresses() (owner, nested, destination solana.PublicKey, err error) {
+	tokenProgram := tokenProgramOrDefault(inst.TokenProgram)
+	owner, _, err = solana.FindAssociatedTokenAddressWithTokenProgram(inst.Wallet, inst.OwnerMint, tokenProgram)
+	if err != nil {
+		return
+	}
+	nested, _, err = solana.FindAssociatedTokenAddressWithTokenProgram(owner, inst.NestedMint, tokenProgram)
+	if err != nil {
+		return
+	}
+	destination, _, err = solana.FindAssociatedTokenAddressWithTokenProgram(inst.Wallet, inst.NestedMint, tokenProgram)
+	return
+}
+
+func (inst RecoverNested) Build() *Instruction {
+	owner, nested, destination, _ := inst.addresses()
+
+	inst.AccountMetaSlice = []*solana.AccountMeta{
+		solana.Meta(nested).WRITE(),
+		solana.Meta(inst.NestedMint),
+		solana.Meta(destination).WRITE(),
+		solana.Meta(owner),
+		solana.Meta(inst.OwnerMint),
+		solana.Meta(inst.Wallet).WRITE().SIGNER(),
+		solana.Meta(tokenProgramOrDefault(inst.TokenProgram)),
+	}
+
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_RecoverNested),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts.
+// If there is a validation error, return the error.
+// Otherwise, build and return the instruction.
+func (inst RecoverNested) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RecoverNested) Validate() error {
+	if inst.NestedMint.IsZero() {
+		return errors.New("NestedMint not set")
+	}
+	if inst.OwnerMint.IsZero() {
+		return errors.New("OwnerMint not set")
+	}
+	if inst.Wallet.IsZero() {
+		return errors.New("Wallet not set")
+	}
+	_, _, _, err := inst.addresses()
+	if err != nil {
+		return fmt.Errorf("error while FindAssociatedTokenAddress: %w", err)
+	}
+	return nil
+}
+
+func (inst *RecoverNested) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("RecoverNested")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params[len=0]").ParentFunc(func(paramsBranch treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts[len=7]").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("      nestedAssociatedTokenAccount", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("                   nestedTokenMint", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta(" destinationAssociatedTokenAccount", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta("       ownerAssociatedTokenAccount", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(format.Meta("                    ownerTokenMint", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(format.Meta("                            wallet", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(format.Meta("                      tokenProgram", inst.AccountMetaSlice.Get(6)))
+					})
+				})
+		})
+}
+
+func (inst RecoverNested) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return nil
+}
+
+func (inst *RecoverNested) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return nil
+}
+
+func NewRecoverNestedInstruction(
+	walletAddress solana.PublicKey,
+	ownerMintAddress solana.PublicKey,
+	nestedMintAddress solana.PublicKey,
+) *RecoverNested {
+	return NewRecoverNestedInstructionBuilder().
+		SetWallet(walletAddress).
+		SetOwnerMint(ownerMintAddress).
+		SetNestedMint(nestedMintAddress)
+}
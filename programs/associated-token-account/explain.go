@@ -0,0 +1,49 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package associatedtokenaccount
+
+import (
+	"fmt"
+
+	solana "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func explainInstruction(ctx *explain.Context, inst *explain.Instruction) []string {
+	decoded, ok := inst.Decoded.(*Instruction)
+	if !ok {
+		return nil
+	}
+
+	var payer, wallet, mint solana.PublicKey
+	switch impl := decoded.Impl.(type) {
+	case *Create:
+		payer, wallet, mint = impl.Payer, impl.Wallet, impl.Mint
+	case *CreateIdempotent:
+		payer, wallet, mint = impl.Payer, impl.Wallet, impl.Mint
+	default:
+		return nil
+	}
+	if len(inst.Accounts) < 2 || payer.IsZero() {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("%s created ATA %s for mint %s owned by %s",
+		ctx.Label(payer),
+		ctx.Label(inst.Accounts[1].PublicKey),
+		ctx.Label(mint),
+		ctx.Label(wallet),
+	)}
+}
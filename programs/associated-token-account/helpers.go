@@ -0,0 +1,62 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package associatedtokenaccount
+
+import (
+	"fmt"
+
+	solana "github.com/xmcontinue/solana-go"
+)
+
+// NewCreateIdempotentWithAddress returns the associated token account address
+// of the provided wallet and mint, along with an instruction that creates it
+// if it doesn't already exist.
+func NewCreateIdempotentWithAddress(
+	payer solana.PublicKey,
+	walletAddress solana.PublicKey,
+	splTokenMintAddress solana.PublicKey,
+) (solana.PublicKey, *Instruction, error) {
+	return NewCreateIdempotentWithAddressAndTokenProgram(
+		payer,
+		walletAddress,
+		splTokenMintAddress,
+		solana.TokenProgramID,
+	)
+}
+
+// NewCreateIdempotentWithAddressAndTokenProgram is like NewCreateIdempotentWithAddress,
+// for mints owned by the provided token program (e.g. solana.Token2022ProgramID).
+func NewCreateIdempotentWithAddressAndTokenProgram(
+	payer solana.PublicKey,
+	walletAddress solana.PublicKey,
+	splTokenMintAddress solana.PublicKey,
+	tokenProgramID solana.PublicKey,
+) (solana.PublicKey, *Instruction, error) {
+	address, _, err := solana.FindAssociatedTokenAddressWithTokenProgram(
+		walletAddress,
+		splTokenMintAddress,
+		tokenProgramID,
+	)
+	if err != nil {
+		return solana.PublicKey{}, nil, fmt.Errorf("error while FindAssociatedTokenAddress: %w", err)
+	}
+	inst, err := NewCreateIdempotentInstruction(payer, walletAddress, splTokenMintAddress).
+		SetTokenProgram(tokenProgramID).
+		ValidateAndBuild()
+	if err != nil {
+		return solana.PublicKey{}, nil, err
+	}
+	return address, inst, nil
+}
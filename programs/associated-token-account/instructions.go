@@ -15,12 +15,14 @@
 package associatedtokenaccount
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/davecgh/go-spew/spew"
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/treeout"
 	solana "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
 	"github.com/xmcontinue/solana-go/text"
 )
 
@@ -29,12 +31,45 @@ var ProgramID solana.PublicKey = solana.SPLAssociatedTokenAccountProgramID
 func SetProgramID(pubkey solana.PublicKey) {
 	ProgramID = pubkey
 	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
 }
 
 const ProgramName = "AssociatedTokenAccount"
 
 func init() {
 	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
+}
+
+const (
+	// Creates an associated token account for the given wallet address and token mint.
+	// Returns an error if the account exists.
+	//
+	// NOTE: for backwards compatibility, this instruction is encoded with empty data.
+	Instruction_Create uint8 = iota
+
+	// Creates an associated token account for the given wallet address and token mint,
+	// if it doesn't already exist.  Returns an error if the account exists,
+	// but with a different owner.
+	Instruction_CreateIdempotent
+
+	// Transfers from and closes a nested associated token account: an
+	// associated token account owned by an associated token account.
+	Instruction_RecoverNested
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_Create:
+		return "Create"
+	case Instruction_CreateIdempotent:
+		return "CreateIdempotent"
+	case Instruction_RecoverNested:
+		return "RecoverNested"
+	default:
+		return ""
+	}
 }
 
 type Instruction struct {
@@ -50,10 +85,16 @@ func (inst *Instruction) EncodeToTree(parent treeout.Branches) {
 }
 
 var InstructionImplDef = bin.NewVariantDefinition(
-	bin.NoTypeIDEncoding, // NOTE: the associated-token-account program has no ID encoding.
+	bin.Uint8TypeIDEncoding,
 	[]bin.VariantType{
 		{
-			"Create", (*Create)(nil),
+			Name: "Create", Type: (*Create)(nil),
+		},
+		{
+			Name: "CreateIdempotent", Type: (*CreateIdempotent)(nil),
+		},
+		{
+			Name: "RecoverNested", Type: (*RecoverNested)(nil),
 		},
 	},
 )
@@ -67,7 +108,11 @@ func (inst *Instruction) Accounts() (out []*solana.AccountMeta) {
 }
 
 func (inst *Instruction) Data() ([]byte, error) {
-	return []byte{}, nil
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
 func (inst *Instruction) TextEncode(encoder *text.Encoder, option *text.Option) error {
@@ -75,10 +120,24 @@ func (inst *Instruction) TextEncode(encoder *text.Encoder, option *text.Option)
 }
 
 func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// The legacy Create instruction has no data.
+	if !decoder.HasRemaining() {
+		inst.TypeID = bin.TypeIDFromUint8(Instruction_Create)
+		inst.Impl = new(Create)
+		return nil
+	}
 	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
 }
 
 func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Create is encoded with empty data, to stay compatible
+	// with the older versions of the program.
+	if inst.TypeID.Uint8() != Instruction_Create {
+		err := encoder.WriteUint8(inst.TypeID.Uint8())
+		if err != nil {
+			return fmt.Errorf("unable to write variant type: %w", err)
+		}
+	}
 	return encoder.Encode(inst.Impl)
 }
 
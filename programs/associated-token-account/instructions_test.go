@@ -0,0 +1,145 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package associatedtokenaccount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	solana "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func TestCreate(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	wallet := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	inst, err := NewCreateInstruction(payer, wallet, mint).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Empty(t, data, "Create must keep the legacy empty data")
+
+	ata, _, err := solana.FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 7)
+	require.Equal(t, ata, accounts[1].PublicKey)
+	require.Equal(t, solana.TokenProgramID, accounts[5].PublicKey)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	require.Equal(t, Instruction_Create, decoded.TypeID.Uint8())
+	create := decoded.Impl.(*Create)
+	require.Equal(t, payer, create.Payer)
+	require.Equal(t, wallet, create.Wallet)
+	require.Equal(t, mint, create.Mint)
+}
+
+func TestCreateIdempotent(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	wallet := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	for _, tokenProgram := range []solana.PublicKey{solana.TokenProgramID, solana.Token2022ProgramID} {
+		address, inst, err := NewCreateIdempotentWithAddressAndTokenProgram(payer, wallet, mint, tokenProgram)
+		require.NoError(t, err)
+
+		expected, _, err := solana.FindAssociatedTokenAddressWithTokenProgram(wallet, mint, tokenProgram)
+		require.NoError(t, err)
+		require.Equal(t, expected, address)
+
+		data, err := inst.Data()
+		require.NoError(t, err)
+		require.Equal(t, []byte{Instruction_CreateIdempotent}, data)
+
+		accounts := inst.Accounts()
+		require.Len(t, accounts, 7)
+		require.Equal(t, solana.Meta(payer).WRITE().SIGNER(), accounts[0])
+		require.Equal(t, solana.Meta(address).WRITE(), accounts[1])
+		require.Equal(t, tokenProgram, accounts[5].PublicKey)
+
+		decoded, err := DecodeInstruction(accounts, data)
+		require.NoError(t, err)
+		require.Equal(t, Instruction_CreateIdempotent, decoded.TypeID.Uint8())
+		require.Equal(t, tokenProgram, decoded.Impl.(*CreateIdempotent).TokenProgram)
+	}
+
+	address, _, err := NewCreateIdempotentWithAddress(payer, wallet, mint)
+	require.NoError(t, err)
+	legacy, _, err := solana.FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+	require.Equal(t, legacy, address)
+}
+
+func TestRecoverNested(t *testing.T) {
+	wallet := solana.NewWallet().PublicKey()
+	ownerMint := solana.NewWallet().PublicKey()
+	nestedMint := solana.NewWallet().PublicKey()
+
+	inst, err := NewRecoverNestedInstruction(wallet, ownerMint, nestedMint).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_RecoverNested}, data)
+
+	owner, _, err := solana.FindAssociatedTokenAddress(wallet, ownerMint)
+	require.NoError(t, err)
+	nested, _, err := solana.FindAssociatedTokenAddress(owner, nestedMint)
+	require.NoError(t, err)
+	destination, _, err := solana.FindAssociatedTokenAddress(wallet, nestedMint)
+	require.NoError(t, err)
+
+	require.Equal(t, []*solana.AccountMeta{
+		solana.Meta(nested).WRITE(),
+		solana.Meta(nestedMint),
+		solana.Meta(destination).WRITE(),
+		solana.Meta(owner),
+		solana.Meta(ownerMint),
+		solana.Meta(wallet).WRITE().SIGNER(),
+		solana.Meta(solana.TokenProgramID),
+	}, inst.Accounts())
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	require.Equal(t, Instruction_RecoverNested, decoded.TypeID.Uint8())
+	require.Equal(t, nestedMint, decoded.Impl.(*RecoverNested).NestedMint)
+}
+
+func TestDecodeInstruction_Unknown(t *testing.T) {
+	_, err := DecodeInstruction(nil, []byte{3})
+	require.Error(t, err)
+}
+
+func TestExplainCreate(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	wallet := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		NewCreateInstruction(payer, wallet, mint).Build(),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	ata, _, err := solana.FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+
+	statements, err := explain.Explain(tx, nil, &explain.Options{Labels: map[solana.PublicKey]string{payer: "alice", wallet: "bob", mint: "USDC"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice created ATA " + ata.String() + " for mint USDC owned by bob"}, statements)
+}
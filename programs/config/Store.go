@@ -0,0 +1,203 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Store new data in a config account.
+// The instruction data is the ConfigKeys followed by the serialized config data.
+type Store struct {
+	// The keys stored in the config account.
+	Keys ConfigKeys
+
+	// The serialized config data (program-specific).
+	Data []byte
+
+	// [0] = [WRITE] config
+	// ··········· The config account; it must sign if it is not yet initialized.
+	//
+	// [1...] = [SIGNER] signers
+	// ··········· The signer keys listed in Keys (except the config account itself).
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *Store) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(1)
+	return nil
+}
+
+func (slice Store) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewStoreInstructionBuilder creates a new `Store` instruction builder.
+func NewStoreInstructionBuilder() *Store {
+	nd := &Store{
+		Accounts: make(ag_solanago.AccountMetaSlice, 1),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetKeys sets the "keys" parameter, and the signer accounts
+// for the keys flagged as signers.
+func (inst *Store) SetKeys(keys ConfigKeys) *Store {
+	inst.Keys = keys
+	inst.Signers = make(ag_solanago.AccountMetaSlice, 0)
+	for _, key := range keys {
+		if !key.IsSigner {
+			continue
+		}
+		if inst.Accounts[0] != nil && inst.Accounts[0].PublicKey.Equals(key.PublicKey) {
+			continue
+		}
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(key.PublicKey).SIGNER())
+	}
+	return inst
+}
+
+// SetData sets the "data" parameter.
+// The serialized config data.
+func (inst *Store) SetData(data []byte) *Store {
+	inst.Data = data
+	return inst
+}
+
+// SetConfigAccount sets the "config" account.
+// The config account must sign when it is being initialized.
+func (inst *Store) SetConfigAccount(config ag_solanago.PublicKey, isSigner bool) *Store {
+	inst.Accounts[0] = ag_solanago.Meta(config).WRITE()
+	if isSigner {
+		inst.Accounts[0].SIGNER()
+	}
+	// Re-compute the signers, which must not include the config account.
+	return inst.SetKeys(inst.Keys)
+}
+
+// GetConfigAccount gets the "config" account.
+func (inst *Store) GetConfigAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+func (inst Store) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.NoTypeIDDefaultID,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Store) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Store) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Data == nil {
+			return errors.New("Data parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return fmt.Errorf("accounts.Config is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *Store) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Store")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						keysBranch := paramsBranch.Child(fmt.Sprintf("Keys[len=%v]", len(inst.Keys)))
+						for i, key := range inst.Keys {
+							keysBranch.Child(ag_format.Param(fmt.Sprintf("[%v]", i), fmt.Sprintf("%s (signer: %v)", key.PublicKey, key.IsSigner)))
+						}
+						paramsBranch.Child(ag_format.Param("Data", fmt.Sprintf("%v bytes", len(inst.Data))))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("config", inst.Accounts[0]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj Store) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Keys` param:
+	err = obj.Keys.MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	// Serialize `Data` param (no length prefix):
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *Store) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Keys`:
+	err = obj.Keys.UnmarshalWithDecoder(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Data` (all the remaining bytes):
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+// NewStoreInstruction declares a new Store instruction with the provided parameters and accounts.
+func NewStoreInstruction(
+	// Parameters:
+	keys ConfigKeys,
+	data []byte,
+	// Accounts:
+	config ag_solanago.PublicKey,
+	isConfigSigner bool,
+) *Store {
+	return NewStoreInstructionBuilder().
+		SetConfigAccount(config, isConfigSigner).
+		SetKeys(keys).
+		SetData(data)
+}
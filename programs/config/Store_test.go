@@ -0,0 +1,100 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestStoreInstruction(t *testing.T) {
+	config := solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	signer := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+
+	keys := ConfigKeys{
+		{PublicKey: config, IsSigner: true},
+		{PublicKey: signer, IsSigner: true},
+		{PublicKey: solana.SystemProgramID, IsSigner: false},
+	}
+	ix, err := NewStoreInstruction(keys, []byte{1, 2, 3}, config, true).ValidateAndBuild()
+	require.NoError(t, err)
+
+	require.Equal(t, ProgramID, ix.ProgramID())
+	require.Equal(t,
+		[]*solana.AccountMeta{
+			solana.Meta(config).WRITE().SIGNER(),
+			solana.Meta(signer).SIGNER(),
+		},
+		ix.Accounts(),
+	)
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	require.Equal(t, byte(3), data[0])
+	require.Equal(t, 1+3*33+3, len(data))
+	require.Equal(t, []byte{1, 2, 3}, data[len(data)-3:])
+
+	decoded, err := DecodeInstruction(ix.Accounts(), data)
+	require.NoError(t, err)
+	got := decoded.Impl.(*Store)
+	require.Equal(t, keys, got.Keys)
+	require.Equal(t, []byte{1, 2, 3}, got.Data)
+	require.Equal(t, config, got.GetConfigAccount().PublicKey)
+	require.Len(t, got.Signers, 1)
+}
+
+func TestValidatorInfo(t *testing.T) {
+	config := solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	identity := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+
+	info := ValidatorInfo{
+		Name:            "Example",
+		Website:         "https://example.com",
+		KeybaseUsername: "example",
+		Details:         "An example validator",
+	}
+	store, err := NewStoreValidatorInfoInstruction(info, config, false, identity)
+	require.NoError(t, err)
+
+	data, err := store.Build().Data()
+	require.NoError(t, err)
+
+	// The account data is laid out like the Store instruction data,
+	// padded with zeros up to the allocated size.
+	acctData := append(data, make([]byte, 64)...)
+	got, err := DecodeValidatorInfo(acctData)
+	require.NoError(t, err)
+	require.Equal(t, identity, got.Identity)
+	require.Equal(t, info, got.Info)
+
+	obj, err := solana.DecodeAccount(ProgramID, acctData)
+	require.NoError(t, err)
+	require.Equal(t, got, obj)
+
+	_, err = DecodeValidatorInfo([]byte{1, 0})
+	require.Error(t, err)
+
+	notInfo, err := NewStoreInstruction(ConfigKeys{{PublicKey: identity, IsSigner: true}}, []byte{}, config, false).Build().Data()
+	require.NoError(t, err)
+	_, err = DecodeValidatorInfo(notInfo)
+	require.ErrorIs(t, err, ErrNotValidatorInfo)
+
+	// Other config accounts decode to the generic layout.
+	obj, err = solana.DecodeAccount(ProgramID, notInfo)
+	require.NoError(t, err)
+	require.Equal(t, &ConfigAccount{Keys: ConfigKeys{{PublicKey: identity, IsSigner: true}}, Data: []byte{}}, obj)
+}
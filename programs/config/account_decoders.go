@@ -0,0 +1,53 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/xmcontinue/solana-go"
+)
+
+func init() {
+	if !ProgramID.IsZero() {
+		registerAccountDecoders(ProgramID)
+	}
+}
+
+// validatorInfoDiscriminator is the start of the keys of a validator-info
+// account: their short_vec length, 2, and the ValidatorInfoID.
+func validatorInfoDiscriminator() []byte {
+	return append([]byte{2}, ValidatorInfoID[:]...)
+}
+
+// registerAccountDecoders registers the validator-info accounts, and the
+// generic layout of the other config accounts, whose data is left raw.
+func registerAccountDecoders(programID solana.PublicKey) {
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:          "ValidatorInfo",
+		Discriminator: validatorInfoDiscriminator(),
+		Decode:        decodeValidatorInfoAccount,
+	})
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:   "ConfigAccount",
+		Decode: decodeConfigAccount,
+	})
+}
+
+func decodeValidatorInfoAccount(data []byte) (interface{}, error) {
+	return DecodeValidatorInfo(data)
+}
+
+func decodeConfigAccount(data []byte) (interface{}, error) {
+	return DecodeConfigAccount(data)
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// KeyedValidatorInfo is a validator-info account along with its address.
+type KeyedValidatorInfo struct {
+	Address solana.PublicKey
+	ValidatorInfoAccount
+}
+
+// FetchValidatorInfos returns all the validator-info accounts owned by the config program.
+func FetchValidatorInfos(ctx context.Context, rpcCli *rpc.Client) (out []*KeyedValidatorInfo, err error) {
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		ProgramID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					Memcmp: &rpc.RPCFilterMemcmp{
+						// Skip the short_vec length of the ConfigKeys.
+						Offset: 1,
+						Bytes:  ValidatorInfoID[:],
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("resp empty... program account not found")
+	}
+
+	for _, keyedAcct := range resp {
+		info, err := DecodeValidatorInfo(keyedAcct.Account.Data.GetBinary())
+		if err != nil {
+			if errors.Is(err, ErrNotValidatorInfo) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to decode validator info %q: %w", keyedAcct.Pubkey, err)
+		}
+		out = append(out, &KeyedValidatorInfo{
+			Address:              keyedAcct.Pubkey,
+			ValidatorInfoAccount: *info,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// ConfigKey is a public key stored in a config account,
+// along with whether that key must sign config updates.
+type ConfigKey struct {
+	PublicKey solana.PublicKey
+	IsSigner  bool
+}
+
+// ConfigKeys is the list of keys stored at the beginning of every config account
+// (and of every Store instruction). It is serialized as a short_vec.
+type ConfigKeys []ConfigKey
+
+func (keys ConfigKeys) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	err = encoder.WriteCompactU16Length(len(keys))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err = encoder.WriteBytes(key.PublicKey[:], false)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteBool(key.IsSigner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (keys *ConfigKeys) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	length, err := decoder.ReadCompactU16Length()
+	if err != nil {
+		return err
+	}
+	*keys = make(ConfigKeys, length)
+	for i := 0; i < length; i++ {
+		buf, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		(*keys)[i].PublicKey = solana.PublicKeyFromBytes(buf)
+		(*keys)[i].IsSigner, err = decoder.ReadBool()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signers returns the keys that must sign config updates.
+func (keys ConfigKeys) Signers() (out solana.PublicKeySlice) {
+	for _, key := range keys {
+		if key.IsSigner {
+			out = append(out, key.PublicKey)
+		}
+	}
+	return
+}
+
+// ConfigAccount is the generic layout of an account owned by the config program:
+// the ConfigKeys, followed by the program-specific config data.
+type ConfigAccount struct {
+	Keys ConfigKeys
+	Data []byte
+}
+
+func (obj ConfigAccount) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	err = obj.Keys.MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *ConfigAccount) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	err = obj.Keys.UnmarshalWithDecoder(decoder)
+	if err != nil {
+		return err
+	}
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+// DecodeConfigAccount decodes the given account bytes into a ConfigAccount.
+func DecodeConfigAccount(data []byte) (*ConfigAccount, error) {
+	var obj ConfigAccount
+	if err := obj.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
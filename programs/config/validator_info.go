@@ -0,0 +1,115 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// ValidatorInfoID is the first key of every validator-info config account.
+var ValidatorInfoID = solana.MustPublicKeyFromBase58("Va1idator1nfo111111111111111111111111111111")
+
+// Maximum size of the validator-info JSON string.
+const MAX_VALIDATOR_INFO = 576
+
+var ErrNotValidatorInfo = errors.New("not a validator-info config account")
+
+// ValidatorInfo is the JSON payload published by validators via `solana validator-info publish`.
+type ValidatorInfo struct {
+	Name            string `json:"name,omitempty"`
+	Website         string `json:"website,omitempty"`
+	KeybaseUsername string `json:"keybaseUsername,omitempty"`
+	Details         string `json:"details,omitempty"`
+	IconURL         string `json:"iconUrl,omitempty"`
+}
+
+// ValidatorInfoAccount is a decoded validator-info config account.
+type ValidatorInfoAccount struct {
+	// The validator identity that signed the info.
+	Identity solana.PublicKey
+	Info     ValidatorInfo
+}
+
+// MarshalValidatorInfo serializes the provided info into the config data
+// of a validator-info account (a bincode string holding the JSON payload).
+func MarshalValidatorInfo(info ValidatorInfo) ([]byte, error) {
+	payload, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode validator info: %w", err)
+	}
+	if len(payload) > MAX_VALIDATOR_INFO {
+		return nil, fmt.Errorf("validator info too long; got %v bytes, but max is %v", len(payload), MAX_VALIDATOR_INFO)
+	}
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).WriteRustString(string(payload)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeValidatorInfo decodes the given config account bytes into a ValidatorInfoAccount.
+// Returns ErrNotValidatorInfo if the account is not a validator-info account.
+func DecodeValidatorInfo(data []byte) (*ValidatorInfoAccount, error) {
+	acct, err := DecodeConfigAccount(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode config account: %w", err)
+	}
+	return ParseValidatorInfo(acct)
+}
+
+// ParseValidatorInfo parses the validator info from a decoded config account.
+// Returns ErrNotValidatorInfo if the account is not a validator-info account.
+func ParseValidatorInfo(acct *ConfigAccount) (*ValidatorInfoAccount, error) {
+	if len(acct.Keys) != 2 || !acct.Keys[0].PublicKey.Equals(ValidatorInfoID) || !acct.Keys[1].IsSigner {
+		return nil, ErrNotValidatorInfo
+	}
+	payload, err := bin.NewBinDecoder(acct.Data).ReadRustString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read validator info: %w", err)
+	}
+	out := &ValidatorInfoAccount{
+		Identity: acct.Keys[1].PublicKey,
+	}
+	if err := json.Unmarshal([]byte(payload), &out.Info); err != nil {
+		return nil, fmt.Errorf("unable to decode validator info JSON: %w", err)
+	}
+	return out, nil
+}
+
+// NewStoreValidatorInfoInstruction declares a new Store instruction
+// that publishes the provided info for the validator identity
+// in the provided validator-info config account.
+func NewStoreValidatorInfoInstruction(
+	info ValidatorInfo,
+	configAccount solana.PublicKey,
+	isConfigSigner bool,
+	identity solana.PublicKey,
+) (*Store, error) {
+	data, err := MarshalValidatorInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	keys := ConfigKeys{
+		{PublicKey: ValidatorInfoID, IsSigner: false},
+		{PublicKey: identity, IsSigner: true},
+	}
+	return NewStoreInstruction(keys, data, configAccount, isConfigSigner), nil
+}
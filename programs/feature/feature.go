@@ -0,0 +1,88 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Runtime features are activated by assigning a `Feature` account
+// to the feature program; the bank activates it at the next epoch boundary
+// and records the activation slot in the account.
+
+package feature
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+var ProgramID solana.PublicKey = solana.FeatureProgramID
+
+const ProgramName = "Feature"
+
+// The serialized size of a Feature account.
+const FEATURE_SIZE = 9
+
+// Feature is the state of a feature account.
+type Feature struct {
+	// The slot at which the feature was activated;
+	// nil if the feature is pending activation.
+	ActivatedAt *uint64 `bin:"optional"`
+}
+
+// IsActive returns true if the feature has been activated.
+func (f Feature) IsActive() bool {
+	return f.ActivatedAt != nil
+}
+
+func (f Feature) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if f.ActivatedAt == nil {
+		err = encoder.WriteBool(false)
+		if err != nil {
+			return err
+		}
+		// Pad to the fixed account size.
+		return encoder.WriteUint64(0, bin.LE)
+	}
+	err = encoder.WriteBool(true)
+	if err != nil {
+		return err
+	}
+	return encoder.WriteUint64(*f.ActivatedAt, bin.LE)
+}
+
+func (f *Feature) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	isSome, err := decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+	if !isSome {
+		f.ActivatedAt = nil
+		return nil
+	}
+	slot, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	f.ActivatedAt = &slot
+	return nil
+}
+
+// DecodeFeature decodes the given account bytes into a Feature.
+func DecodeFeature(data []byte) (*Feature, error) {
+	var f Feature
+	if err := f.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode feature: %w", err)
+	}
+	return &f, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"bytes"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestDecodeFeature(t *testing.T) {
+	{
+		f, err := DecodeFeature([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0})
+		require.NoError(t, err)
+		require.False(t, f.IsActive())
+		require.Nil(t, f.ActivatedAt)
+	}
+	{
+		f, err := DecodeFeature([]byte{1, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0})
+		require.NoError(t, err)
+		require.True(t, f.IsActive())
+		require.Equal(t, uint64(1000000), *f.ActivatedAt)
+	}
+	{
+		_, err := DecodeFeature([]byte{1, 0x40})
+		require.Error(t, err)
+	}
+}
+
+func TestEncodeFeature(t *testing.T) {
+	slot := uint64(1000000)
+	for _, f := range []Feature{{}, {ActivatedAt: &slot}} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, bin.NewBinEncoder(buf).Encode(f))
+		require.Equal(t, FEATURE_SIZE, buf.Len())
+
+		got, err := DecodeFeature(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, f, *got)
+
+		obj, err := solana.DecodeAccount(ProgramID, buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, got, obj)
+	}
+}
+
+func TestKnownFeatures(t *testing.T) {
+	f, ok := LookupKnownFeature(VersionedTxMessageEnabled)
+	require.True(t, ok)
+	require.Equal(t, "versioned_tx_message_enabled", f.Name)
+
+	custom := solana.MustPublicKeyFromBase58("9kdtFSrXHQg3hKkbXkQ6trJ3Ja1xpJ22CTFSNAciEwmL")
+	_, ok = LookupKnownFeature(custom)
+	require.False(t, ok)
+
+	RegisterKnownFeature(custom, "custom_feature", "a custom feature")
+	f, ok = LookupKnownFeature(custom)
+	require.True(t, ok)
+	require.Equal(t, "a custom feature", f.Description)
+	require.True(t, KnownFeatureIDs().Contains(custom))
+}
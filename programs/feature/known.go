@@ -0,0 +1,111 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// KnownFeature describes a runtime feature gate.
+type KnownFeature struct {
+	ID          solana.PublicKey
+	Name        string
+	Description string
+}
+
+var (
+	VersionedTxMessageEnabled       = solana.MustPublicKeyFromBase58("3KZZ6Ks1885aGBQ45fwRcPXVBCtzUvxhUTkwKMR41Tca")
+	AddSetComputeUnitPriceIx        = solana.MustPublicKeyFromBase58("98std1NSHqXi9WYvFShfVepRdCoq1qvsp8fsR2XZtG8g")
+	Secp256k1ProgramEnabled         = solana.MustPublicKeyFromBase58("E3PHP7w8kB7np3CTQ1qQ2tW3KCtjRSXBQgW9vM2mWv2Y")
+	Blake3SyscallEnabled            = solana.MustPublicKeyFromBase58("HTW2pSyErTj4BV6KBM9NZ9VBUJVxt7sacNWcf76wtzb3")
+	DisableFeesSysvar               = solana.MustPublicKeyFromBase58("JAN1trEUEtZjgXYzNBYHU9DYd7GnThhXfFP7SzPXkPsG")
+	Libsecp256k1FailOnBadCount      = solana.MustPublicKeyFromBase58("8aXvSuopd1PUj7UhehfXJRg6619RHp8ZvwTyyJHdUYsj")
+	InstructionsSysvarOwnedBySysvar = solana.MustPublicKeyFromBase58("H3kBSaKdeiUsyHmeHqjJYNc27jesXZ6zWj3zWkowQbkV")
+)
+
+var knownFeatures = struct {
+	mu       *sync.RWMutex
+	features map[solana.PublicKey]*KnownFeature
+}{
+	mu:       &sync.RWMutex{},
+	features: make(map[solana.PublicKey]*KnownFeature),
+}
+
+func init() {
+	RegisterKnownFeature(VersionedTxMessageEnabled, "versioned_tx_message_enabled", "enable versioned transaction message processing")
+	RegisterKnownFeature(AddSetComputeUnitPriceIx, "add_set_compute_unit_price_ix", "add compute budget ix for setting a compute unit price")
+	RegisterKnownFeature(Secp256k1ProgramEnabled, "secp256k1_program_enabled", "secp256k1 program")
+	RegisterKnownFeature(Blake3SyscallEnabled, "blake3_syscall_enabled", "blake3 syscall")
+	RegisterKnownFeature(DisableFeesSysvar, "disable_fees_sysvar", "disable fees sysvar")
+	RegisterKnownFeature(Libsecp256k1FailOnBadCount, "libsecp256k1_fail_on_bad_count", "fail libsecp256k1_verify if count appears wrong")
+	RegisterKnownFeature(InstructionsSysvarOwnedBySysvar, "instructions_sysvar_owned_by_sysvar", "fix owner for instructions sysvar")
+}
+
+// RegisterKnownFeature adds (or replaces) a feature in the known-features catalog.
+func RegisterKnownFeature(id solana.PublicKey, name string, description string) {
+	knownFeatures.mu.Lock()
+	defer knownFeatures.mu.Unlock()
+
+	knownFeatures.features[id] = &KnownFeature{
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+}
+
+// LookupKnownFeature returns the catalog entry for the provided feature ID.
+func LookupKnownFeature(id solana.PublicKey) (*KnownFeature, bool) {
+	knownFeatures.mu.RLock()
+	defer knownFeatures.mu.RUnlock()
+
+	f, ok := knownFeatures.features[id]
+	return f, ok
+}
+
+// KnownFeatures returns all the features in the catalog, sorted by name.
+//
+// The bundled catalog is partial: it only names a handful of features,
+// use RegisterKnownFeature to add the ones missing.
+func KnownFeatures() []*KnownFeature {
+	knownFeatures.mu.RLock()
+	defer knownFeatures.mu.RUnlock()
+
+	out := make([]*KnownFeature, 0, len(knownFeatures.features))
+	for _, f := range knownFeatures.features {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// KnownFeatureIDs returns the IDs of all the features in the catalog, sorted by name.
+func KnownFeatureIDs() solana.PublicKeySlice {
+	features := KnownFeatures()
+	out := make(solana.PublicKeySlice, len(features))
+	for i, f := range features {
+		out[i] = f.ID
+	}
+	return out
+}
+
+func (f KnownFeature) String() string {
+	return fmt.Sprintf("%s (%s): %s", f.Name, f.ID, f.Description)
+}
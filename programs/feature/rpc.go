@@ -0,0 +1,130 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+type FeatureActivationStatus string
+
+const (
+	// The feature account does not exist (or is not owned by the feature program).
+	FeatureInactive FeatureActivationStatus = "inactive"
+	// The feature account exists, but the feature will be activated at the next epoch boundary.
+	FeaturePending FeatureActivationStatus = "pending"
+	// The feature has been activated.
+	FeatureActive FeatureActivationStatus = "active"
+)
+
+type FeatureStatus struct {
+	ID     solana.PublicKey
+	Status FeatureActivationStatus
+
+	// The slot at which the feature was activated (only set when Status is FeatureActive).
+	ActivatedAt *uint64
+
+	// The catalog entry of the feature, if known.
+	Known *KnownFeature
+}
+
+// IsActive returns true if the feature has been activated.
+func (st FeatureStatus) IsActive() bool {
+	return st.Status == FeatureActive
+}
+
+// GetFeatureStatuses fetches the feature accounts of the provided feature IDs
+// (with rpc.Client.GetMultipleAccountsChunked) and returns their activation status,
+// in the same order as the provided IDs.
+func GetFeatureStatuses(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	featureIDs []solana.PublicKey,
+	commitment rpc.CommitmentType, // optional
+) (out []*FeatureStatus, err error) {
+	resp, err := rpcCli.GetMultipleAccountsChunked(
+		ctx,
+		featureIDs,
+		&rpc.GetMultipleAccountsChunkedOpts{
+			GetMultipleAccountsOpts: rpc.GetMultipleAccountsOpts{
+				Encoding:   solana.EncodingBase64,
+				Commitment: commitment,
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	out = make([]*FeatureStatus, 0, len(featureIDs))
+	for i, acct := range resp.Value {
+		status, err := newFeatureStatus(featureIDs[i], acct)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, status)
+	}
+	return out, nil
+}
+
+// GetKnownFeatureStatuses returns the activation status of all the features
+// in the KnownFeatures catalog, which is partial: use GetFeatureStatuses
+// to query features that are not in it.
+func GetKnownFeatureStatuses(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	commitment rpc.CommitmentType, // optional
+) (out []*FeatureStatus, err error) {
+	return GetFeatureStatuses(ctx, rpcCli, KnownFeatureIDs(), commitment)
+}
+
+// IsFeatureActive returns true if the provided feature has been activated.
+func IsFeatureActive(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	featureID solana.PublicKey,
+	commitment rpc.CommitmentType, // optional
+) (bool, error) {
+	statuses, err := GetFeatureStatuses(ctx, rpcCli, []solana.PublicKey{featureID}, commitment)
+	if err != nil {
+		return false, err
+	}
+	return statuses[0].IsActive(), nil
+}
+
+func newFeatureStatus(id solana.PublicKey, acct *rpc.Account) (*FeatureStatus, error) {
+	status := &FeatureStatus{
+		ID:     id,
+		Status: FeatureInactive,
+	}
+	status.Known, _ = LookupKnownFeature(id)
+	if acct == nil || !acct.Owner.Equals(ProgramID) || acct.Data == nil {
+		return status, nil
+	}
+	f, err := DecodeFeature(acct.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("feature %s: %w", id, err)
+	}
+	if f.IsActive() {
+		status.Status = FeatureActive
+		status.ActivatedAt = f.ActivatedAt
+	} else {
+		status.Status = FeaturePending
+	}
+	return status, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func TestGetFeatureStatuses(t *testing.T) {
+	// Active at slot 1000000; pending; missing.
+	responseBody := `{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":83986105},"value":[` +
+		`{"data":["AUBCDwAAAAAA","base64"],"executable":false,"lamports":953520,"owner":"Feature111111111111111111111111111111111111","rentEpoch":0},` +
+		`{"data":["AAAAAAAAAAAA","base64"],"executable":false,"lamports":953520,"owner":"Feature111111111111111111111111111111111111","rentEpoch":0},` +
+		`null]}}`
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &requestBody))
+		rw.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+	client := rpc.New(server.URL)
+
+	unknown := solana.MustPublicKeyFromBase58("7xLk17EQQ5KLDLDe44wCmupJKJjTGd8hs3eSVVhCx932")
+	ids := []solana.PublicKey{
+		VersionedTxMessageEnabled,
+		AddSetComputeUnitPriceIx,
+		unknown,
+	}
+	out, err := GetFeatureStatuses(context.Background(), client, ids, rpc.CommitmentFinalized)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string]interface{}{
+			"id":      float64(0),
+			"jsonrpc": "2.0",
+			"method":  "getMultipleAccounts",
+			"params": []interface{}{
+				[]interface{}{
+					VersionedTxMessageEnabled.String(),
+					AddSetComputeUnitPriceIx.String(),
+					unknown.String(),
+				},
+				map[string]interface{}{
+					"encoding":   "base64",
+					"commitment": "finalized",
+				},
+			},
+		},
+		requestBody,
+	)
+
+	require.Len(t, out, 3)
+
+	require.Equal(t, VersionedTxMessageEnabled, out[0].ID)
+	require.Equal(t, FeatureActive, out[0].Status)
+	require.Equal(t, uint64(1000000), *out[0].ActivatedAt)
+	require.Equal(t, "versioned_tx_message_enabled", out[0].Known.Name)
+
+	require.Equal(t, FeaturePending, out[1].Status)
+	require.Nil(t, out[1].ActivatedAt)
+
+	require.Equal(t, FeatureInactive, out[2].Status)
+	require.Nil(t, out[2].Known)
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// Every DEX account starts with the "serum" padding followed by its
+// account flags, which tell the kind of account it is.
+func accountDiscriminator(flags AccountFlag) []byte {
+	out := make([]byte, 5+8)
+	copy(out, "serum")
+	binary.LittleEndian.PutUint64(out[5:], uint64(flags))
+	return out
+}
+
+func registerAccountDecoders(programID solana.PublicKey) {
+	layouts := []solana.AccountLayout{
+		{Name: "Market", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagMarket), Decode: decodeMarketAccount},
+		{Name: "OpenOrders", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagOpenOrders), Decode: decodeOpenOrdersAccount},
+		{Name: "RequestQueue", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagRequestQueue), Decode: decodeRequestQueueAccount},
+		{Name: "EventQueue", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagEventQueue), Decode: decodeEventQueueAccount},
+		{Name: "Bids", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagBids), Decode: decodeOrderbookAccount},
+		{Name: "Asks", Discriminator: accountDiscriminator(AccountFlagInitialized | AccountFlagAsks), Decode: decodeOrderbookAccount},
+	}
+	for _, layout := range layouts {
+		solana.RegisterAccountDecoder(programID, layout)
+	}
+}
+
+func decodeMarketAccount(data []byte) (interface{}, error) {
+	market := new(MarketV2)
+	if err := market.Decode(data); err != nil {
+		return nil, err
+	}
+	return market, nil
+}
+
+func decodeOpenOrdersAccount(data []byte) (interface{}, error) {
+	openOrders := new(OpenOrders)
+	if err := openOrders.Decode(data); err != nil {
+		return nil, err
+	}
+	return openOrders, nil
+}
+
+func decodeRequestQueueAccount(data []byte) (interface{}, error) {
+	queue := new(RequestQueue)
+	if err := queue.Decode(data); err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+	return queue, nil
+}
+
+func decodeEventQueueAccount(data []byte) (interface{}, error) {
+	queue := new(EventQueue)
+	if err := queue.Decode(data); err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+	return queue, nil
+}
+
+func decodeOrderbookAccount(data []byte) (interface{}, error) {
+	orderbook := new(Orderbook)
+	if err := bin.NewBinDecoder(data).Decode(orderbook); err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+	return orderbook, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"go.uber.org/zap"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/programs/token"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// MARKET_V2_SIZE is the size of a MarketV2 account, including the serum padding.
+const MARKET_V2_SIZE = 388
+
+// Flags that are set on all the initialized market accounts.
+const marketFlags = AccountFlagInitialized | AccountFlagMarket
+
+// FetchMarkets enumerates the MarketV2 accounts owned by the provided
+// DEX program and resolves the decimals of their base and quote mints.
+// Markets whose name is in the embedded known markets list get that name.
+func FetchMarkets(ctx context.Context, rpcCli *rpc.Client, programID solana.PublicKey) ([]*MarketMeta, error) {
+	// Only filter on the size: markets may have more flags than
+	// Initialized and Market set (e.g. Disabled), which are checked
+	// once decoded.
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		programID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					DataSize: MARKET_V2_SIZE,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get market accounts: %w", err)
+	}
+
+	names := map[solana.PublicKey]string{}
+	if known, err := KnownMarket(); err == nil {
+		for _, market := range known {
+			names[market.Address] = market.Name
+		}
+	}
+
+	var markets []*MarketMeta
+	var mints solana.PublicKeySlice
+	for _, keyedAcct := range resp {
+		meta := &MarketMeta{
+			Address:   keyedAcct.Pubkey,
+			Name:      names[keyedAcct.Pubkey],
+			ProgramID: programID,
+		}
+		if err := meta.MarketV2.Decode(keyedAcct.Account.Data.GetBinary()); err != nil {
+			zlog.Warn("unable to decode market account, skipping",
+				zap.Stringer("account_address", keyedAcct.Pubkey),
+				zap.Error(err),
+			)
+			continue
+		}
+		if flags := meta.MarketV2.AccountFlags; flags&marketFlags != marketFlags {
+			zlog.Debug("account is not an initialized market, skipping",
+				zap.Stringer("account_address", keyedAcct.Pubkey),
+				zap.Stringer("account_flags", &flags),
+			)
+			continue
+		}
+		markets = append(markets, meta)
+		mints = append(mints, meta.MarketV2.BaseMint, meta.MarketV2.QuoteMint)
+	}
+
+	resolved, err := fetchMints(ctx, rpcCli, mints.Dedupe())
+	if err != nil {
+		return nil, err
+	}
+	for _, market := range markets {
+		if mint, ok := resolved[market.MarketV2.BaseMint]; ok {
+			market.BaseMint = *mint
+		}
+		if mint, ok := resolved[market.MarketV2.QuoteMint]; ok {
+			market.QuoteMint = *mint
+		}
+	}
+
+	sort.Slice(markets, func(i, j int) bool {
+		if markets[i].Name != markets[j].Name {
+			return markets[i].Name < markets[j].Name
+		}
+		return markets[i].Address.String() < markets[j].Address.String()
+	})
+	return markets, nil
+}
+
+func fetchMints(ctx context.Context, rpcCli *rpc.Client, mints solana.PublicKeySlice) (map[solana.PublicKey]*token.Mint, error) {
+	resp, err := rpcCli.GetMultipleAccountsChunked(ctx, mints, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get mint accounts: %w", err)
+	}
+	out := make(map[solana.PublicKey]*token.Mint, len(mints))
+	for idx, acct := range resp.Value {
+		if acct == nil {
+			zlog.Debug("mint account not found", zap.Stringer("mint", mints[idx]))
+			continue
+		}
+		var mint token.Mint
+		if err := bin.NewBinDecoder(acct.Data.GetBinary()).Decode(&mint); err != nil {
+			return nil, fmt.Errorf("unable to decode mint %s: %w", mints[idx], err)
+		}
+		out[mints[idx]] = &mint
+	}
+	return out, nil
+}
+
+// MarketsCache is the on-disk representation of the markets discovered for a DEX program.
+type MarketsCache struct {
+	ProgramID solana.PublicKey `json:"programId"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	Markets   []*MarketMeta    `json:"markets"`
+}
+
+// IsFresh returns true if the cache was updated less than maxAge ago.
+func (c *MarketsCache) IsFresh(maxAge time.Duration) bool {
+	return time.Since(c.UpdatedAt) < maxAge
+}
+
+// LoadMarketsCache reads a markets cache file.
+func LoadMarketsCache(path string) (*MarketsCache, error) {
+	cnt, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &MarketsCache{}
+	if err := json.Unmarshal(cnt, cache); err != nil {
+		return nil, fmt.Errorf("unable to decode markets cache %q: %w", path, err)
+	}
+	return cache, nil
+}
+
+// Save writes the cache to the provided file, creating its directory if needed.
+func (c *MarketsCache) Save(path string) error {
+	cnt, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode markets cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create markets cache directory: %w", err)
+	}
+
+	// Write then rename, so that a concurrent reader never sees a partial file.
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, cnt, 0644); err != nil {
+		return fmt.Errorf("unable to write markets cache: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// DefaultMarketsCachePath returns the default location of the markets cache
+// of a DEX program, in the user cache directory.
+func DefaultMarketsCachePath(programID solana.PublicKey) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "solana-go", "serum", fmt.Sprintf("markets-%s.json", programID)), nil
+}
+
+// DiscoverMarkets returns the markets of the provided DEX program, reading them from
+// the cache file at `cachePath` if it was updated less than `maxAge` ago, or from
+// the chain otherwise, in which case the cache file is refreshed. A zero `maxAge`
+// always hits the chain. An empty `cachePath` disables caching.
+func DiscoverMarkets(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	programID solana.PublicKey,
+	cachePath string,
+	maxAge time.Duration,
+) ([]*MarketMeta, error) {
+	if cachePath != "" && maxAge > 0 {
+		cache, err := LoadMarketsCache(cachePath)
+		switch {
+		case err == nil && cache.ProgramID.Equals(programID) && cache.IsFresh(maxAge):
+			return cache.Markets, nil
+		case err != nil && !os.IsNotExist(err):
+			zlog.Warn("unable to load markets cache, fetching markets from chain",
+				zap.String("path", cachePath),
+				zap.Error(err),
+			)
+		}
+	}
+
+	markets, err := FetchMarkets(ctx, rpcCli, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		cache := &MarketsCache{
+			ProgramID: programID,
+			UpdatedAt: time.Now().UTC(),
+			Markets:   markets,
+		}
+		if err := cache.Save(cachePath); err != nil {
+			return nil, err
+		}
+	}
+	return markets, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/programs/token"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func encodeBase64Account(t *testing.T, v interface{}) map[string]interface{} {
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(v))
+	return map[string]interface{}{
+		"data":       []string{base64.StdEncoding.EncodeToString(buf.Bytes()), "base64"},
+		"executable": false,
+		"lamports":   1,
+		"owner":      DEXProgramIDV3.String(),
+		"rentEpoch":  0,
+	}
+}
+
+func mockDiscoveryRPC(t *testing.T, accounts map[solana.PublicKey]*MarketV2, mints map[solana.PublicKey]*token.Mint) (srv *httptest.Server, calls map[string]int) {
+	calls = map[string]int{}
+	srv = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		var request struct {
+			ID     interface{}        `json:"id"`
+			Method string             `json:"method"`
+			Params stdjson.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(body, &request))
+		calls[request.Method]++
+
+		var result interface{}
+		switch request.Method {
+		case "getProgramAccounts":
+			assert.Contains(t, string(request.Params), `"dataSize":388`)
+			assert.NotContains(t, string(request.Params), `"memcmp"`)
+			var values []interface{}
+			for address, account := range accounts {
+				values = append(values, map[string]interface{}{
+					"pubkey":  address.String(),
+					"account": encodeBase64Account(t, account),
+				})
+			}
+			result = values
+		case "getMultipleAccounts":
+			var params []stdjson.RawMessage
+			require.NoError(t, json.Unmarshal(request.Params, &params))
+			var keys []solana.PublicKey
+			require.NoError(t, json.Unmarshal(params[0], &keys))
+
+			var values []interface{}
+			for _, key := range keys {
+				if mint, ok := mints[key]; ok {
+					values = append(values, encodeBase64Account(t, mint))
+				} else {
+					values = append(values, nil)
+				}
+			}
+			result = map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value":   values,
+			}
+		default:
+			t.Fatalf("unexpected method %q", request.Method)
+		}
+
+		out, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result})
+		require.NoError(t, err)
+		rw.Write(out)
+	}))
+	return srv, calls
+}
+
+func TestDiscoverMarkets(t *testing.T) {
+	market := solana.MustPublicKeyFromBase58("9wFFyRfZBsuAha4YcuxcXLKwMxJR43S7fPfQLusDBzvT")
+	baseMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	quoteMint := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	marketV2 := &MarketV2{
+		SerumPadding: [5]byte{'s', 'e', 'r', 'u', 'm'},
+		AccountFlags: AccountFlagInitialized | AccountFlagMarket,
+		OwnAddress:   market,
+		BaseMint:     baseMint,
+		QuoteMint:    quoteMint,
+		BaseLotSize:  100000000,
+		QuoteLotSize: 100,
+		EndPadding:   [7]byte{'p', 'a', 'd', 'd', 'i', 'n', 'g'},
+	}
+	// A market with more flags than Initialized and Market:
+	disabledMarket := solana.MustPublicKeyFromBase58("HWHvQhFmJB3NUcu1aihKmrKegfVxBEHzwVX6yZCKEsi1")
+	disabledMarketV2 := *marketV2
+	disabledMarketV2.AccountFlags |= AccountFlagDisabled
+	disabledMarketV2.OwnAddress = disabledMarket
+	// An account of the same size that isn't a market:
+	notMarket := solana.MustPublicKeyFromBase58("5KKsLVU6TcbVDK4BS6K1DGDxnh4Q9xjYJ8XaDCG5t8ht")
+	notMarketV2 := *marketV2
+	notMarketV2.AccountFlags = AccountFlagInitialized | AccountFlagEventQueue
+
+	srv, calls := mockDiscoveryRPC(t, map[solana.PublicKey]*MarketV2{
+		market:         marketV2,
+		disabledMarket: &disabledMarketV2,
+		notMarket:      &notMarketV2,
+	}, map[solana.PublicKey]*token.Mint{
+		baseMint:  {Decimals: 9, IsInitialized: true},
+		quoteMint: {Decimals: 6, IsInitialized: true},
+	})
+	defer srv.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "markets.json")
+	rpcCli := rpc.New(srv.URL)
+
+	markets, err := DiscoverMarkets(context.Background(), rpcCli, DEXProgramIDV3, cachePath, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, markets, 2)
+	// Unnamed markets are sorted by address.
+	assert.Equal(t, disabledMarket, markets[1].Address)
+	assert.True(t, markets[1].MarketV2.AccountFlags.Is(AccountFlagDisabled))
+	assert.Equal(t, market, markets[0].Address)
+	assert.Equal(t, DEXProgramIDV3, markets[0].ProgramID)
+	assert.Equal(t, uint8(9), markets[0].BaseMint.Decimals)
+	assert.Equal(t, uint8(6), markets[0].QuoteMint.Decimals)
+	assert.Equal(t, bin.Uint64(100), markets[0].MarketV2.QuoteLotSize)
+	assert.Equal(t, 1, calls["getProgramAccounts"])
+
+	// Served from the cache.
+	markets, err = DiscoverMarkets(context.Background(), rpcCli, DEXProgramIDV3, cachePath, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, markets, 2)
+	assert.Equal(t, uint8(9), markets[0].BaseMint.Decimals)
+	assert.Equal(t, baseMint, markets[0].MarketV2.BaseMint)
+	assert.Equal(t, 1, calls["getProgramAccounts"])
+
+	// A cache for another program is ignored.
+	_, err = DiscoverMarkets(context.Background(), rpcCli, DEXProgramIDV2, cachePath, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls["getProgramAccounts"])
+
+	cache, err := LoadMarketsCache(cachePath)
+	require.NoError(t, err)
+	assert.Equal(t, DEXProgramIDV2, cache.ProgramID)
+	assert.False(t, cache.IsFresh(0))
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"fmt"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func explainInstruction(ctx *explain.Context, inst *explain.Instruction) []string {
+	decoded, ok := inst.Decoded.(*Instruction)
+	if !ok {
+		return nil
+	}
+
+	var side Side
+	var owner, market *solana.AccountMeta
+	switch impl := decoded.Impl.(type) {
+	case *InstructionNewOrder:
+		side, owner, market = impl.Side, impl.Accounts.Owner, impl.Accounts.Market
+	case *InstructionNewOrderV2:
+		side, owner, market = impl.Side, impl.Accounts.Owner, impl.Accounts.Market
+	case *InstructionNewOrderV3:
+		side, owner, market = impl.Side, impl.Accounts.Owner, impl.Accounts.Market
+	case *InstructionSendTake:
+		side, owner, market = impl.Side, impl.Accounts.Owner, impl.Accounts.Market
+	case *InstructionSettleFunds:
+		return []string{fmt.Sprintf("%s settled funds on Serum market %s", ctx.Label(impl.Accounts.Owner.PublicKey), ctx.Label(impl.Accounts.Market.PublicKey))}
+	default:
+		return nil
+	}
+
+	if swap, ok := ctx.DescribeSwap(owner.PublicKey); ok {
+		return []string{fmt.Sprintf("%s swapped %s on Serum", ctx.Label(owner.PublicKey), swap)}
+	}
+
+	order := "a bid"
+	if side == SideAsk {
+		order = "an ask"
+	}
+	return []string{fmt.Sprintf("%s placed %s on Serum market %s", ctx.Label(owner.PublicKey), order, ctx.Label(market.PublicKey))}
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func TestExplainSendTake(t *testing.T) {
+	keys := newTestKeys(11)
+	owner, coinWallet, pcWallet := keys[7], keys[5], keys[6]
+	coinMint, pcMint := solana.SolMint, solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	inst, err := NewSendTakeInstructionBuilder().
+		SetSide(SideBid).
+		SetLimitPrice(10).
+		SetMaxCoinQuantity(20).
+		SetMaxNativePCQuantityIncludingFees(30).
+		SetMinCoinQuantity(1).
+		SetMinNativePCQuantity(2).
+		SetLimit(5).
+		SetMarketAccount(keys[0]).
+		SetRequestQueueAccount(keys[1]).
+		SetEventQueueAccount(keys[2]).
+		SetBidsAccount(keys[3]).
+		SetAsksAccount(keys[4]).
+		SetCoinWalletAccount(coinWallet).
+		SetPCWalletAccount(pcWallet).
+		SetOwnerAccount(owner).
+		SetCoinVaultAccount(keys[8]).
+		SetPCVaultAccount(keys[9]).
+		SetVaultSignerAccount(keys[10]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	tx, err := solana.NewTransaction([]solana.Instruction{inst}, solana.Hash{}, solana.TransactionPayer(owner))
+	require.NoError(t, err)
+
+	index := func(pubkey solana.PublicKey) uint16 {
+		for i, key := range tx.Message.AccountKeys {
+			if key.Equals(pubkey) {
+				return uint16(i)
+			}
+		}
+		t.Fatalf("account %s not found", pubkey)
+		return 0
+	}
+	balance := func(account, mint solana.PublicKey, amount string, decimals uint8) rpc.TokenBalance {
+		return rpc.TokenBalance{AccountIndex: index(account), Owner: &owner, Mint: mint, UiTokenAmount: &rpc.UiTokenAmount{Amount: amount, Decimals: decimals}}
+	}
+
+	balances := make([]uint64, len(tx.Message.AccountKeys))
+	meta := &rpc.TransactionMeta{
+		PreBalances:       balances,
+		PostBalances:      balances,
+		PreTokenBalances:  []rpc.TokenBalance{balance(coinWallet, coinMint, "0", 9), balance(pcWallet, pcMint, "25000000", 6)},
+		PostTokenBalances: []rpc.TokenBalance{balance(coinWallet, coinMint, "50000000", 9), balance(pcWallet, pcMint, "15000000", 6)},
+	}
+
+	labels := map[solana.PublicKey]string{owner: "alice", pcMint: "USDC", keys[0]: "SOL/USDC"}
+	statements, err := explain.Explain(tx, meta, &explain.Options{Labels: labels})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alice swapped 10 USDC for 0.05 SOL on Serum",
+		"alice paid 0 SOL in fees",
+	}, statements)
+
+	statements, err = explain.Explain(tx, nil, &explain.Options{Labels: labels})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice placed a bid on Serum market SOL/USDC"}, statements)
+}
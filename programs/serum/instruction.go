@@ -18,17 +18,36 @@
 package serum
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 
 	bin "github.com/gagliardetto/binary"
 
 	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
 	"github.com/xmcontinue/solana-go/text"
 )
 
+// ProgramID is the program ID used by the instructions built with this package.
+var ProgramID = DEXProgramIDV3
+
+// SetProgramID sets the program ID used by the instructions built with this package,
+// and registers the instruction and account decoders for it.
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
+}
+
 func init() {
 	solana.RegisterInstructionDecoder(DEXProgramIDV2, registryDecodeInstruction)
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(DEXProgramIDV2)
+	registerAccountDecoders(ProgramID)
+	explain.RegisterExplainer(DEXProgramIDV2, explainInstruction)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
 }
 
 func registryDecodeInstruction(accounts []*solana.AccountMeta, data []byte) (interface{}, error) {
@@ -56,6 +75,26 @@ func DecodeInstruction(accounts []*solana.AccountMeta, data []byte) (*Instructio
 	return &inst, nil
 }
 
+const (
+	Instruction_InitializeMarket uint32 = iota
+	Instruction_NewOrder
+	Instruction_MatchOrder
+	Instruction_ConsumeEvents
+	Instruction_CancelOrder
+	Instruction_SettleFunds
+	Instruction_CancelOrderByClientId
+	Instruction_DisableMarket
+	Instruction_SweepFees
+	Instruction_NewOrderV2
+
+	// Added in DEX V3
+
+	Instruction_NewOrderV3
+	Instruction_CancelOrderV2
+	Instruction_CancelOrderByClientIdV2
+	Instruction_SendTake
+)
+
 var InstructionDefVariant = bin.NewVariantDefinition(bin.Uint32TypeIDEncoding, []bin.VariantType{
 	{Name: "initialize_market", Type: (*InstructionInitializeMarket)(nil)},
 	{Name: "new_order", Type: (*InstructionNewOrder)(nil)},
@@ -82,6 +121,27 @@ type Instruction struct {
 
 var _ bin.EncoderDecoder = &Instruction{}
 
+var _ solana.Instruction = &Instruction{}
+
+func (i *Instruction) ProgramID() solana.PublicKey {
+	return ProgramID
+}
+
+func (i *Instruction) Accounts() (out []*solana.AccountMeta) {
+	if v, ok := i.Impl.(solana.AccountsGettable); ok {
+		return v.GetAccounts()
+	}
+	return nil
+}
+
+func (i *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).Encode(i); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 func (i *Instruction) TextEncode(encoder *text.Encoder, option *text.Option) error {
 	return encoder.Encode(i.Impl, option)
 }
@@ -109,10 +169,15 @@ func (i Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
 
 type InitializeMarketAccounts struct {
 	Market        *solana.AccountMeta `text:"linear,notype"`
+	RequestQueue  *solana.AccountMeta `text:"linear,notype"`
+	EventQueue    *solana.AccountMeta `text:"linear,notype"`
+	Bids          *solana.AccountMeta `text:"linear,notype"`
+	Asks          *solana.AccountMeta `text:"linear,notype"`
 	SPLCoinToken  *solana.AccountMeta `text:"linear,notype"`
 	SPLPriceToken *solana.AccountMeta `text:"linear,notype"`
 	CoinMint      *solana.AccountMeta `text:"linear,notype"`
 	PriceMint     *solana.AccountMeta `text:"linear,notype"`
+	Rent          *solana.AccountMeta `text:"linear,notype"`
 }
 
 type InstructionInitializeMarket struct {
@@ -131,11 +196,18 @@ func (i *InstructionInitializeMarket) SetAccounts(accounts []*solana.AccountMeta
 	}
 	i.Accounts = &InitializeMarketAccounts{
 		Market:        accounts[0],
+		RequestQueue:  accounts[1],
+		EventQueue:    accounts[2],
+		Bids:          accounts[3],
+		Asks:          accounts[4],
 		SPLCoinToken:  accounts[5],
 		SPLPriceToken: accounts[6],
 		CoinMint:      accounts[7],
 		PriceMint:     accounts[8],
 	}
+	if len(accounts) >= 10 {
+		i.Accounts.Rent = accounts[9]
+	}
 	return nil
 }
 
@@ -495,8 +567,8 @@ type InstructionNewOrderV3 struct {
 }
 
 func (i *InstructionNewOrderV3) SetAccounts(accounts []*solana.AccountMeta) error {
-	if len(accounts) < 13 {
-		return fmt.Errorf("insufficient account, New Order V3 requires at-least 13 accounts not %d", len(accounts))
+	if len(accounts) < 12 {
+		return fmt.Errorf("insufficient account, New Order V3 requires at-least 12 accounts + 1 optional not %d", len(accounts))
 	}
 
 	i.Accounts = &NewOrderV3Accounts{
@@ -512,7 +584,10 @@ func (i *InstructionNewOrderV3) SetAccounts(accounts []*solana.AccountMeta) erro
 		PCVault:         accounts[9],
 		SPLTokenProgram: accounts[10],
 		RentSysvar:      accounts[11],
-		FeeDiscount:     accounts[12],
+	}
+
+	if len(accounts) >= 13 {
+		i.Accounts.FeeDiscount = accounts[12]
 	}
 
 	return nil
@@ -581,13 +656,20 @@ func (i *InstructionCancelOrderByClientIdV2) SetAccounts(accounts []*solana.Acco
 	return nil
 }
 
-// InstructionSendTakeAccounts defined from comment in serum-dex contract code, was never able to validate it's correct
 type InstructionSendTakeAccounts struct {
-	Market     *solana.AccountMeta `text:"linear,notype"` // 0. `[writable]` market
-	Bids       *solana.AccountMeta `text:"linear,notype"` // 1. `[writable]` bids
-	Asks       *solana.AccountMeta `text:"linear,notype"` // 2. `[writable]` asks
-	OpenOrders *solana.AccountMeta `text:"linear,notype"` // 3. `[writable]` OpenOrders
-	Owner      *solana.AccountMeta `text:"linear,notype"` // 4. `[]`
+	Market          *solana.AccountMeta `text:"linear,notype"` // 0. `[writable]` market
+	RequestQueue    *solana.AccountMeta `text:"linear,notype"` // 1. `[writable]` the request queue
+	EventQueue      *solana.AccountMeta `text:"linear,notype"` // 2. `[writable]` the event queue
+	Bids            *solana.AccountMeta `text:"linear,notype"` // 3. `[writable]` bids
+	Asks            *solana.AccountMeta `text:"linear,notype"` // 4. `[writable]` asks
+	CoinWallet      *solana.AccountMeta `text:"linear,notype"` // 5. `[writable]` the coin currency wallet account
+	PCWallet        *solana.AccountMeta `text:"linear,notype"` // 6. `[writable]` the price currency wallet account
+	Owner           *solana.AccountMeta `text:"linear,notype"` // 7. `[signer]` the owner of the wallets
+	CoinVault       *solana.AccountMeta `text:"linear,notype"` // 8. `[writable]` coin vault
+	PCVault         *solana.AccountMeta `text:"linear,notype"` // 9. `[writable]` pc vault
+	SPLTokenProgram *solana.AccountMeta `text:"linear,notype"` // 10. `[]` spl token program
+	VaultSigner     *solana.AccountMeta `text:"linear,notype"` // 11. `[]` the vault signer
+	FeeDiscount     *solana.AccountMeta `text:"linear,notype"` // 12. `[]` (optional) the (M)SRM account used for fee discounts
 }
 
 type InstructionSendTake struct {
@@ -603,15 +685,25 @@ type InstructionSendTake struct {
 }
 
 func (i *InstructionSendTake) SetAccounts(accounts []*solana.AccountMeta) error {
-	if len(accounts) < 5 {
-		return fmt.Errorf("insufficient account, Send Take requires at-least 5 accounts not %d", len(accounts))
+	if len(accounts) < 12 {
+		return fmt.Errorf("insufficient account, Send Take requires at-least 12 accounts + 1 optional not %d", len(accounts))
 	}
 	i.Accounts = &InstructionSendTakeAccounts{
-		Market:     accounts[0],
-		Bids:       accounts[1],
-		Asks:       accounts[2],
-		OpenOrders: accounts[3],
-		Owner:      accounts[4],
+		Market:          accounts[0],
+		RequestQueue:    accounts[1],
+		EventQueue:      accounts[2],
+		Bids:            accounts[3],
+		Asks:            accounts[4],
+		CoinWallet:      accounts[5],
+		PCWallet:        accounts[6],
+		Owner:           accounts[7],
+		CoinVault:       accounts[8],
+		PCVault:         accounts[9],
+		SPLTokenProgram: accounts[10],
+		VaultSigner:     accounts[11],
+	}
+	if len(accounts) >= 13 {
+		i.Accounts.FeeDiscount = accounts[12]
 	}
 	return nil
 }
@@ -0,0 +1,2046 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// NewInitializeMarketInstructionBuilder creates a new `InstructionInitializeMarket` instruction builder.
+func NewInitializeMarketInstructionBuilder() *InstructionInitializeMarket {
+	nd := &InstructionInitializeMarket{
+		Accounts: &InitializeMarketAccounts{},
+	}
+	nd.Accounts.Rent = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetBaseLotSize sets the "baseLotSize" parameter.
+func (i *InstructionInitializeMarket) SetBaseLotSize(baseLotSize uint64) *InstructionInitializeMarket {
+	i.BaseLotSize = baseLotSize
+	return i
+}
+
+// SetQuoteLotSize sets the "quoteLotSize" parameter.
+func (i *InstructionInitializeMarket) SetQuoteLotSize(quoteLotSize uint64) *InstructionInitializeMarket {
+	i.QuoteLotSize = quoteLotSize
+	return i
+}
+
+// SetFeeRateBps sets the "feeRateBps" parameter.
+func (i *InstructionInitializeMarket) SetFeeRateBps(feeRateBps uint16) *InstructionInitializeMarket {
+	i.FeeRateBps = feeRateBps
+	return i
+}
+
+// SetVaultSignerNonce sets the "vaultSignerNonce" parameter.
+func (i *InstructionInitializeMarket) SetVaultSignerNonce(vaultSignerNonce uint64) *InstructionInitializeMarket {
+	i.VaultSignerNonce = vaultSignerNonce
+	return i
+}
+
+// SetQuoteDustThreshold sets the "quoteDustThreshold" parameter.
+func (i *InstructionInitializeMarket) SetQuoteDustThreshold(quoteDustThreshold uint64) *InstructionInitializeMarket {
+	i.QuoteDustThreshold = quoteDustThreshold
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market to initialize).
+func (i *InstructionInitializeMarket) SetMarketAccount(market solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (zeroed out request queue).
+func (i *InstructionInitializeMarket) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (zeroed out event queue).
+func (i *InstructionInitializeMarket) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// SetBidsAccount sets the "bids" account (zeroed out bids).
+func (i *InstructionInitializeMarket) SetBidsAccount(bids solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.Bids = solana.Meta(bids).WRITE()
+	return i
+}
+
+// SetAsksAccount sets the "asks" account (zeroed out asks).
+func (i *InstructionInitializeMarket) SetAsksAccount(asks solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.Asks = solana.Meta(asks).WRITE()
+	return i
+}
+
+// SetSPLCoinTokenAccount sets the "splCoinToken" account (spl-token account for the coin currency).
+func (i *InstructionInitializeMarket) SetSPLCoinTokenAccount(splCoinToken solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.SPLCoinToken = solana.Meta(splCoinToken).WRITE()
+	return i
+}
+
+// SetSPLPriceTokenAccount sets the "splPriceToken" account (spl-token account for the price currency).
+func (i *InstructionInitializeMarket) SetSPLPriceTokenAccount(splPriceToken solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.SPLPriceToken = solana.Meta(splPriceToken).WRITE()
+	return i
+}
+
+// SetCoinMintAccount sets the "coinMint" account (coin currency Mint).
+func (i *InstructionInitializeMarket) SetCoinMintAccount(coinMint solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.CoinMint = solana.Meta(coinMint)
+	return i
+}
+
+// SetPriceMintAccount sets the "priceMint" account (price currency Mint).
+func (i *InstructionInitializeMarket) SetPriceMintAccount(priceMint solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.PriceMint = solana.Meta(priceMint)
+	return i
+}
+
+// SetRentAccount sets the "rent" account (the rent sysvar).
+func (i *InstructionInitializeMarket) SetRentAccount(rent solana.PublicKey) *InstructionInitializeMarket {
+	i.Accounts.Rent = solana.Meta(rent)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionInitializeMarket) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.RequestQueue,
+		i.Accounts.EventQueue,
+		i.Accounts.Bids,
+		i.Accounts.Asks,
+		i.Accounts.SPLCoinToken,
+		i.Accounts.SPLPriceToken,
+		i.Accounts.CoinMint,
+		i.Accounts.PriceMint,
+		i.Accounts.Rent,
+	)
+	return
+}
+
+func (i InstructionInitializeMarket) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_InitializeMarket, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionInitializeMarket) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionInitializeMarket) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if i.BaseLotSize == 0 {
+			return errors.New("BaseLotSize parameter is not set")
+		}
+		if i.QuoteLotSize == 0 {
+			return errors.New("QuoteLotSize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+		if i.Accounts.Bids == nil {
+			return fmt.Errorf("accounts.Bids is not set")
+		}
+		if i.Accounts.Asks == nil {
+			return fmt.Errorf("accounts.Asks is not set")
+		}
+		if i.Accounts.SPLCoinToken == nil {
+			return fmt.Errorf("accounts.SPLCoinToken is not set")
+		}
+		if i.Accounts.SPLPriceToken == nil {
+			return fmt.Errorf("accounts.SPLPriceToken is not set")
+		}
+		if i.Accounts.CoinMint == nil {
+			return fmt.Errorf("accounts.CoinMint is not set")
+		}
+		if i.Accounts.PriceMint == nil {
+			return fmt.Errorf("accounts.PriceMint is not set")
+		}
+		if i.Accounts.Rent == nil {
+			return fmt.Errorf("accounts.Rent is not set")
+		}
+	}
+	return nil
+}
+
+// NewNewOrderInstructionBuilder creates a new `InstructionNewOrder` instruction builder.
+func NewNewOrderInstructionBuilder() *InstructionNewOrder {
+	nd := &InstructionNewOrder{
+		Accounts: &NewOrderAccounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	nd.Accounts.Rent = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionNewOrder) SetSide(side Side) *InstructionNewOrder {
+	i.Side = side
+	return i
+}
+
+// SetLimitPrice sets the "limitPrice" parameter.
+func (i *InstructionNewOrder) SetLimitPrice(limitPrice uint64) *InstructionNewOrder {
+	i.LimitPrice = limitPrice
+	return i
+}
+
+// SetMaxQuantity sets the "maxQuantity" parameter.
+func (i *InstructionNewOrder) SetMaxQuantity(maxQuantity uint64) *InstructionNewOrder {
+	i.MaxQuantity = maxQuantity
+	return i
+}
+
+// SetOrderType sets the "orderType" parameter.
+func (i *InstructionNewOrder) SetOrderType(orderType OrderType) *InstructionNewOrder {
+	i.OrderType = orderType
+	return i
+}
+
+// SetClientID sets the "clientID" parameter.
+func (i *InstructionNewOrder) SetClientID(clientID uint64) *InstructionNewOrder {
+	i.ClientID = clientID
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionNewOrder) SetMarketAccount(market solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account to use).
+func (i *InstructionNewOrder) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionNewOrder) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetPayerAccount sets the "payer" account (the (coin or price currency) account paying for the order).
+func (i *InstructionNewOrder) SetPayerAccount(payer solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.Payer = solana.Meta(payer).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (owner of the OpenOrders account).
+func (i *InstructionNewOrder) SetOwnerAccount(owner solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetCoinVaultAccount sets the "coinVault" account (coin vault).
+func (i *InstructionNewOrder) SetCoinVaultAccount(coinVault solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.CoinVault = solana.Meta(coinVault).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionNewOrder) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionNewOrder) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// SetRentAccount sets the "rent" account (the rent sysvar).
+func (i *InstructionNewOrder) SetRentAccount(rent solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.Rent = solana.Meta(rent)
+	return i
+}
+
+// SetSRMDiscountAccount sets the "srmDiscountAccount" account ((optional) the (M)SRM account used for fee discounts).
+func (i *InstructionNewOrder) SetSRMDiscountAccount(srmDiscountAccount solana.PublicKey) *InstructionNewOrder {
+	i.Accounts.SRMDiscountAccount = solana.Meta(srmDiscountAccount)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionNewOrder) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.RequestQueue,
+		i.Accounts.Payer,
+		i.Accounts.Owner,
+		i.Accounts.CoinVault,
+		i.Accounts.PCVault,
+		i.Accounts.SPLTokenProgram,
+		i.Accounts.Rent,
+	)
+	if i.Accounts.SRMDiscountAccount != nil {
+		out = append(out, i.Accounts.SRMDiscountAccount)
+	}
+	return
+}
+
+func (i InstructionNewOrder) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_NewOrder, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionNewOrder) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionNewOrder) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if i.LimitPrice == 0 {
+			return errors.New("LimitPrice parameter is not set")
+		}
+		if i.MaxQuantity == 0 {
+			return errors.New("MaxQuantity parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.Payer == nil {
+			return fmt.Errorf("accounts.Payer is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.CoinVault == nil {
+			return fmt.Errorf("accounts.CoinVault is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+		if i.Accounts.Rent == nil {
+			return fmt.Errorf("accounts.Rent is not set")
+		}
+	}
+	return nil
+}
+
+// NewMatchOrderInstructionBuilder creates a new `InstructionMatchOrder` instruction builder.
+func NewMatchOrderInstructionBuilder() *InstructionMatchOrder {
+	nd := &InstructionMatchOrder{
+		Accounts: &MatchOrderAccounts{},
+	}
+	return nd
+}
+
+// SetLimit sets the "limit" parameter.
+func (i *InstructionMatchOrder) SetLimit(limit uint16) *InstructionMatchOrder {
+	i.Limit = limit
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionMatchOrder) SetMarketAccount(market solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionMatchOrder) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (the event queue).
+func (i *InstructionMatchOrder) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// SetBidsAccount sets the "bids" account (bids).
+func (i *InstructionMatchOrder) SetBidsAccount(bids solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.Bids = solana.Meta(bids).WRITE()
+	return i
+}
+
+// SetAsksAccount sets the "asks" account (asks).
+func (i *InstructionMatchOrder) SetAsksAccount(asks solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.Asks = solana.Meta(asks).WRITE()
+	return i
+}
+
+// SetCoinFeeReceivableAccount sets the "coinFeeReceivable" account (coin fee receivable account).
+func (i *InstructionMatchOrder) SetCoinFeeReceivableAccount(coinFeeReceivable solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.CoinFeeReceivable = solana.Meta(coinFeeReceivable).WRITE()
+	return i
+}
+
+// SetPCFeeReceivableAccount sets the "pcFeeReceivable" account (pc fee receivable account).
+func (i *InstructionMatchOrder) SetPCFeeReceivableAccount(pcFeeReceivable solana.PublicKey) *InstructionMatchOrder {
+	i.Accounts.PCFeeReceivable = solana.Meta(pcFeeReceivable).WRITE()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionMatchOrder) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.RequestQueue,
+		i.Accounts.EventQueue,
+		i.Accounts.Bids,
+		i.Accounts.Asks,
+		i.Accounts.CoinFeeReceivable,
+		i.Accounts.PCFeeReceivable,
+	)
+	return
+}
+
+func (i InstructionMatchOrder) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_MatchOrder, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionMatchOrder) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionMatchOrder) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+		if i.Accounts.Bids == nil {
+			return fmt.Errorf("accounts.Bids is not set")
+		}
+		if i.Accounts.Asks == nil {
+			return fmt.Errorf("accounts.Asks is not set")
+		}
+		if i.Accounts.CoinFeeReceivable == nil {
+			return fmt.Errorf("accounts.CoinFeeReceivable is not set")
+		}
+		if i.Accounts.PCFeeReceivable == nil {
+			return fmt.Errorf("accounts.PCFeeReceivable is not set")
+		}
+	}
+	return nil
+}
+
+// NewConsumeEventsInstructionBuilder creates a new `InstructionConsumeEvents` instruction builder.
+func NewConsumeEventsInstructionBuilder() *InstructionConsumeEvents {
+	nd := &InstructionConsumeEvents{
+		Accounts: &ConsumeEventsAccounts{},
+	}
+	return nd
+}
+
+// SetLimit sets the "limit" parameter.
+func (i *InstructionConsumeEvents) SetLimit(limit uint16) *InstructionConsumeEvents {
+	i.Limit = limit
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionConsumeEvents) SetMarketAccount(market solana.PublicKey) *InstructionConsumeEvents {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (the event queue).
+func (i *InstructionConsumeEvents) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionConsumeEvents {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// SetCoinFeeReceivableAccount sets the "coinFeeReceivable" account (coin fee receivable account).
+func (i *InstructionConsumeEvents) SetCoinFeeReceivableAccount(coinFeeReceivable solana.PublicKey) *InstructionConsumeEvents {
+	i.Accounts.CoinFeeReceivable = solana.Meta(coinFeeReceivable).WRITE()
+	return i
+}
+
+// SetPCFeeReceivableAccount sets the "pcFeeReceivable" account (pc fee receivable account).
+func (i *InstructionConsumeEvents) SetPCFeeReceivableAccount(pcFeeReceivable solana.PublicKey) *InstructionConsumeEvents {
+	i.Accounts.PCFeeReceivable = solana.Meta(pcFeeReceivable).WRITE()
+	return i
+}
+
+// AppendOpenOrdersAccount appends an OpenOrders account whose events are to be consumed.
+func (i *InstructionConsumeEvents) AppendOpenOrdersAccount(openOrders solana.PublicKey) *InstructionConsumeEvents {
+	i.Accounts.OpenOrders = append(i.Accounts.OpenOrders, solana.Meta(openOrders).WRITE())
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionConsumeEvents) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out, i.Accounts.OpenOrders...)
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.EventQueue,
+		i.Accounts.CoinFeeReceivable,
+		i.Accounts.PCFeeReceivable,
+	)
+	return
+}
+
+func (i InstructionConsumeEvents) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_ConsumeEvents, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionConsumeEvents) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionConsumeEvents) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+		if i.Accounts.CoinFeeReceivable == nil {
+			return fmt.Errorf("accounts.CoinFeeReceivable is not set")
+		}
+		if i.Accounts.PCFeeReceivable == nil {
+			return fmt.Errorf("accounts.PCFeeReceivable is not set")
+		}
+		if len(i.Accounts.OpenOrders) == 0 {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+	}
+	return nil
+}
+
+// NewCancelOrderInstructionBuilder creates a new `InstructionCancelOrder` instruction builder.
+func NewCancelOrderInstructionBuilder() *InstructionCancelOrder {
+	nd := &InstructionCancelOrder{
+		Accounts: &CancelOrderAccounts{},
+	}
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionCancelOrder) SetSide(side Side) *InstructionCancelOrder {
+	i.Side = side
+	return i
+}
+
+// SetOrderID sets the "orderID" parameter.
+func (i *InstructionCancelOrder) SetOrderID(orderID bin.Uint128) *InstructionCancelOrder {
+	i.OrderID = orderID
+	return i
+}
+
+// SetOpenOrders sets the "openOrders" parameter.
+func (i *InstructionCancelOrder) SetOpenOrders(openOrders solana.PublicKey) *InstructionCancelOrder {
+	i.OpenOrders = openOrders
+	return i
+}
+
+// SetOpenOrderSlot sets the "openOrderSlot" parameter.
+func (i *InstructionCancelOrder) SetOpenOrderSlot(openOrderSlot uint8) *InstructionCancelOrder {
+	i.OpenOrderSlot = openOrderSlot
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionCancelOrder) SetMarketAccount(market solana.PublicKey) *InstructionCancelOrder {
+	i.Accounts.Market = solana.Meta(market)
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account).
+func (i *InstructionCancelOrder) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionCancelOrder {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionCancelOrder) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionCancelOrder {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the OpenOrders owner).
+func (i *InstructionCancelOrder) SetOwnerAccount(owner solana.PublicKey) *InstructionCancelOrder {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionCancelOrder) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.RequestQueue,
+		i.Accounts.Owner,
+	)
+	return
+}
+
+func (i InstructionCancelOrder) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_CancelOrder, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionCancelOrder) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionCancelOrder) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+	}
+	return nil
+}
+
+// NewSettleFundsInstructionBuilder creates a new `InstructionSettleFunds` instruction builder.
+func NewSettleFundsInstructionBuilder() *InstructionSettleFunds {
+	nd := &InstructionSettleFunds{
+		Accounts: &SettleFundsAccounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	return nd
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionSettleFunds) SetMarketAccount(market solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account to settle).
+func (i *InstructionSettleFunds) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the OpenOrders owner).
+func (i *InstructionSettleFunds) SetOwnerAccount(owner solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetCoinVaultAccount sets the "coinVault" account (coin vault).
+func (i *InstructionSettleFunds) SetCoinVaultAccount(coinVault solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.CoinVault = solana.Meta(coinVault).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionSettleFunds) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetCoinWalletAccount sets the "coinWallet" account (coin wallet).
+func (i *InstructionSettleFunds) SetCoinWalletAccount(coinWallet solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.CoinWallet = solana.Meta(coinWallet).WRITE()
+	return i
+}
+
+// SetPCWalletAccount sets the "pcWallet" account (pc wallet).
+func (i *InstructionSettleFunds) SetPCWalletAccount(pcWallet solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.PCWallet = solana.Meta(pcWallet).WRITE()
+	return i
+}
+
+// SetSignerAccount sets the "signer" account (the vault signer).
+func (i *InstructionSettleFunds) SetSignerAccount(signer solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.Signer = solana.Meta(signer)
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionSettleFunds) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// SetReferrerPCWalletAccount sets the "referrerPCWallet" account ((optional) referrer pc wallet).
+func (i *InstructionSettleFunds) SetReferrerPCWalletAccount(referrerPCWallet solana.PublicKey) *InstructionSettleFunds {
+	i.Accounts.ReferrerPCWallet = solana.Meta(referrerPCWallet).WRITE()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionSettleFunds) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.Owner,
+		i.Accounts.CoinVault,
+		i.Accounts.PCVault,
+		i.Accounts.CoinWallet,
+		i.Accounts.PCWallet,
+		i.Accounts.Signer,
+		i.Accounts.SPLTokenProgram,
+	)
+	if i.Accounts.ReferrerPCWallet != nil {
+		out = append(out, i.Accounts.ReferrerPCWallet)
+	}
+	return
+}
+
+func (i InstructionSettleFunds) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_SettleFunds, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionSettleFunds) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionSettleFunds) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.CoinVault == nil {
+			return fmt.Errorf("accounts.CoinVault is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.CoinWallet == nil {
+			return fmt.Errorf("accounts.CoinWallet is not set")
+		}
+		if i.Accounts.PCWallet == nil {
+			return fmt.Errorf("accounts.PCWallet is not set")
+		}
+		if i.Accounts.Signer == nil {
+			return fmt.Errorf("accounts.Signer is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+// NewCancelOrderByClientIdInstructionBuilder creates a new `InstructionCancelOrderByClientId` instruction builder.
+func NewCancelOrderByClientIdInstructionBuilder() *InstructionCancelOrderByClientId {
+	nd := &InstructionCancelOrderByClientId{
+		Accounts: &CancelOrderByClientIdAccounts{},
+	}
+	return nd
+}
+
+// SetClientID sets the "clientID" parameter.
+func (i *InstructionCancelOrderByClientId) SetClientID(clientID uint64) *InstructionCancelOrderByClientId {
+	i.ClientID = clientID
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionCancelOrderByClientId) SetMarketAccount(market solana.PublicKey) *InstructionCancelOrderByClientId {
+	i.Accounts.Market = solana.Meta(market)
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account).
+func (i *InstructionCancelOrderByClientId) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionCancelOrderByClientId {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionCancelOrderByClientId) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionCancelOrderByClientId {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the OpenOrders owner).
+func (i *InstructionCancelOrderByClientId) SetOwnerAccount(owner solana.PublicKey) *InstructionCancelOrderByClientId {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionCancelOrderByClientId) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.RequestQueue,
+		i.Accounts.Owner,
+	)
+	return
+}
+
+func (i InstructionCancelOrderByClientId) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_CancelOrderByClientId, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionCancelOrderByClientId) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionCancelOrderByClientId) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+	}
+	return nil
+}
+
+// NewDisableMarketInstructionBuilder creates a new `InstructionDisableMarketAccounts` instruction builder.
+func NewDisableMarketInstructionBuilder() *InstructionDisableMarketAccounts {
+	nd := &InstructionDisableMarketAccounts{
+		Accounts: &DisableMarketAccounts{},
+	}
+	return nd
+}
+
+// SetMarketAccount sets the "market" account (the market to disable).
+func (i *InstructionDisableMarketAccounts) SetMarketAccount(market solana.PublicKey) *InstructionDisableMarketAccounts {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetDisableAuthorityAccount sets the "disableAuthority" account (the disable authority).
+func (i *InstructionDisableMarketAccounts) SetDisableAuthorityAccount(disableAuthority solana.PublicKey) *InstructionDisableMarketAccounts {
+	i.Accounts.DisableAuthority = solana.Meta(disableAuthority).SIGNER()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionDisableMarketAccounts) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.DisableAuthority,
+	)
+	return
+}
+
+func (i InstructionDisableMarketAccounts) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_DisableMarket, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionDisableMarketAccounts) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionDisableMarketAccounts) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.DisableAuthority == nil {
+			return fmt.Errorf("accounts.DisableAuthority is not set")
+		}
+	}
+	return nil
+}
+
+// NewSweepFeesInstructionBuilder creates a new `InstructionSweepFees` instruction builder.
+func NewSweepFeesInstructionBuilder() *InstructionSweepFees {
+	nd := &InstructionSweepFees{
+		Accounts: &SweepFeesAccounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	return nd
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionSweepFees) SetMarketAccount(market solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionSweepFees) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetFeeSweepingAuthorityAccount sets the "feeSweepingAuthority" account (the fee sweeping authority).
+func (i *InstructionSweepFees) SetFeeSweepingAuthorityAccount(feeSweepingAuthority solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.FeeSweepingAuthority = solana.Meta(feeSweepingAuthority).SIGNER()
+	return i
+}
+
+// SetFeeReceivableAccount sets the "feeReceivableAccount" account (the fee receivable account).
+func (i *InstructionSweepFees) SetFeeReceivableAccount(feeReceivableAccount solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.FeeReceivableAccount = solana.Meta(feeReceivableAccount).WRITE()
+	return i
+}
+
+// SetVaultSignerAccount sets the "vaultSigner" account (the vault signer).
+func (i *InstructionSweepFees) SetVaultSignerAccount(vaultSigner solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.VaultSigner = solana.Meta(vaultSigner)
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionSweepFees) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionSweepFees {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionSweepFees) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.PCVault,
+		i.Accounts.FeeSweepingAuthority,
+		i.Accounts.FeeReceivableAccount,
+		i.Accounts.VaultSigner,
+		i.Accounts.SPLTokenProgram,
+	)
+	return
+}
+
+func (i InstructionSweepFees) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_SweepFees, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionSweepFees) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionSweepFees) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.FeeSweepingAuthority == nil {
+			return fmt.Errorf("accounts.FeeSweepingAuthority is not set")
+		}
+		if i.Accounts.FeeReceivableAccount == nil {
+			return fmt.Errorf("accounts.FeeReceivableAccount is not set")
+		}
+		if i.Accounts.VaultSigner == nil {
+			return fmt.Errorf("accounts.VaultSigner is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+// NewNewOrderV2InstructionBuilder creates a new `InstructionNewOrderV2` instruction builder.
+func NewNewOrderV2InstructionBuilder() *InstructionNewOrderV2 {
+	nd := &InstructionNewOrderV2{
+		Accounts: &NewOrderV2Accounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	nd.Accounts.RentSysvar = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionNewOrderV2) SetSide(side Side) *InstructionNewOrderV2 {
+	i.Side = side
+	return i
+}
+
+// SetLimitPrice sets the "limitPrice" parameter.
+func (i *InstructionNewOrderV2) SetLimitPrice(limitPrice uint64) *InstructionNewOrderV2 {
+	i.LimitPrice = limitPrice
+	return i
+}
+
+// SetMaxQuantity sets the "maxQuantity" parameter.
+func (i *InstructionNewOrderV2) SetMaxQuantity(maxQuantity uint64) *InstructionNewOrderV2 {
+	i.MaxQuantity = maxQuantity
+	return i
+}
+
+// SetOrderType sets the "orderType" parameter.
+func (i *InstructionNewOrderV2) SetOrderType(orderType OrderType) *InstructionNewOrderV2 {
+	i.OrderType = orderType
+	return i
+}
+
+// SetClientID sets the "clientID" parameter.
+func (i *InstructionNewOrderV2) SetClientID(clientID uint64) *InstructionNewOrderV2 {
+	i.ClientID = clientID
+	return i
+}
+
+// SetSelfTradeBehavior sets the "selfTradeBehavior" parameter.
+func (i *InstructionNewOrderV2) SetSelfTradeBehavior(selfTradeBehavior SelfTradeBehavior) *InstructionNewOrderV2 {
+	i.SelfTradeBehavior = selfTradeBehavior
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionNewOrderV2) SetMarketAccount(market solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account to use).
+func (i *InstructionNewOrderV2) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionNewOrderV2) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetPayerAccount sets the "payer" account (the (coin or price currency) account paying for the order).
+func (i *InstructionNewOrderV2) SetPayerAccount(payer solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.Payer = solana.Meta(payer).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (owner of the OpenOrders account).
+func (i *InstructionNewOrderV2) SetOwnerAccount(owner solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetCoinVaultAccount sets the "coinVault" account (coin vault).
+func (i *InstructionNewOrderV2) SetCoinVaultAccount(coinVault solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.CoinVault = solana.Meta(coinVault).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionNewOrderV2) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionNewOrderV2) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// SetRentSysvarAccount sets the "rentSysvar" account (the rent sysvar).
+func (i *InstructionNewOrderV2) SetRentSysvarAccount(rentSysvar solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.RentSysvar = solana.Meta(rentSysvar)
+	return i
+}
+
+// SetFeeDiscountAccount sets the "feeDiscount" account ((optional) the (M)SRM account used for fee discounts).
+func (i *InstructionNewOrderV2) SetFeeDiscountAccount(feeDiscount solana.PublicKey) *InstructionNewOrderV2 {
+	i.Accounts.FeeDiscount = solana.Meta(feeDiscount)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionNewOrderV2) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.RequestQueue,
+		i.Accounts.Payer,
+		i.Accounts.Owner,
+		i.Accounts.CoinVault,
+		i.Accounts.PCVault,
+		i.Accounts.SPLTokenProgram,
+		i.Accounts.RentSysvar,
+	)
+	if i.Accounts.FeeDiscount != nil {
+		out = append(out, i.Accounts.FeeDiscount)
+	}
+	return
+}
+
+func (i InstructionNewOrderV2) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_NewOrderV2, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionNewOrderV2) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionNewOrderV2) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if i.LimitPrice == 0 {
+			return errors.New("LimitPrice parameter is not set")
+		}
+		if i.MaxQuantity == 0 {
+			return errors.New("MaxQuantity parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.Payer == nil {
+			return fmt.Errorf("accounts.Payer is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.CoinVault == nil {
+			return fmt.Errorf("accounts.CoinVault is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+		if i.Accounts.RentSysvar == nil {
+			return fmt.Errorf("accounts.RentSysvar is not set")
+		}
+	}
+	return nil
+}
+
+// NewNewOrderV3InstructionBuilder creates a new `InstructionNewOrderV3` instruction builder.
+func NewNewOrderV3InstructionBuilder() *InstructionNewOrderV3 {
+	nd := &InstructionNewOrderV3{
+		Accounts: &NewOrderV3Accounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	nd.Accounts.RentSysvar = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionNewOrderV3) SetSide(side Side) *InstructionNewOrderV3 {
+	i.Side = side
+	return i
+}
+
+// SetLimitPrice sets the "limitPrice" parameter.
+func (i *InstructionNewOrderV3) SetLimitPrice(limitPrice uint64) *InstructionNewOrderV3 {
+	i.LimitPrice = limitPrice
+	return i
+}
+
+// SetMaxCoinQuantity sets the "maxCoinQuantity" parameter.
+func (i *InstructionNewOrderV3) SetMaxCoinQuantity(maxCoinQuantity uint64) *InstructionNewOrderV3 {
+	i.MaxCoinQuantity = maxCoinQuantity
+	return i
+}
+
+// SetMaxNativePCQuantityIncludingFees sets the "maxNativePCQuantityIncludingFees" parameter.
+func (i *InstructionNewOrderV3) SetMaxNativePCQuantityIncludingFees(maxNativePCQuantityIncludingFees uint64) *InstructionNewOrderV3 {
+	i.MaxNativePCQuantityIncludingFees = maxNativePCQuantityIncludingFees
+	return i
+}
+
+// SetSelfTradeBehavior sets the "selfTradeBehavior" parameter.
+func (i *InstructionNewOrderV3) SetSelfTradeBehavior(selfTradeBehavior SelfTradeBehavior) *InstructionNewOrderV3 {
+	i.SelfTradeBehavior = selfTradeBehavior
+	return i
+}
+
+// SetOrderType sets the "orderType" parameter.
+func (i *InstructionNewOrderV3) SetOrderType(orderType OrderType) *InstructionNewOrderV3 {
+	i.OrderType = orderType
+	return i
+}
+
+// SetClientOrderID sets the "clientOrderID" parameter.
+func (i *InstructionNewOrderV3) SetClientOrderID(clientOrderID uint64) *InstructionNewOrderV3 {
+	i.ClientOrderID = clientOrderID
+	return i
+}
+
+// SetLimit sets the "limit" parameter.
+func (i *InstructionNewOrderV3) SetLimit(limit uint16) *InstructionNewOrderV3 {
+	i.Limit = limit
+	return i
+}
+
+// SetMarketAccount sets the "market" account (the market).
+func (i *InstructionNewOrderV3) SetMarketAccount(market solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (the OpenOrders account to use).
+func (i *InstructionNewOrderV3) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionNewOrderV3) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (the event queue).
+func (i *InstructionNewOrderV3) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// SetBidderAccount sets the "bidder" account (bids).
+func (i *InstructionNewOrderV3) SetBidderAccount(bidder solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.Bidder = solana.Meta(bidder).WRITE()
+	return i
+}
+
+// SetAskerAccount sets the "asker" account (asks).
+func (i *InstructionNewOrderV3) SetAskerAccount(asker solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.Asker = solana.Meta(asker).WRITE()
+	return i
+}
+
+// SetPayerAccount sets the "payer" account (the (coin or price currency) account paying for the order).
+func (i *InstructionNewOrderV3) SetPayerAccount(payer solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.Payer = solana.Meta(payer).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (owner of the OpenOrders account).
+func (i *InstructionNewOrderV3) SetOwnerAccount(owner solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetCoinVaultAccount sets the "coinVault" account (coin vault).
+func (i *InstructionNewOrderV3) SetCoinVaultAccount(coinVault solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.CoinVault = solana.Meta(coinVault).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionNewOrderV3) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionNewOrderV3) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// SetRentSysvarAccount sets the "rentSysvar" account (the rent sysvar).
+func (i *InstructionNewOrderV3) SetRentSysvarAccount(rentSysvar solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.RentSysvar = solana.Meta(rentSysvar)
+	return i
+}
+
+// SetFeeDiscountAccount sets the "feeDiscount" account ((optional) the (M)SRM account used for fee discounts).
+func (i *InstructionNewOrderV3) SetFeeDiscountAccount(feeDiscount solana.PublicKey) *InstructionNewOrderV3 {
+	i.Accounts.FeeDiscount = solana.Meta(feeDiscount)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionNewOrderV3) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.OpenOrders,
+		i.Accounts.RequestQueue,
+		i.Accounts.EventQueue,
+		i.Accounts.Bidder,
+		i.Accounts.Asker,
+		i.Accounts.Payer,
+		i.Accounts.Owner,
+		i.Accounts.CoinVault,
+		i.Accounts.PCVault,
+		i.Accounts.SPLTokenProgram,
+		i.Accounts.RentSysvar,
+	)
+	if i.Accounts.FeeDiscount != nil {
+		out = append(out, i.Accounts.FeeDiscount)
+	}
+	return
+}
+
+func (i InstructionNewOrderV3) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_NewOrderV3, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionNewOrderV3) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionNewOrderV3) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if i.LimitPrice == 0 {
+			return errors.New("LimitPrice parameter is not set")
+		}
+		if i.MaxCoinQuantity == 0 {
+			return errors.New("MaxCoinQuantity parameter is not set")
+		}
+		if i.MaxNativePCQuantityIncludingFees == 0 {
+			return errors.New("MaxNativePCQuantityIncludingFees parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+		if i.Accounts.Bidder == nil {
+			return fmt.Errorf("accounts.Bidder is not set")
+		}
+		if i.Accounts.Asker == nil {
+			return fmt.Errorf("accounts.Asker is not set")
+		}
+		if i.Accounts.Payer == nil {
+			return fmt.Errorf("accounts.Payer is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.CoinVault == nil {
+			return fmt.Errorf("accounts.CoinVault is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+		if i.Accounts.RentSysvar == nil {
+			return fmt.Errorf("accounts.RentSysvar is not set")
+		}
+	}
+	return nil
+}
+
+// NewCancelOrderV2InstructionBuilder creates a new `InstructionCancelOrderV2` instruction builder.
+func NewCancelOrderV2InstructionBuilder() *InstructionCancelOrderV2 {
+	nd := &InstructionCancelOrderV2{
+		Accounts: &CancelOrderV2Accounts{},
+	}
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionCancelOrderV2) SetSide(side Side) *InstructionCancelOrderV2 {
+	i.Side = side
+	return i
+}
+
+// SetOrderID sets the "orderID" parameter.
+func (i *InstructionCancelOrderV2) SetOrderID(orderID bin.Uint128) *InstructionCancelOrderV2 {
+	i.OrderID = orderID
+	return i
+}
+
+// SetMarketAccount sets the "market" account (market).
+func (i *InstructionCancelOrderV2) SetMarketAccount(market solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetBidsAccount sets the "bids" account (bids).
+func (i *InstructionCancelOrderV2) SetBidsAccount(bids solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.Bids = solana.Meta(bids).WRITE()
+	return i
+}
+
+// SetAsksAccount sets the "asks" account (asks).
+func (i *InstructionCancelOrderV2) SetAsksAccount(asks solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.Asks = solana.Meta(asks).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (OpenOrders).
+func (i *InstructionCancelOrderV2) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the OpenOrders owner).
+func (i *InstructionCancelOrderV2) SetOwnerAccount(owner solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (event_q).
+func (i *InstructionCancelOrderV2) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionCancelOrderV2 {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionCancelOrderV2) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.Bids,
+		i.Accounts.Asks,
+		i.Accounts.OpenOrders,
+		i.Accounts.Owner,
+		i.Accounts.EventQueue,
+	)
+	return
+}
+
+func (i InstructionCancelOrderV2) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_CancelOrderV2, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionCancelOrderV2) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionCancelOrderV2) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.Bids == nil {
+			return fmt.Errorf("accounts.Bids is not set")
+		}
+		if i.Accounts.Asks == nil {
+			return fmt.Errorf("accounts.Asks is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+	}
+	return nil
+}
+
+// NewCancelOrderByClientIdV2InstructionBuilder creates a new `InstructionCancelOrderByClientIdV2` instruction builder.
+func NewCancelOrderByClientIdV2InstructionBuilder() *InstructionCancelOrderByClientIdV2 {
+	nd := &InstructionCancelOrderByClientIdV2{
+		Accounts: &CancelOrderByClientIdV2Accounts{},
+	}
+	return nd
+}
+
+// SetClientID sets the "clientID" parameter.
+func (i *InstructionCancelOrderByClientIdV2) SetClientID(clientID uint64) *InstructionCancelOrderByClientIdV2 {
+	i.ClientID = clientID
+	return i
+}
+
+// SetMarketAccount sets the "market" account (market).
+func (i *InstructionCancelOrderByClientIdV2) SetMarketAccount(market solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetBidsAccount sets the "bids" account (bids).
+func (i *InstructionCancelOrderByClientIdV2) SetBidsAccount(bids solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.Bids = solana.Meta(bids).WRITE()
+	return i
+}
+
+// SetAsksAccount sets the "asks" account (asks).
+func (i *InstructionCancelOrderByClientIdV2) SetAsksAccount(asks solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.Asks = solana.Meta(asks).WRITE()
+	return i
+}
+
+// SetOpenOrdersAccount sets the "openOrders" account (OpenOrders).
+func (i *InstructionCancelOrderByClientIdV2) SetOpenOrdersAccount(openOrders solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.OpenOrders = solana.Meta(openOrders).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the OpenOrders owner).
+func (i *InstructionCancelOrderByClientIdV2) SetOwnerAccount(owner solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (event_q).
+func (i *InstructionCancelOrderByClientIdV2) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionCancelOrderByClientIdV2 {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionCancelOrderByClientIdV2) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.Bids,
+		i.Accounts.Asks,
+		i.Accounts.OpenOrders,
+		i.Accounts.Owner,
+		i.Accounts.EventQueue,
+	)
+	return
+}
+
+func (i InstructionCancelOrderByClientIdV2) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_CancelOrderByClientIdV2, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionCancelOrderByClientIdV2) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionCancelOrderByClientIdV2) Validate() error {
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.Bids == nil {
+			return fmt.Errorf("accounts.Bids is not set")
+		}
+		if i.Accounts.Asks == nil {
+			return fmt.Errorf("accounts.Asks is not set")
+		}
+		if i.Accounts.OpenOrders == nil {
+			return fmt.Errorf("accounts.OpenOrders is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+	}
+	return nil
+}
+
+// NewSendTakeInstructionBuilder creates a new `InstructionSendTake` instruction builder.
+func NewSendTakeInstructionBuilder() *InstructionSendTake {
+	nd := &InstructionSendTake{
+		Accounts: &InstructionSendTakeAccounts{},
+	}
+	nd.Accounts.SPLTokenProgram = solana.Meta(solana.TokenProgramID)
+	return nd
+}
+
+// SetSide sets the "side" parameter.
+func (i *InstructionSendTake) SetSide(side Side) *InstructionSendTake {
+	i.Side = side
+	return i
+}
+
+// SetLimitPrice sets the "limitPrice" parameter.
+func (i *InstructionSendTake) SetLimitPrice(limitPrice uint64) *InstructionSendTake {
+	i.LimitPrice = limitPrice
+	return i
+}
+
+// SetMaxCoinQuantity sets the "maxCoinQuantity" parameter.
+func (i *InstructionSendTake) SetMaxCoinQuantity(maxCoinQuantity uint64) *InstructionSendTake {
+	i.MaxCoinQuantity = maxCoinQuantity
+	return i
+}
+
+// SetMaxNativePCQuantityIncludingFees sets the "maxNativePCQuantityIncludingFees" parameter.
+func (i *InstructionSendTake) SetMaxNativePCQuantityIncludingFees(maxNativePCQuantityIncludingFees uint64) *InstructionSendTake {
+	i.MaxNativePCQuantityIncludingFees = maxNativePCQuantityIncludingFees
+	return i
+}
+
+// SetMinCoinQuantity sets the "minCoinQuantity" parameter.
+func (i *InstructionSendTake) SetMinCoinQuantity(minCoinQuantity uint64) *InstructionSendTake {
+	i.MinCoinQuantity = minCoinQuantity
+	return i
+}
+
+// SetMinNativePCQuantity sets the "minNativePCQuantity" parameter.
+func (i *InstructionSendTake) SetMinNativePCQuantity(minNativePCQuantity uint64) *InstructionSendTake {
+	i.MinNativePCQuantity = minNativePCQuantity
+	return i
+}
+
+// SetLimit sets the "limit" parameter.
+func (i *InstructionSendTake) SetLimit(limit uint16) *InstructionSendTake {
+	i.Limit = limit
+	return i
+}
+
+// SetMarketAccount sets the "market" account (market).
+func (i *InstructionSendTake) SetMarketAccount(market solana.PublicKey) *InstructionSendTake {
+	i.Accounts.Market = solana.Meta(market).WRITE()
+	return i
+}
+
+// SetRequestQueueAccount sets the "requestQueue" account (the request queue).
+func (i *InstructionSendTake) SetRequestQueueAccount(requestQueue solana.PublicKey) *InstructionSendTake {
+	i.Accounts.RequestQueue = solana.Meta(requestQueue).WRITE()
+	return i
+}
+
+// SetEventQueueAccount sets the "eventQueue" account (the event queue).
+func (i *InstructionSendTake) SetEventQueueAccount(eventQueue solana.PublicKey) *InstructionSendTake {
+	i.Accounts.EventQueue = solana.Meta(eventQueue).WRITE()
+	return i
+}
+
+// SetBidsAccount sets the "bids" account (bids).
+func (i *InstructionSendTake) SetBidsAccount(bids solana.PublicKey) *InstructionSendTake {
+	i.Accounts.Bids = solana.Meta(bids).WRITE()
+	return i
+}
+
+// SetAsksAccount sets the "asks" account (asks).
+func (i *InstructionSendTake) SetAsksAccount(asks solana.PublicKey) *InstructionSendTake {
+	i.Accounts.Asks = solana.Meta(asks).WRITE()
+	return i
+}
+
+// SetCoinWalletAccount sets the "coinWallet" account (the coin currency wallet account).
+func (i *InstructionSendTake) SetCoinWalletAccount(coinWallet solana.PublicKey) *InstructionSendTake {
+	i.Accounts.CoinWallet = solana.Meta(coinWallet).WRITE()
+	return i
+}
+
+// SetPCWalletAccount sets the "pcWallet" account (the price currency wallet account).
+func (i *InstructionSendTake) SetPCWalletAccount(pcWallet solana.PublicKey) *InstructionSendTake {
+	i.Accounts.PCWallet = solana.Meta(pcWallet).WRITE()
+	return i
+}
+
+// SetOwnerAccount sets the "owner" account (the owner of the wallets).
+func (i *InstructionSendTake) SetOwnerAccount(owner solana.PublicKey) *InstructionSendTake {
+	i.Accounts.Owner = solana.Meta(owner).SIGNER()
+	return i
+}
+
+// SetCoinVaultAccount sets the "coinVault" account (coin vault).
+func (i *InstructionSendTake) SetCoinVaultAccount(coinVault solana.PublicKey) *InstructionSendTake {
+	i.Accounts.CoinVault = solana.Meta(coinVault).WRITE()
+	return i
+}
+
+// SetPCVaultAccount sets the "pcVault" account (pc vault).
+func (i *InstructionSendTake) SetPCVaultAccount(pcVault solana.PublicKey) *InstructionSendTake {
+	i.Accounts.PCVault = solana.Meta(pcVault).WRITE()
+	return i
+}
+
+// SetSPLTokenProgramAccount sets the "splTokenProgram" account (spl token program).
+func (i *InstructionSendTake) SetSPLTokenProgramAccount(splTokenProgram solana.PublicKey) *InstructionSendTake {
+	i.Accounts.SPLTokenProgram = solana.Meta(splTokenProgram)
+	return i
+}
+
+// SetVaultSignerAccount sets the "vaultSigner" account (the vault signer).
+func (i *InstructionSendTake) SetVaultSignerAccount(vaultSigner solana.PublicKey) *InstructionSendTake {
+	i.Accounts.VaultSigner = solana.Meta(vaultSigner)
+	return i
+}
+
+// SetFeeDiscountAccount sets the "feeDiscount" account ((optional) the (M)SRM account used for fee discounts).
+func (i *InstructionSendTake) SetFeeDiscountAccount(feeDiscount solana.PublicKey) *InstructionSendTake {
+	i.Accounts.FeeDiscount = solana.Meta(feeDiscount)
+	return i
+}
+
+// GetAccounts returns the accounts of the instruction, in the order expected by the program.
+func (i *InstructionSendTake) GetAccounts() (out []*solana.AccountMeta) {
+	if i.Accounts == nil {
+		return nil
+	}
+	out = append(out,
+		i.Accounts.Market,
+		i.Accounts.RequestQueue,
+		i.Accounts.EventQueue,
+		i.Accounts.Bids,
+		i.Accounts.Asks,
+		i.Accounts.CoinWallet,
+		i.Accounts.PCWallet,
+		i.Accounts.Owner,
+		i.Accounts.CoinVault,
+		i.Accounts.PCVault,
+		i.Accounts.SPLTokenProgram,
+		i.Accounts.VaultSigner,
+	)
+	if i.Accounts.FeeDiscount != nil {
+		out = append(out, i.Accounts.FeeDiscount)
+	}
+	return
+}
+
+func (i InstructionSendTake) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   &i,
+			TypeID: bin.TypeIDFromUint32(Instruction_SendTake, bin.LE),
+		},
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (i InstructionSendTake) ValidateAndBuild() (*Instruction, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	return i.Build(), nil
+}
+
+func (i *InstructionSendTake) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if i.LimitPrice == 0 {
+			return errors.New("LimitPrice parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	if i.Accounts == nil {
+		return errors.New("accounts are not set")
+	}
+	{
+		if i.Accounts.Market == nil {
+			return fmt.Errorf("accounts.Market is not set")
+		}
+		if i.Accounts.RequestQueue == nil {
+			return fmt.Errorf("accounts.RequestQueue is not set")
+		}
+		if i.Accounts.EventQueue == nil {
+			return fmt.Errorf("accounts.EventQueue is not set")
+		}
+		if i.Accounts.Bids == nil {
+			return fmt.Errorf("accounts.Bids is not set")
+		}
+		if i.Accounts.Asks == nil {
+			return fmt.Errorf("accounts.Asks is not set")
+		}
+		if i.Accounts.CoinWallet == nil {
+			return fmt.Errorf("accounts.CoinWallet is not set")
+		}
+		if i.Accounts.PCWallet == nil {
+			return fmt.Errorf("accounts.PCWallet is not set")
+		}
+		if i.Accounts.Owner == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+		if i.Accounts.CoinVault == nil {
+			return fmt.Errorf("accounts.CoinVault is not set")
+		}
+		if i.Accounts.PCVault == nil {
+			return fmt.Errorf("accounts.PCVault is not set")
+		}
+		if i.Accounts.SPLTokenProgram == nil {
+			return fmt.Errorf("accounts.SPLTokenProgram is not set")
+		}
+		if i.Accounts.VaultSigner == nil {
+			return fmt.Errorf("accounts.VaultSigner is not set")
+		}
+	}
+	return nil
+}
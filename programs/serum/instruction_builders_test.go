@@ -0,0 +1,594 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func newTestKeys(n int) (out []solana.PublicKey) {
+	for i := 0; i < n; i++ {
+		out = append(out, solana.NewWallet().PublicKey())
+	}
+	return
+}
+
+func roundTripInstruction(t *testing.T, inst *Instruction) *Instruction {
+	t.Helper()
+	data, err := inst.Data()
+	require.NoError(t, err)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	require.Equal(t, inst.TypeID, decoded.TypeID)
+	return decoded
+}
+
+func TestNewOrderV3Builder(t *testing.T) {
+	keys := newTestKeys(11)
+
+	builder := NewNewOrderV3InstructionBuilder().
+		SetSide(SideBid).
+		SetLimitPrice(1720).
+		SetMaxCoinQuantity(650000).
+		SetMaxNativePCQuantityIncludingFees(1118000000).
+		SetSelfTradeBehavior(SelfTradeBehaviorDecrementTake).
+		SetOrderType(OrderTypeImmediateOrCancel).
+		SetClientOrderID(42).
+		SetLimit(65535).
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetRequestQueueAccount(keys[2]).
+		SetEventQueueAccount(keys[3]).
+		SetBidderAccount(keys[4]).
+		SetAskerAccount(keys[5]).
+		SetPayerAccount(keys[6]).
+		SetOwnerAccount(keys[7]).
+		SetCoinVaultAccount(keys[8]).
+		SetPCVaultAccount(keys[9])
+
+	t.Run("without fee discount", func(t *testing.T) {
+		inst, err := builder.ValidateAndBuild()
+		require.NoError(t, err)
+		assert.Equal(t, ProgramID, inst.ProgramID())
+
+		accounts := inst.Accounts()
+		require.Len(t, accounts, 12)
+		assert.Equal(t, keys[7], accounts[7].PublicKey)
+		assert.True(t, accounts[7].IsSigner)
+		assert.False(t, accounts[7].IsWritable)
+		assert.Equal(t, solana.TokenProgramID, accounts[10].PublicKey)
+		assert.Equal(t, solana.SysVarRentPubkey, accounts[11].PublicKey)
+
+		data, err := inst.Data()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0, 10, 0, 0, 0}, data[:5])
+
+		decoded := roundTripInstruction(t, inst)
+		impl := decoded.Impl.(*InstructionNewOrderV3)
+		assert.Equal(t, Side(SideBid), impl.Side)
+		assert.Equal(t, uint64(1720), impl.LimitPrice)
+		assert.Equal(t, uint64(650000), impl.MaxCoinQuantity)
+		assert.Equal(t, uint64(1118000000), impl.MaxNativePCQuantityIncludingFees)
+		assert.Equal(t, SelfTradeBehavior(SelfTradeBehaviorDecrementTake), impl.SelfTradeBehavior)
+		assert.Equal(t, OrderTypeImmediateOrCancel, impl.OrderType)
+		assert.Equal(t, uint64(42), impl.ClientOrderID)
+		assert.Equal(t, uint16(65535), impl.Limit)
+		assert.Equal(t, keys[4], impl.Accounts.Bidder.PublicKey)
+		assert.Nil(t, impl.Accounts.FeeDiscount)
+	})
+
+	t.Run("with fee discount", func(t *testing.T) {
+		inst, err := builder.SetFeeDiscountAccount(keys[10]).ValidateAndBuild()
+		require.NoError(t, err)
+		require.Len(t, inst.Accounts(), 13)
+
+		decoded := roundTripInstruction(t, inst)
+		impl := decoded.Impl.(*InstructionNewOrderV3)
+		require.NotNil(t, impl.Accounts.FeeDiscount)
+		assert.Equal(t, keys[10], impl.Accounts.FeeDiscount.PublicKey)
+	})
+}
+
+func TestNewOrderV3Builder_Validate(t *testing.T) {
+	_, err := NewNewOrderV3InstructionBuilder().SetLimitPrice(1).ValidateAndBuild()
+	require.Error(t, err)
+}
+
+func TestCancelOrderV2Builder(t *testing.T) {
+	keys := newTestKeys(6)
+	orderID := bin.Uint128{Lo: 18446744073709551615, Hi: 93, Endianness: binary.LittleEndian}
+
+	inst, err := NewCancelOrderV2InstructionBuilder().
+		SetSide(SideAsk).
+		SetOrderID(orderID).
+		SetMarketAccount(keys[0]).
+		SetBidsAccount(keys[1]).
+		SetAsksAccount(keys[2]).
+		SetOpenOrdersAccount(keys[3]).
+		SetOwnerAccount(keys[4]).
+		SetEventQueueAccount(keys[5]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionCancelOrderV2)
+	assert.Equal(t, Side(SideAsk), impl.Side)
+	assert.Equal(t, orderID.Lo, impl.OrderID.Lo)
+	assert.Equal(t, orderID.Hi, impl.OrderID.Hi)
+	assert.Equal(t, keys[3], impl.Accounts.OpenOrders.PublicKey)
+	assert.Equal(t, keys[4], impl.Accounts.Owner.PublicKey)
+}
+
+func TestConsumeEventsBuilder(t *testing.T) {
+	keys := newTestKeys(6)
+
+	inst, err := NewConsumeEventsInstructionBuilder().
+		SetLimit(10).
+		SetMarketAccount(keys[0]).
+		SetEventQueueAccount(keys[1]).
+		SetCoinFeeReceivableAccount(keys[2]).
+		SetPCFeeReceivableAccount(keys[3]).
+		AppendOpenOrdersAccount(keys[4]).
+		AppendOpenOrdersAccount(keys[5]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 6)
+	assert.Equal(t, keys[4], accounts[0].PublicKey)
+	assert.Equal(t, keys[5], accounts[1].PublicKey)
+	assert.Equal(t, keys[0], accounts[2].PublicKey)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionConsumeEvents)
+	assert.Equal(t, uint16(10), impl.Limit)
+	require.Len(t, impl.Accounts.OpenOrders, 2)
+	assert.Equal(t, keys[5], impl.Accounts.OpenOrders[1].PublicKey)
+	assert.Equal(t, keys[1], impl.Accounts.EventQueue.PublicKey)
+
+	_, err = NewConsumeEventsInstructionBuilder().
+		SetMarketAccount(keys[0]).
+		SetEventQueueAccount(keys[1]).
+		SetCoinFeeReceivableAccount(keys[2]).
+		SetPCFeeReceivableAccount(keys[3]).
+		ValidateAndBuild()
+	require.Error(t, err)
+}
+
+func TestSettleFundsBuilder(t *testing.T) {
+	keys := newTestKeys(8)
+
+	inst, err := NewSettleFundsInstructionBuilder().
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetOwnerAccount(keys[2]).
+		SetCoinVaultAccount(keys[3]).
+		SetPCVaultAccount(keys[4]).
+		SetCoinWalletAccount(keys[5]).
+		SetPCWalletAccount(keys[6]).
+		SetSignerAccount(keys[7]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionSettleFunds)
+	assert.Equal(t, keys[5], impl.Accounts.CoinWallet.PublicKey)
+	assert.Equal(t, keys[7], impl.Accounts.Signer.PublicKey)
+	assert.Equal(t, solana.TokenProgramID, impl.Accounts.SPLTokenProgram.PublicKey)
+}
+
+func TestSendTakeBuilder(t *testing.T) {
+	keys := newTestKeys(12)
+
+	inst, err := NewSendTakeInstructionBuilder().
+		SetSide(SideBid).
+		SetLimitPrice(10).
+		SetMaxCoinQuantity(20).
+		SetMaxNativePCQuantityIncludingFees(30).
+		SetMinCoinQuantity(1).
+		SetMinNativePCQuantity(2).
+		SetLimit(5).
+		SetMarketAccount(keys[0]).
+		SetRequestQueueAccount(keys[1]).
+		SetEventQueueAccount(keys[2]).
+		SetBidsAccount(keys[3]).
+		SetAsksAccount(keys[4]).
+		SetCoinWalletAccount(keys[5]).
+		SetPCWalletAccount(keys[6]).
+		SetOwnerAccount(keys[7]).
+		SetCoinVaultAccount(keys[8]).
+		SetPCVaultAccount(keys[9]).
+		SetVaultSignerAccount(keys[10]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+	require.Len(t, inst.Accounts(), 12)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionSendTake)
+	assert.Equal(t, uint64(2), impl.MinNativePCQuantity)
+	assert.Equal(t, keys[7], impl.Accounts.Owner.PublicKey)
+	assert.Equal(t, keys[10], impl.Accounts.VaultSigner.PublicKey)
+	assert.Nil(t, impl.Accounts.FeeDiscount)
+}
+
+// requireAccountMetas checks the order and the writable/signer flags of
+// the accounts of an instruction.
+func requireAccountMetas(t *testing.T, expected []*solana.AccountMeta, got []*solana.AccountMeta) {
+	t.Helper()
+	require.Len(t, got, len(expected))
+	for idx, exp := range expected {
+		assert.Equal(t, exp.PublicKey, got[idx].PublicKey, "account %d", idx)
+		assert.Equal(t, exp.IsWritable, got[idx].IsWritable, "account %d writable", idx)
+		assert.Equal(t, exp.IsSigner, got[idx].IsSigner, "account %d signer", idx)
+	}
+}
+
+func TestInitializeMarketBuilder(t *testing.T) {
+	keys := newTestKeys(9)
+
+	inst, err := NewInitializeMarketInstructionBuilder().
+		SetBaseLotSize(100000).
+		SetQuoteLotSize(100).
+		SetFeeRateBps(22).
+		SetVaultSignerNonce(3).
+		SetQuoteDustThreshold(500).
+		SetMarketAccount(keys[0]).
+		SetRequestQueueAccount(keys[1]).
+		SetEventQueueAccount(keys[2]).
+		SetBidsAccount(keys[3]).
+		SetAsksAccount(keys[4]).
+		SetSPLCoinTokenAccount(keys[5]).
+		SetSPLPriceTokenAccount(keys[6]).
+		SetCoinMintAccount(keys[7]).
+		SetPriceMintAccount(keys[8]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]).WRITE(),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).WRITE(),
+			solana.Meta(keys[3]).WRITE(),
+			solana.Meta(keys[4]).WRITE(),
+			solana.Meta(keys[5]).WRITE(),
+			solana.Meta(keys[6]).WRITE(),
+			solana.Meta(keys[7]),
+			solana.Meta(keys[8]),
+			solana.Meta(solana.SysVarRentPubkey),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionInitializeMarket)
+	assert.Equal(t, uint64(100000), impl.BaseLotSize)
+	assert.Equal(t, uint64(100), impl.QuoteLotSize)
+	assert.Equal(t, uint16(22), impl.FeeRateBps)
+	assert.Equal(t, uint64(3), impl.VaultSignerNonce)
+	assert.Equal(t, uint64(500), impl.QuoteDustThreshold)
+	assert.Equal(t, keys[0], impl.Accounts.Market.PublicKey)
+	assert.Equal(t, keys[6], impl.Accounts.SPLPriceToken.PublicKey)
+	assert.Equal(t, keys[8], impl.Accounts.PriceMint.PublicKey)
+	assert.Equal(t, solana.SysVarRentPubkey, impl.Accounts.Rent.PublicKey)
+}
+
+func TestNewOrderBuilder(t *testing.T) {
+	keys := newTestKeys(8)
+
+	builder := NewNewOrderInstructionBuilder().
+		SetSide(SideAsk).
+		SetLimitPrice(1720).
+		SetMaxQuantity(650000).
+		SetOrderType(OrderTypePostOnly).
+		SetClientID(42).
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetRequestQueueAccount(keys[2]).
+		SetPayerAccount(keys[3]).
+		SetOwnerAccount(keys[4]).
+		SetCoinVaultAccount(keys[5]).
+		SetPCVaultAccount(keys[6])
+
+	inst, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	expected := []*solana.AccountMeta{
+		solana.Meta(keys[0]).WRITE(),
+		solana.Meta(keys[1]).WRITE(),
+		solana.Meta(keys[2]).WRITE(),
+		solana.Meta(keys[3]).WRITE(),
+		solana.Meta(keys[4]).SIGNER(),
+		solana.Meta(keys[5]).WRITE(),
+		solana.Meta(keys[6]).WRITE(),
+		solana.Meta(solana.TokenProgramID),
+		solana.Meta(solana.SysVarRentPubkey),
+	}
+	requireAccountMetas(t, expected, inst.Accounts())
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionNewOrder)
+	assert.Equal(t, Side(SideAsk), impl.Side)
+	assert.Equal(t, uint64(1720), impl.LimitPrice)
+	assert.Equal(t, uint64(650000), impl.MaxQuantity)
+	assert.Equal(t, OrderTypePostOnly, impl.OrderType)
+	assert.Equal(t, uint64(42), impl.ClientID)
+	assert.Equal(t, keys[3], impl.Accounts.Payer.PublicKey)
+	assert.Equal(t, keys[4], impl.Accounts.Owner.PublicKey)
+	assert.Nil(t, impl.Accounts.SRMDiscountAccount)
+
+	inst, err = builder.SetSRMDiscountAccount(keys[7]).ValidateAndBuild()
+	require.NoError(t, err)
+	requireAccountMetas(t, append(expected, solana.Meta(keys[7])), inst.Accounts())
+
+	decoded = roundTripInstruction(t, inst)
+	impl = decoded.Impl.(*InstructionNewOrder)
+	require.NotNil(t, impl.Accounts.SRMDiscountAccount)
+	assert.Equal(t, keys[7], impl.Accounts.SRMDiscountAccount.PublicKey)
+}
+
+func TestMatchOrderBuilder(t *testing.T) {
+	keys := newTestKeys(7)
+
+	inst, err := NewMatchOrderInstructionBuilder().
+		SetLimit(15).
+		SetMarketAccount(keys[0]).
+		SetRequestQueueAccount(keys[1]).
+		SetEventQueueAccount(keys[2]).
+		SetBidsAccount(keys[3]).
+		SetAsksAccount(keys[4]).
+		SetCoinFeeReceivableAccount(keys[5]).
+		SetPCFeeReceivableAccount(keys[6]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]).WRITE(),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).WRITE(),
+			solana.Meta(keys[3]).WRITE(),
+			solana.Meta(keys[4]).WRITE(),
+			solana.Meta(keys[5]).WRITE(),
+			solana.Meta(keys[6]).WRITE(),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionMatchOrder)
+	assert.Equal(t, uint16(15), impl.Limit)
+	assert.Equal(t, keys[2], impl.Accounts.EventQueue.PublicKey)
+	assert.Equal(t, keys[6], impl.Accounts.PCFeeReceivable.PublicKey)
+}
+
+func TestCancelOrderBuilder(t *testing.T) {
+	keys := newTestKeys(4)
+	orderID := bin.Uint128{Lo: 1234, Hi: 5678, Endianness: binary.LittleEndian}
+
+	inst, err := NewCancelOrderInstructionBuilder().
+		SetSide(SideBid).
+		SetOrderID(orderID).
+		SetOpenOrders(keys[1]).
+		SetOpenOrderSlot(7).
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetRequestQueueAccount(keys[2]).
+		SetOwnerAccount(keys[3]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).WRITE(),
+			solana.Meta(keys[3]).SIGNER(),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionCancelOrder)
+	assert.Equal(t, Side(SideBid), impl.Side)
+	assert.Equal(t, orderID.Lo, impl.OrderID.Lo)
+	assert.Equal(t, orderID.Hi, impl.OrderID.Hi)
+	assert.Equal(t, keys[1], impl.OpenOrders)
+	assert.Equal(t, uint8(7), impl.OpenOrderSlot)
+	assert.Equal(t, keys[3], impl.Accounts.Owner.PublicKey)
+}
+
+func TestCancelOrderByClientIdBuilder(t *testing.T) {
+	keys := newTestKeys(4)
+
+	inst, err := NewCancelOrderByClientIdInstructionBuilder().
+		SetClientID(42).
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetRequestQueueAccount(keys[2]).
+		SetOwnerAccount(keys[3]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).WRITE(),
+			solana.Meta(keys[3]).SIGNER(),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionCancelOrderByClientId)
+	assert.Equal(t, uint64(42), impl.ClientID)
+	assert.Equal(t, keys[1], impl.Accounts.OpenOrders.PublicKey)
+	assert.Equal(t, keys[2], impl.Accounts.RequestQueue.PublicKey)
+}
+
+func TestCancelOrderByClientIdV2Builder(t *testing.T) {
+	keys := newTestKeys(6)
+
+	inst, err := NewCancelOrderByClientIdV2InstructionBuilder().
+		SetClientID(42).
+		SetMarketAccount(keys[0]).
+		SetBidsAccount(keys[1]).
+		SetAsksAccount(keys[2]).
+		SetOpenOrdersAccount(keys[3]).
+		SetOwnerAccount(keys[4]).
+		SetEventQueueAccount(keys[5]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]).WRITE(),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).WRITE(),
+			solana.Meta(keys[3]).WRITE(),
+			solana.Meta(keys[4]).SIGNER(),
+			solana.Meta(keys[5]).WRITE(),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionCancelOrderByClientIdV2)
+	assert.Equal(t, uint64(42), impl.ClientID)
+	assert.Equal(t, keys[3], impl.Accounts.OpenOrders.PublicKey)
+	assert.Equal(t, keys[5], impl.Accounts.EventQueue.PublicKey)
+}
+
+func TestDisableMarketBuilder(t *testing.T) {
+	keys := newTestKeys(2)
+
+	inst, err := NewDisableMarketInstructionBuilder().
+		SetMarketAccount(keys[0]).
+		SetDisableAuthorityAccount(keys[1]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]).WRITE(),
+			solana.Meta(keys[1]).SIGNER(),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionDisableMarketAccounts)
+	assert.Equal(t, keys[0], impl.Accounts.Market.PublicKey)
+	assert.Equal(t, keys[1], impl.Accounts.DisableAuthority.PublicKey)
+}
+
+func TestSweepFeesBuilder(t *testing.T) {
+	keys := newTestKeys(5)
+
+	inst, err := NewSweepFeesInstructionBuilder().
+		SetMarketAccount(keys[0]).
+		SetPCVaultAccount(keys[1]).
+		SetFeeSweepingAuthorityAccount(keys[2]).
+		SetFeeReceivableAccount(keys[3]).
+		SetVaultSignerAccount(keys[4]).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	requireAccountMetas(t,
+		[]*solana.AccountMeta{
+			solana.Meta(keys[0]).WRITE(),
+			solana.Meta(keys[1]).WRITE(),
+			solana.Meta(keys[2]).SIGNER(),
+			solana.Meta(keys[3]).WRITE(),
+			solana.Meta(keys[4]),
+			solana.Meta(solana.TokenProgramID),
+		},
+		inst.Accounts(),
+	)
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionSweepFees)
+	assert.Equal(t, keys[2], impl.Accounts.FeeSweepingAuthority.PublicKey)
+	assert.Equal(t, keys[3], impl.Accounts.FeeReceivableAccount.PublicKey)
+	assert.Equal(t, keys[4], impl.Accounts.VaultSigner.PublicKey)
+	assert.Equal(t, solana.TokenProgramID, impl.Accounts.SPLTokenProgram.PublicKey)
+}
+
+func TestNewOrderV2Builder(t *testing.T) {
+	keys := newTestKeys(8)
+
+	builder := NewNewOrderV2InstructionBuilder().
+		SetSide(SideBid).
+		SetLimitPrice(1720).
+		SetMaxQuantity(650000).
+		SetOrderType(OrderTypeImmediateOrCancel).
+		SetClientID(42).
+		SetSelfTradeBehavior(SelfTradeBehaviorCancelProvide).
+		SetMarketAccount(keys[0]).
+		SetOpenOrdersAccount(keys[1]).
+		SetRequestQueueAccount(keys[2]).
+		SetPayerAccount(keys[3]).
+		SetOwnerAccount(keys[4]).
+		SetCoinVaultAccount(keys[5]).
+		SetPCVaultAccount(keys[6])
+
+	inst, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	expected := []*solana.AccountMeta{
+		solana.Meta(keys[0]).WRITE(),
+		solana.Meta(keys[1]).WRITE(),
+		solana.Meta(keys[2]).WRITE(),
+		solana.Meta(keys[3]).WRITE(),
+		solana.Meta(keys[4]).SIGNER(),
+		solana.Meta(keys[5]).WRITE(),
+		solana.Meta(keys[6]).WRITE(),
+		solana.Meta(solana.TokenProgramID),
+		solana.Meta(solana.SysVarRentPubkey),
+	}
+	requireAccountMetas(t, expected, inst.Accounts())
+
+	decoded := roundTripInstruction(t, inst)
+	impl := decoded.Impl.(*InstructionNewOrderV2)
+	assert.Equal(t, Side(SideBid), impl.Side)
+	assert.Equal(t, uint64(1720), impl.LimitPrice)
+	assert.Equal(t, uint64(650000), impl.MaxQuantity)
+	assert.Equal(t, OrderTypeImmediateOrCancel, impl.OrderType)
+	assert.Equal(t, uint64(42), impl.ClientID)
+	assert.Equal(t, SelfTradeBehavior(SelfTradeBehaviorCancelProvide), impl.SelfTradeBehavior)
+	assert.Equal(t, keys[4], impl.Accounts.Owner.PublicKey)
+	assert.Nil(t, impl.Accounts.FeeDiscount)
+
+	inst, err = builder.SetFeeDiscountAccount(keys[7]).ValidateAndBuild()
+	require.NoError(t, err)
+	requireAccountMetas(t, append(expected, solana.Meta(keys[7])), inst.Accounts())
+
+	decoded = roundTripInstruction(t, inst)
+	impl = decoded.Impl.(*InstructionNewOrderV2)
+	require.NotNil(t, impl.Accounts.FeeDiscount)
+	assert.Equal(t, keys[7], impl.Accounts.FeeDiscount.PublicKey)
+}
@@ -28,6 +28,7 @@ type MarketMeta struct {
 	Address    solana.PublicKey `json:"address"`
 	Name       string           `json:"name"`
 	Deprecated bool             `json:"deprecated"`
+	ProgramID  solana.PublicKey `json:"programId"`
 	QuoteMint  token.Mint
 	BaseMint   token.Mint
 
@@ -0,0 +1,363 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/diff"
+	"github.com/xmcontinue/solana-go/rpc"
+	"github.com/xmcontinue/solana-go/rpc/ws"
+)
+
+// OrderbookLevel is an aggregated (level 2) price level of one side of the book.
+type OrderbookLevel struct {
+	Side      Side
+	PriceLots uint64
+	SizeLots  uint64
+
+	Price *big.Float
+	Size  *big.Float
+}
+
+// OrderbookOrder is a single resting (level 3) order of one side of the book.
+type OrderbookOrder struct {
+	Side          Side
+	OrderID       OrderID
+	Owner         solana.PublicKey // OpenOrders account address, NOT the trader
+	OwnerSlot     uint8
+	FeeTier       uint8
+	ClientOrderID uint64
+	PriceLots     uint64
+	QuantityLots  uint64
+
+	Price    *big.Float
+	Quantity *big.Float
+}
+
+// OrderbookL2Event is a level 2 change: a price level appeared, changed size or disappeared.
+// Old is nil for additions, New is nil for removals.
+type OrderbookL2Event struct {
+	Kind diff.Kind
+	Old  *OrderbookLevel
+	New  *OrderbookLevel
+}
+
+// Level returns the level affected by the event, that is the old one
+// on removal and the new one otherwise.
+func (e *OrderbookL2Event) Level() *OrderbookLevel {
+	if e.Kind == diff.KindRemoved {
+		return e.Old
+	}
+	return e.New
+}
+
+// OrderbookL3Event is a level 3 change: an order was placed, partially filled or left the book.
+// Old is nil for additions, New is nil for removals.
+type OrderbookL3Event struct {
+	Kind diff.Kind
+	Old  *OrderbookOrder
+	New  *OrderbookOrder
+}
+
+// Order returns the order affected by the event, that is the old one
+// on removal and the new one otherwise.
+func (e *OrderbookL3Event) Order() *OrderbookOrder {
+	if e.Kind == diff.KindRemoved {
+		return e.Old
+	}
+	return e.New
+}
+
+// OrderbookUpdate holds the changes of one side of the book between two
+// consecutive states. Events are sorted in book order (best price first).
+type OrderbookUpdate struct {
+	Slot uint64
+	Side Side
+	L2   []*OrderbookL2Event
+	L3   []*OrderbookL3Event
+}
+
+// IsEmpty returns true when the update carries no change.
+func (u *OrderbookUpdate) IsEmpty() bool {
+	return len(u.L2) == 0 && len(u.L3) == 0
+}
+
+// OrderbookOrders returns the orders of the book in book order (best price first),
+// with their price and quantity converted using the market lot sizes and mint decimals.
+func (m *MarketMeta) OrderbookOrders(side Side, book *Orderbook) (out []*OrderbookOrder) {
+	if book == nil {
+		return nil
+	}
+	book.Items(side == SideBid, func(node *SlabLeafNode) error {
+		out = append(out, m.newOrderbookOrder(side, node))
+		return nil
+	})
+	return out
+}
+
+// OrderbookLevels aggregates the orders of the book into price levels, in book order.
+func (m *MarketMeta) OrderbookLevels(side Side, book *Orderbook) (out []*OrderbookLevel) {
+	for _, order := range m.OrderbookOrders(side, book) {
+		if len(out) > 0 && out[len(out)-1].PriceLots == order.PriceLots {
+			out[len(out)-1].SizeLots += order.QuantityLots
+			continue
+		}
+		out = append(out, &OrderbookLevel{Side: side, PriceLots: order.PriceLots, SizeLots: order.QuantityLots})
+	}
+	for _, level := range out {
+		m.fillOrderbookLevel(level)
+	}
+	return out
+}
+
+func (m *MarketMeta) newOrderbookOrder(side Side, node *SlabLeafNode) *OrderbookOrder {
+	orderID := OrderID(node.Key)
+	return &OrderbookOrder{
+		Side:          side,
+		OrderID:       orderID,
+		Owner:         node.Owner,
+		OwnerSlot:     node.OwnerSlot,
+		FeeTier:       node.FeeTier,
+		ClientOrderID: uint64(node.ClientOrderId),
+		PriceLots:     orderID.Price(),
+		QuantityLots:  uint64(node.Quantity),
+		Price:         m.PriceLotsToNumber(new(big.Int).SetUint64(orderID.Price())),
+		Quantity:      m.BaseSizeLotsToNumber(new(big.Int).SetUint64(uint64(node.Quantity))),
+	}
+}
+
+func (m *MarketMeta) fillOrderbookLevel(level *OrderbookLevel) {
+	level.Price = m.PriceLotsToNumber(new(big.Int).SetUint64(level.PriceLots))
+	level.Size = m.BaseSizeLotsToNumber(new(big.Int).SetUint64(level.SizeLots))
+}
+
+// DiffOrderbook computes the level 2 and level 3 changes between two states of the
+// same side of the book. A nil `old` book is considered empty, so the returned
+// update then carries the whole `current` book as additions.
+func DiffOrderbook(market *MarketMeta, side Side, old, current *Orderbook) *OrderbookUpdate {
+	update := &OrderbookUpdate{Side: side}
+
+	oldOrders := ordersByID(market.OrderbookOrders(side, old))
+	newOrders := ordersByID(market.OrderbookOrders(side, current))
+	diff.Diff(orderQuantities(oldOrders), orderQuantities(newOrders), diff.OnEvent(func(event diff.Event) {
+		key := mapKey(event).String()
+		update.L3 = append(update.L3, &OrderbookL3Event{Kind: event.Kind, Old: oldOrders[key], New: newOrders[key]})
+	}))
+
+	oldLevels := levelsByPrice(market.OrderbookLevels(side, old))
+	newLevels := levelsByPrice(market.OrderbookLevels(side, current))
+	diff.Diff(levelSizes(oldLevels), levelSizes(newLevels), diff.OnEvent(func(event diff.Event) {
+		key := mapKey(event).Uint()
+		update.L2 = append(update.L2, &OrderbookL2Event{Kind: event.Kind, Old: oldLevels[key], New: newLevels[key]})
+	}))
+
+	sort.SliceStable(update.L3, func(i, j int) bool {
+		return orderIDLess(side, update.L3[i].Order().OrderID, update.L3[j].Order().OrderID)
+	})
+	sort.SliceStable(update.L2, func(i, j int) bool {
+		if side == SideBid {
+			return update.L2[i].Level().PriceLots > update.L2[j].Level().PriceLots
+		}
+		return update.L2[i].Level().PriceLots < update.L2[j].Level().PriceLots
+	})
+
+	return update
+}
+
+// mapKey returns the key of the map entry a diff event was reported on.
+func mapKey(event diff.Event) reflect.Value {
+	return cmp.Path(event.Path).Last().(cmp.MapIndex).Key()
+}
+
+// orderIDLess orders by book priority: price then time, with the best price first.
+func orderIDLess(side Side, a, b OrderID) bool {
+	if side == SideBid {
+		// Bid order IDs store the bitwise NOT of the sequence number, so
+		// the highest ID is also the oldest order at the best price.
+		return a.Hi > b.Hi || (a.Hi == b.Hi && a.Lo > b.Lo)
+	}
+	return a.Hi < b.Hi || (a.Hi == b.Hi && a.Lo < b.Lo)
+}
+
+func ordersByID(orders []*OrderbookOrder) map[string]*OrderbookOrder {
+	out := make(map[string]*OrderbookOrder, len(orders))
+	for _, order := range orders {
+		out[order.OrderID.HexString(false)] = order
+	}
+	return out
+}
+
+func orderQuantities(orders map[string]*OrderbookOrder) map[string]uint64 {
+	out := make(map[string]uint64, len(orders))
+	for key, order := range orders {
+		out[key] = order.QuantityLots
+	}
+	return out
+}
+
+func levelsByPrice(levels []*OrderbookLevel) map[uint64]*OrderbookLevel {
+	out := make(map[uint64]*OrderbookLevel, len(levels))
+	for _, level := range levels {
+		out[level.PriceLots] = level
+	}
+	return out
+}
+
+func levelSizes(levels map[uint64]*OrderbookLevel) map[uint64]uint64 {
+	out := make(map[uint64]uint64, len(levels))
+	for price, level := range levels {
+		out[price] = level.SizeLots
+	}
+	return out
+}
+
+// OrderbookStream keeps a live copy of the bids and asks of a market,
+// fed by account notifications.
+type OrderbookStream struct {
+	client     *ws.Client
+	market     *MarketMeta
+	commitment rpc.CommitmentType
+
+	lock  sync.RWMutex
+	books map[Side]*Orderbook
+}
+
+func NewOrderbookStream(client *ws.Client, market *MarketMeta, commitment rpc.CommitmentType) *OrderbookStream {
+	return &OrderbookStream{
+		client:     client,
+		market:     market,
+		commitment: commitment,
+		books:      map[Side]*Orderbook{},
+	}
+}
+
+// Apply decodes `data` as the new content of the `side` account of the market,
+// replaces the current book for that side and returns the changes.
+func (s *OrderbookStream) Apply(side Side, slot uint64, data []byte) (*OrderbookUpdate, error) {
+	var book *Orderbook
+	if err := bin.NewBinDecoder(data).Decode(&book); err != nil {
+		return nil, fmt.Errorf("unable to decode orderbook: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	update := DiffOrderbook(s.market, side, s.books[side], book)
+	update.Slot = slot
+	s.books[side] = book
+	return update, nil
+}
+
+// Orders returns a snapshot of the current orders of one side, in book order.
+func (s *OrderbookStream) Orders(side Side) []*OrderbookOrder {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.market.OrderbookOrders(side, s.books[side])
+}
+
+// Levels returns a snapshot of the current price levels of one side, in book order.
+func (s *OrderbookStream) Levels(side Side) []*OrderbookLevel {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.market.OrderbookLevels(side, s.books[side])
+}
+
+type orderbookNotification struct {
+	side   Side
+	result *ws.AccountResult
+	err    error
+}
+
+// Run subscribes to the bids and asks accounts of the market and calls `f` with
+// the changes of each notification, until the context is done, a subscription
+// fails or `f` returns an error. The first notification of each side is
+// diffed against an empty book, so it carries the whole side as additions.
+func (s *OrderbookStream) Run(ctx context.Context, f func(update *OrderbookUpdate) error) error {
+	accounts := map[Side]solana.PublicKey{
+		SideBid: s.market.MarketV2.Bids,
+		SideAsk: s.market.MarketV2.Asks,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	notifications := make(chan *orderbookNotification)
+	for side, account := range accounts {
+		sub, err := s.client.AccountSubscribe(account, s.commitment)
+		if err != nil {
+			return fmt.Errorf("unable to subscribe to orderbook account %q: %w", account, err)
+		}
+		defer sub.Unsubscribe()
+
+		go func(side Side, sub *ws.AccountSubscription) {
+			for {
+				res, err := sub.Recv()
+				if res == nil && err == nil {
+					// Subscription was closed.
+					return
+				}
+				select {
+				case notifications <- &orderbookNotification{side: side, result: res, err: err}:
+				case <-done:
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(side, sub)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notif := <-notifications:
+			if notif.err != nil {
+				return fmt.Errorf("received error from orderbook subscription: %w", notif.err)
+			}
+
+			update, err := s.Apply(notif.side, notif.result.Context.Slot, notif.result.Value.Account.Data.GetBinary())
+			if err != nil {
+				zlog.Warn("unable to apply orderbook update, skipping",
+					zap.Uint64("slot", notif.result.Context.Slot),
+					zap.Stringer("account", accounts[notif.side]),
+					zap.Error(err),
+				)
+				continue
+			}
+			if update.IsEmpty() {
+				continue
+			}
+			if err := f(update); err != nil {
+				return err
+			}
+		}
+	}
+}
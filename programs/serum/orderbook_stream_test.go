@@ -0,0 +1,212 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/diff"
+	"github.com/xmcontinue/solana-go/programs/token"
+)
+
+type testLeaf struct {
+	price    uint64
+	seq      uint64
+	quantity uint64
+}
+
+// newTestOrderbook builds a (degenerate but valid) slab holding the given leaves.
+func newTestOrderbook(side Side, leaves ...testLeaf) *Orderbook {
+	keys := make([]bin.Uint128, len(leaves))
+	for i, leaf := range leaves {
+		seq := leaf.seq
+		if side == SideBid {
+			seq = ^seq
+		}
+		keys[i] = bin.Uint128{Hi: leaf.price, Lo: seq}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Hi < keys[j].Hi || (keys[i].Hi == keys[j].Hi && keys[i].Lo < keys[j].Lo)
+	})
+	quantities := map[bin.Uint128]uint64{}
+	for i, leaf := range leaves {
+		seq := leaf.seq
+		if side == SideBid {
+			seq = ^seq
+		}
+		quantities[bin.Uint128{Hi: leaf.price, Lo: seq}] = leaves[i].quantity
+	}
+
+	flag := AccountFlagInitialized | AccountFlagAsks
+	if side == SideBid {
+		flag = AccountFlagInitialized | AccountFlagBids
+	}
+	book := &Orderbook{AccountFlags: flag, LeafCount: uint32(len(keys))}
+	newLeaf := func(key bin.Uint128) *Slab {
+		return &Slab{BaseVariant: bin.BaseVariant{
+			TypeID: bin.TypeIDFromUint32(2, binary.LittleEndian),
+			Impl: &SlabLeafNode{
+				Key:           key,
+				Owner:         solana.SystemProgramID,
+				Quantity:      bin.Uint64(quantities[key]),
+				ClientOrderId: bin.Uint64(key.Lo),
+			},
+		}}
+	}
+	if len(keys) == 1 {
+		book.Nodes = []*Slab{newLeaf(keys[0])}
+	}
+	// Inner node i has the leaf for keys[i] on its left, and the next inner
+	// node (or the last leaf) on its right.
+	for i := 0; len(keys) > 1 && i < len(keys)-1; i++ {
+		book.Nodes = append(book.Nodes,
+			&Slab{BaseVariant: bin.BaseVariant{
+				TypeID: bin.TypeIDFromUint32(1, binary.LittleEndian),
+				Impl:   &SlabInnerNode{Children: [2]uint32{uint32(2*i + 1), uint32(2*i + 2)}},
+			}},
+			newLeaf(keys[i]),
+		)
+		if i == len(keys)-2 {
+			book.Nodes = append(book.Nodes, newLeaf(keys[i+1]))
+		}
+	}
+	book.BumpIndex = uint32(len(book.Nodes))
+	return book
+}
+
+func newTestMarketMeta() *MarketMeta {
+	return &MarketMeta{
+		BaseMint:  token.Mint{Decimals: 6},
+		QuoteMint: token.Mint{Decimals: 6},
+		MarketV2: MarketV2{
+			BaseLotSize:  100000,
+			QuoteLotSize: 100,
+		},
+	}
+}
+
+func TestOrderbookLevels(t *testing.T) {
+	market := newTestMarketMeta()
+	book := newTestOrderbook(SideBid,
+		testLeaf{price: 10, seq: 1, quantity: 5},
+		testLeaf{price: 12, seq: 2, quantity: 3},
+		testLeaf{price: 10, seq: 3, quantity: 7},
+	)
+
+	orders := market.OrderbookOrders(SideBid, book)
+	require.Len(t, orders, 3)
+	assert.Equal(t, uint64(12), orders[0].PriceLots)
+	assert.Equal(t, uint64(10), orders[1].PriceLots)
+	assert.Equal(t, uint64(1), orders[1].OrderID.SeqNum(SideBid))
+	assert.Equal(t, uint64(3), orders[2].OrderID.SeqNum(SideBid))
+
+	levels := market.OrderbookLevels(SideBid, book)
+	require.Len(t, levels, 2)
+	assert.Equal(t, uint64(12), levels[0].PriceLots)
+	assert.Equal(t, uint64(10), levels[1].PriceLots)
+	assert.Equal(t, uint64(12), levels[1].SizeLots)
+	assert.Equal(t, "0.01", levels[1].Price.Text('f', 2))
+	assert.Equal(t, "1.2", levels[1].Size.Text('f', 1))
+}
+
+func TestDiffOrderbook(t *testing.T) {
+	market := newTestMarketMeta()
+	old := newTestOrderbook(SideAsk,
+		testLeaf{price: 10, seq: 1, quantity: 5},
+		testLeaf{price: 11, seq: 2, quantity: 3},
+		testLeaf{price: 12, seq: 3, quantity: 4},
+	)
+	current := newTestOrderbook(SideAsk,
+		testLeaf{price: 10, seq: 1, quantity: 2}, // partially filled
+		testLeaf{price: 12, seq: 3, quantity: 4}, // unchanged
+		testLeaf{price: 12, seq: 4, quantity: 1}, // new order on existing level
+		testLeaf{price: 13, seq: 5, quantity: 6}, // new level
+	)
+
+	update := DiffOrderbook(market, SideAsk, old, current)
+	assert.Equal(t, Side(SideAsk), update.Side)
+
+	require.Len(t, update.L3, 4)
+	assert.Equal(t, diff.KindChanged, update.L3[0].Kind)
+	assert.Equal(t, uint64(5), update.L3[0].Old.QuantityLots)
+	assert.Equal(t, uint64(2), update.L3[0].New.QuantityLots)
+	assert.Equal(t, diff.KindRemoved, update.L3[1].Kind)
+	assert.Equal(t, uint64(11), update.L3[1].Order().PriceLots)
+	assert.Nil(t, update.L3[1].New)
+	assert.Equal(t, diff.KindAdded, update.L3[2].Kind)
+	assert.Equal(t, uint64(4), update.L3[2].Order().OrderID.SeqNum(SideAsk))
+	assert.Nil(t, update.L3[2].Old)
+	assert.Equal(t, diff.KindAdded, update.L3[3].Kind)
+	assert.Equal(t, uint64(13), update.L3[3].Order().PriceLots)
+
+	require.Len(t, update.L2, 4)
+	assert.Equal(t, diff.KindChanged, update.L2[0].Kind)
+	assert.Equal(t, uint64(10), update.L2[0].Level().PriceLots)
+	assert.Equal(t, uint64(2), update.L2[0].New.SizeLots)
+	assert.Equal(t, diff.KindRemoved, update.L2[1].Kind)
+	assert.Equal(t, uint64(11), update.L2[1].Level().PriceLots)
+	assert.Equal(t, diff.KindChanged, update.L2[2].Kind)
+	assert.Equal(t, uint64(4), update.L2[2].Old.SizeLots)
+	assert.Equal(t, uint64(5), update.L2[2].New.SizeLots)
+	assert.Equal(t, diff.KindAdded, update.L2[3].Kind)
+	assert.Equal(t, uint64(13), update.L2[3].Level().PriceLots)
+
+	assert.True(t, DiffOrderbook(market, SideAsk, current, current).IsEmpty())
+}
+
+func TestOrderbookStream_Apply(t *testing.T) {
+	market := newTestMarketMeta()
+	stream := NewOrderbookStream(nil, market, "")
+
+	encode := func(book *Orderbook) []byte {
+		buf := new(bytes.Buffer)
+		require.NoError(t, bin.NewBinEncoder(buf).Encode(book))
+		return buf.Bytes()
+	}
+
+	update, err := stream.Apply(SideBid, 1, encode(newTestOrderbook(SideBid,
+		testLeaf{price: 10, seq: 1, quantity: 5},
+		testLeaf{price: 9, seq: 2, quantity: 3},
+	)))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), update.Slot)
+	require.Len(t, update.L2, 2)
+	assert.Equal(t, diff.KindAdded, update.L2[0].Kind)
+	assert.Equal(t, uint64(10), update.L2[0].Level().PriceLots)
+
+	update, err = stream.Apply(SideBid, 2, encode(newTestOrderbook(SideBid,
+		testLeaf{price: 9, seq: 2, quantity: 3},
+	)))
+	require.NoError(t, err)
+	require.Len(t, update.L3, 1)
+	assert.Equal(t, diff.KindRemoved, update.L3[0].Kind)
+	assert.Equal(t, uint64(1), update.L3[0].Old.OrderID.SeqNum(SideBid))
+
+	levels := stream.Levels(SideBid)
+	require.Len(t, levels, 1)
+	assert.Equal(t, uint64(9), levels[0].PriceLots)
+	assert.Empty(t, stream.Orders(SideAsk))
+}
@@ -0,0 +1,324 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"go.uber.org/zap"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+	"github.com/xmcontinue/solana-go/rpc/ws"
+)
+
+// Event queue account layout: 5 bytes of serum padding, then the
+// account flags, head, count and sequence number (8 bytes each),
+// then the ring buffer, then 7 bytes of end padding.
+const (
+	eventQueueHeaderSize  = 5 + 8 + 8 + 8 + 8
+	eventQueuePaddingSize = 7
+)
+
+// SequencedEvent is an event queue record along with its sequence number,
+// that is its position in the history of all the events ever pushed to the queue.
+type SequencedEvent struct {
+	SeqNum uint64
+	*Event
+}
+
+// EventQueueUpdate holds the events pushed to the queue since the previous update.
+type EventQueueUpdate struct {
+	Slot uint64
+
+	// SeqNum is the sequence number of the queue after this update,
+	// the next event to be pushed will have this sequence number.
+	SeqNum uint64
+
+	// Events are the new events, in the order they were pushed.
+	Events []*SequencedEvent
+
+	// Missed is the number of events that were pushed since the previous update,
+	// but had already been overwritten in the ring buffer when this update was read.
+	Missed uint64
+}
+
+// Fills returns the new fill events.
+func (u *EventQueueUpdate) Fills() (out []*SequencedEvent) {
+	for _, ev := range u.Events {
+		if ev.Filled() {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// FillsByOwner returns the new fill events of the provided OpenOrders account.
+func (u *EventQueueUpdate) FillsByOwner(openOrders solana.PublicKey) (out []*SequencedEvent) {
+	for _, ev := range u.Fills() {
+		if ev.Owner.Equals(openOrders) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// FillsByClientOrderID returns the new fill events of orders placed with the provided client order ID.
+func (u *EventQueueUpdate) FillsByClientOrderID(clientOrderID uint64) (out []*SequencedEvent) {
+	for _, ev := range u.Fills() {
+		if ev.ClientOrderID == clientOrderID {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// EventQueueConsumer follows an event queue over successive reads of its
+// account (polls or websocket notifications) and returns only the events
+// pushed in between.
+//
+// The ring buffer keeps the events after they are consumed by the
+// crank, so new events are read by sequence number regardless of the
+// queue head: the event with sequence number `n` is stored at index
+// `n % capacity`. Events are lost only when more than `capacity` events
+// were pushed between two reads.
+type EventQueueConsumer struct {
+	lock        sync.Mutex
+	initialized bool
+	seqNum      uint64
+	slot        uint64
+}
+
+// NewEventQueueConsumer creates a consumer that starts at the first queue state
+// it sees: events pushed before that are not returned.
+func NewEventQueueConsumer() *EventQueueConsumer {
+	return &EventQueueConsumer{}
+}
+
+// NewEventQueueConsumerFromSeqNum creates a consumer that resumes at the provided
+// sequence number, as previously returned in `EventQueueUpdate.SeqNum`.
+func NewEventQueueConsumerFromSeqNum(seqNum uint64) *EventQueueConsumer {
+	return &EventQueueConsumer{
+		initialized: true,
+		seqNum:      seqNum,
+	}
+}
+
+// SeqNum returns the sequence number of the next event the consumer expects.
+func (c *EventQueueConsumer) SeqNum() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.seqNum
+}
+
+// Consume reads the raw data of the event queue account as seen at `slot`
+// and returns the events pushed since the previous call. Reads that are
+// older than the latest consumed one (e.g. from a lagging RPC node) return
+// an empty update and do not move the consumer backwards.
+func (c *EventQueueConsumer) Consume(slot uint64, data []byte) (*EventQueueUpdate, error) {
+	ring, err := newEventQueueRing(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	update := &EventQueueUpdate{Slot: slot, SeqNum: c.seqNum}
+	if !c.initialized {
+		c.initialized = true
+		c.seqNum = ring.seqNum
+		c.slot = slot
+		update.SeqNum = ring.seqNum
+		return update, nil
+	}
+
+	if slot < c.slot || ring.seqNum < c.seqNum {
+		if traceEnabled {
+			zlog.Debug("ignoring stale event queue state",
+				zap.Uint64("slot", slot),
+				zap.Uint64("seq_num", ring.seqNum),
+				zap.Uint64("expected_seq_num", c.seqNum),
+			)
+		}
+		return update, nil
+	}
+
+	from := c.seqNum
+	if ring.seqNum-from > ring.capacity {
+		update.Missed = ring.seqNum - from - ring.capacity
+		from = ring.seqNum - ring.capacity
+	}
+
+	for seqNum := from; seqNum < ring.seqNum; seqNum++ {
+		ev, err := ring.eventAt(seqNum)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode event %d: %w", seqNum, err)
+		}
+		update.Events = append(update.Events, &SequencedEvent{SeqNum: seqNum, Event: ev})
+	}
+
+	c.seqNum = ring.seqNum
+	c.slot = slot
+	update.SeqNum = ring.seqNum
+	return update, nil
+}
+
+// Stream subscribes to the event queue account and calls `f` with each non-empty
+// update until the context is done, the subscription fails or `f` returns an error.
+func (c *EventQueueConsumer) Stream(
+	ctx context.Context,
+	client *ws.Client,
+	eventQueue solana.PublicKey,
+	commitment rpc.CommitmentType,
+	f func(update *EventQueueUpdate) error,
+) error {
+	sub, err := client.AccountSubscribe(eventQueue, commitment)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to event queue %q: %w", eventQueue, err)
+	}
+	defer sub.Unsubscribe()
+
+	type notification struct {
+		result *ws.AccountResult
+		err    error
+	}
+	done := make(chan struct{})
+	defer close(done)
+	notifications := make(chan *notification)
+	go func() {
+		for {
+			res, err := sub.Recv()
+			if res == nil && err == nil {
+				// Subscription was closed.
+				return
+			}
+			select {
+			case notifications <- &notification{result: res, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notif := <-notifications:
+			if notif.err != nil {
+				return fmt.Errorf("received error from event queue subscription: %w", notif.err)
+			}
+			if err := c.consumeAndNotify(notif.result.Context.Slot, notif.result.Value.Account.Data.GetBinary(), f); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Poll reads the event queue account every `interval` and calls `f` with each non-empty
+// update until the context is done, a read fails or `f` returns an error.
+func (c *EventQueueConsumer) Poll(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	eventQueue solana.PublicKey,
+	interval time.Duration,
+	f func(update *EventQueueUpdate) error,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		acctInfo, err := rpcCli.GetAccountInfo(ctx, eventQueue)
+		if err != nil {
+			return fmt.Errorf("unable to get event queue account: %w", err)
+		}
+		if err := c.consumeAndNotify(acctInfo.Context.Slot, acctInfo.Value.Data.GetBinary(), f); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *EventQueueConsumer) consumeAndNotify(slot uint64, data []byte, f func(update *EventQueueUpdate) error) error {
+	update, err := c.Consume(slot, data)
+	if err != nil {
+		return fmt.Errorf("unable to consume event queue: %w", err)
+	}
+	if len(update.Events) == 0 && update.Missed == 0 {
+		return nil
+	}
+	return f(update)
+}
+
+type eventQueueRing struct {
+	data     []byte
+	seqNum   uint64
+	capacity uint64
+}
+
+func newEventQueueRing(data []byte) (*eventQueueRing, error) {
+	if len(data) < eventQueueHeaderSize+eventQueuePaddingSize+int(EVENT_BYTE_SIZE) {
+		return nil, fmt.Errorf("event queue data too short: %d bytes", len(data))
+	}
+
+	var queue EventQueue
+	decoder := bin.NewBinDecoder(data)
+	if err := decoder.SkipBytes(5); err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&queue.AccountFlags); err != nil {
+		return nil, err
+	}
+	if !queue.AccountFlags.Is(AccountFlagEventQueue) {
+		return nil, fmt.Errorf("account is not an event queue: %s", queue.AccountFlags.String())
+	}
+	if err := decoder.SkipBytes(16); err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(&queue.SeqNum); err != nil {
+		return nil, err
+	}
+
+	return &eventQueueRing{
+		data:     data,
+		seqNum:   uint64(queue.SeqNum),
+		capacity: uint64(len(data)-eventQueueHeaderSize-eventQueuePaddingSize) / uint64(EVENT_BYTE_SIZE),
+	}, nil
+}
+
+func (r *eventQueueRing) eventAt(seqNum uint64) (*Event, error) {
+	offset := eventQueueHeaderSize + (seqNum%r.capacity)*uint64(EVENT_BYTE_SIZE)
+	var ev *Event
+	if err := bin.NewBinDecoder(r.data[offset : offset+uint64(EVENT_BYTE_SIZE)]).Decode(&ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// testEventQueue simulates the on-chain ring buffer: events are pushed at
+// `seqNum % capacity` and consumed (popped) from the head.
+type testEventQueue struct {
+	capacity uint64
+	head     uint64
+	count    uint64
+	seqNum   uint64
+	events   []*Event
+}
+
+func newTestEventQueue(capacity uint64) *testEventQueue {
+	q := &testEventQueue{capacity: capacity, events: make([]*Event, capacity)}
+	for i := range q.events {
+		q.events[i] = &Event{}
+	}
+	return q
+}
+
+func (q *testEventQueue) push(ev *Event) {
+	q.events[q.seqNum%q.capacity] = ev
+	q.seqNum++
+	if q.count == q.capacity {
+		q.head = (q.head + 1) % q.capacity
+	} else {
+		q.count++
+	}
+}
+
+func (q *testEventQueue) pop(n uint64) {
+	q.head = (q.head + n) % q.capacity
+	q.count -= n
+}
+
+func (q *testEventQueue) bytes(t *testing.T) []byte {
+	buf := new(bytes.Buffer)
+	enc := bin.NewBinEncoder(buf)
+	require.NoError(t, enc.WriteBytes([]byte("serum"), false))
+	for _, v := range []uint64{uint64(AccountFlagInitialized | AccountFlagEventQueue), q.head, q.count, q.seqNum} {
+		require.NoError(t, enc.WriteUint64(v, binary.LittleEndian))
+	}
+	for _, ev := range q.events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	require.NoError(t, enc.WriteBytes([]byte("padding"), false))
+	return buf.Bytes()
+}
+
+func TestEventQueueConsumer(t *testing.T) {
+	owner := solana.MustPublicKeyFromBase58("AoS32A7R7PGoDftJsNnWcB3C8CZ2vhxbNhbDt11ny62o")
+	other := solana.MustPublicKeyFromBase58("13iGJcA4w5hcJZDjJbJQor1zUiDLE4jv2rMW9HkD5Eo1")
+
+	queue := newTestEventQueue(4)
+	queue.push(&Event{Flag: EventFlagOut, Owner: owner, ClientOrderID: 1})
+
+	decoded := &EventQueue{}
+	require.NoError(t, decoded.Decode(queue.bytes(t)))
+	require.Len(t, decoded.Events, 1)
+	assert.Equal(t, owner, decoded.Events[0].Owner)
+
+	consumer := NewEventQueueConsumer()
+	update, err := consumer.Consume(10, queue.bytes(t))
+	require.NoError(t, err)
+	assert.Empty(t, update.Events, "events pushed before the first read are skipped")
+	assert.Equal(t, uint64(1), update.SeqNum)
+
+	queue.push(&Event{Flag: EventFlagFill | EventFlagBid, Owner: owner, ClientOrderID: 7, NativeQtyReleased: 100})
+	queue.push(&Event{Flag: EventFlagFill | EventFlagMaker, Owner: other, ClientOrderID: 8})
+	queue.push(&Event{Flag: EventFlagOut, Owner: owner, ClientOrderID: 7})
+	// The crank consumes everything, the events must still be returned.
+	queue.pop(queue.count)
+
+	update, err = consumer.Consume(11, queue.bytes(t))
+	require.NoError(t, err)
+	require.Len(t, update.Events, 3)
+	assert.Equal(t, uint64(1), update.Events[0].SeqNum)
+	assert.Equal(t, uint64(3), update.Events[2].SeqNum)
+	assert.Equal(t, uint64(0), update.Missed)
+	assert.Len(t, update.Fills(), 2)
+
+	fills := update.FillsByOwner(owner)
+	require.Len(t, fills, 1)
+	assert.Equal(t, uint64(100), fills[0].NativeQtyReleased)
+	assert.Equal(t, Side(SideBid), fills[0].Side())
+
+	fills = update.FillsByClientOrderID(8)
+	require.Len(t, fills, 1)
+	assert.Equal(t, other, fills[0].Owner)
+
+	// Same state again: nothing new.
+	update, err = consumer.Consume(12, queue.bytes(t))
+	require.NoError(t, err)
+	assert.Empty(t, update.Events)
+
+	// Wrap around the ring buffer.
+	queue.push(&Event{Flag: EventFlagFill, Owner: owner, ClientOrderID: 9})
+	queue.push(&Event{Flag: EventFlagFill, Owner: owner, ClientOrderID: 10})
+	update, err = consumer.Consume(13, queue.bytes(t))
+	require.NoError(t, err)
+	require.Len(t, update.Events, 2)
+	assert.Equal(t, uint64(4), update.Events[0].SeqNum)
+	assert.Equal(t, uint64(9), update.Events[0].ClientOrderID)
+	assert.Equal(t, uint64(10), update.Events[1].ClientOrderID)
+
+	// A stale read must not move the consumer backwards.
+	stale := queue.bytes(t)
+	for i := 0; i < 6; i++ {
+		queue.push(&Event{Flag: EventFlagFill, Owner: owner, ClientOrderID: uint64(100 + i)})
+	}
+	update, err = consumer.Consume(12, stale)
+	require.NoError(t, err)
+	assert.Empty(t, update.Events)
+	assert.Equal(t, uint64(6), consumer.SeqNum())
+
+	// More events than the capacity were pushed: the oldest ones are lost.
+	update, err = consumer.Consume(14, queue.bytes(t))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), update.Missed)
+	require.Len(t, update.Events, 4)
+	assert.Equal(t, uint64(8), update.Events[0].SeqNum)
+	assert.Equal(t, uint64(102), update.Events[0].ClientOrderID)
+	assert.Equal(t, uint64(12), update.SeqNum)
+}
+
+func TestEventQueueConsumer_FromSeqNum(t *testing.T) {
+	queue := newTestEventQueue(8)
+	for i := 0; i < 5; i++ {
+		queue.push(&Event{Flag: EventFlagFill, ClientOrderID: uint64(i)})
+	}
+
+	consumer := NewEventQueueConsumerFromSeqNum(3)
+	update, err := consumer.Consume(1, queue.bytes(t))
+	require.NoError(t, err)
+	require.Len(t, update.Events, 2)
+	assert.Equal(t, uint64(3), update.Events[0].ClientOrderID)
+	assert.Equal(t, uint64(5), consumer.SeqNum())
+}
+
+func TestEventQueueConsumer_NotAnEventQueue(t *testing.T) {
+	data := newTestEventQueue(2).bytes(t)
+	binary.LittleEndian.PutUint64(data[5:], uint64(AccountFlagInitialized|AccountFlagRequestQueue))
+
+	_, err := NewEventQueueConsumer().Consume(1, data)
+	require.Error(t, err)
+}
@@ -277,6 +277,10 @@ func Test_OpenOrder_GetOrder(t *testing.T) {
 	}, o)
 	assert.Equal(t, o.SeqNum(), uint64(5447938))
 	assert.Equal(t, o.Price(), uint64(2112))
+
+	obj, err := solana.DecodeAccount(ProgramID, readHexFile(t, openOrderData))
+	require.NoError(t, err)
+	assert.Equal(t, openOrders, obj)
 }
 
 func TestIsBitZero(t *testing.T) {
@@ -22,6 +22,9 @@ import (
 	"github.com/xmcontinue/solana-go"
 )
 
+// NONCE_ACCOUNT_SIZE is the size of a NonceAccount.
+const NONCE_ACCOUNT_SIZE = 80
+
 type NonceAccount struct {
 	Version          uint32
 	State            uint32
@@ -92,3 +95,19 @@ func (obj *FeeCalculator) UnmarshalWithDecoder(decoder *bin.Decoder) (err error)
 	obj.LamportsPerSignature, err = decoder.ReadUint64(binary.LittleEndian)
 	return err
 }
+
+func registerAccountDecoders(programID solana.PublicKey) {
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:   "NonceAccount",
+		Size:   NONCE_ACCOUNT_SIZE,
+		Decode: decodeNonceAccount,
+	})
+}
+
+func decodeNonceAccount(data []byte) (interface{}, error) {
+	acc := new(NonceAccount)
+	if err := acc.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
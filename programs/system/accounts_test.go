@@ -42,4 +42,8 @@ func TestDecode(t *testing.T) {
 	assert.Equal(t, solana.MustPublicKeyFromBase58("5omQJtDUHA3gMFdHEQg1zZSvcBUVzey5WaKWYRmqF1Vj"), acc.AuthorizedPubkey)
 	assert.Equal(t, solana.MustPublicKeyFromBase58("8ksS6xXd7vzNrpZfBTf9gJ87Bma5AjnQ9baEcT7xH5QE"), acc.Nonce)
 	assert.Equal(t, uint64(5000), acc.FeeCalculator.LamportsPerSignature)
+
+	obj, err := solana.DecodeAccount(solana.SystemProgramID, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, acc, obj)
 }
@@ -0,0 +1,37 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package system
+
+import (
+	"github.com/xmcontinue/solana-go"
+)
+
+// Errors are the custom errors of the System program.
+var Errors = []solana.ProgramError{
+	{Code: 0, Name: "AccountAlreadyInUse", Message: "an account with the same address already exists"},
+	{Code: 1, Name: "ResultWithNegativeLamports", Message: "account does not have enough SOL to perform the operation"},
+	{Code: 2, Name: "InvalidProgramId", Message: "cannot assign account to this program id"},
+	{Code: 3, Name: "InvalidAccountDataLength", Message: "cannot allocate account data of this length"},
+	{Code: 4, Name: "MaxSeedLengthExceeded", Message: "length of requested seed is too long"},
+	{Code: 5, Name: "AddressWithSeedMismatch", Message: "provided address does not match addressed derived from seed"},
+	{Code: 6, Name: "NonceNoRecentBlockhashes", Message: "advancing stored nonce requires a populated RecentBlockhashes sysvar"},
+	{Code: 7, Name: "NonceBlockhashNotExpired", Message: "stored nonce is still in recent_blockhashes"},
+	{Code: 8, Name: "NonceUnexpectedBlockhashValue", Message: "specified nonce does not match stored nonce"},
+}
+
+func registerErrors(programID solana.PublicKey) {
+	solana.RegisterProgramErrors(programID, Errors...)
+}
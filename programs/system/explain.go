@@ -0,0 +1,45 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"fmt"
+
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func explainInstruction(ctx *explain.Context, inst *explain.Instruction) []string {
+	decoded, ok := inst.Decoded.(*Instruction)
+	if !ok {
+		return nil
+	}
+
+	switch impl := decoded.Impl.(type) {
+	case *Transfer:
+		from, to := impl.GetFundingAccount(), impl.GetRecipientAccount()
+		if impl.Lamports == nil || from == nil || to == nil {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s transferred %s to %s", ctx.Label(from.PublicKey), ctx.FormatSOL(*impl.Lamports), ctx.Label(to.PublicKey))}
+	case *CreateAccount:
+		funding, account := impl.GetFundingAccount(), impl.GetNewAccount()
+		if impl.Lamports == nil || impl.Owner == nil || funding == nil || account == nil {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s created account %s owned by %s with %s", ctx.Label(funding.PublicKey), ctx.Label(account.PublicKey), ctx.Label(*impl.Owner), ctx.FormatSOL(*impl.Lamports))}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func TestExplainInstruction(t *testing.T) {
+	alice := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	bob := solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	account := solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		NewTransferInstruction(1500000000, alice, bob).Build(),
+		NewCreateAccountInstruction(2039280, 165, solana.TokenProgramID, alice, account).Build(),
+	}, solana.Hash{}, solana.TransactionPayer(alice))
+	require.NoError(t, err)
+
+	statements, err := explain.Explain(tx, nil, &explain.Options{Labels: map[solana.PublicKey]string{alice: "alice", bob: "bob"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alice transferred 1.5 SOL to bob",
+		"alice created account " + account.String() + " owned by the Token program with 0.00203928 SOL",
+	}, statements)
+}
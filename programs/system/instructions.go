@@ -26,6 +26,7 @@ import (
 	ag_binary "github.com/gagliardetto/binary"
 	ag_treeout "github.com/gagliardetto/treeout"
 	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
 	ag_text "github.com/xmcontinue/solana-go/text"
 )
 
@@ -34,12 +35,18 @@ var ProgramID ag_solanago.PublicKey = ag_solanago.SystemProgramID
 func SetProgramID(pubkey ag_solanago.PublicKey) {
 	ProgramID = pubkey
 	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+	registerErrors(ProgramID)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
 }
 
 const ProgramName = "System"
 
 func init() {
 	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+	registerErrors(ProgramID)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
 }
 
 const (
@@ -0,0 +1,348 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Make a flash loan.
+//
+// The flash loan receiver program is invoked with the ReceiveFlashLoan instruction
+// (tag 0, followed by the amount), followed by the accounts after the receiver program.
+type FlashLoan struct {
+	// The amount that is to be borrowed - u64::MAX for up to 100% of available liquidity.
+	Amount *uint64
+
+	// [0] = [WRITE] sourceLiquidity
+	// ··········· Source liquidity; reserve liquidity supply SPL Token account.
+	//
+	// [1] = [WRITE] destinationLiquidity
+	// ··········· Destination liquidity; non-reserve liquidity SPL Token account.
+	//
+	// [2] = [WRITE] reserve
+	// ··········· Reserve account.
+	//
+	// [3] = [WRITE] flashLoanFeeReceiver
+	// ··········· Flash loan fee receiver account; must match the reserve liquidity fee receiver.
+	//
+	// [4] = [WRITE] hostFeeReceiver
+	// ··········· Host fee receiver.
+	//
+	// [5] = [] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [6] = [] lendingMarketAuthority
+	// ··········· Derived lending market authority.
+	//
+	// [7] = [] tokenProgram
+	// ··········· Token program id.
+	//
+	// [8] = [] flashLoanReceiverProgram
+	// ··········· Flash loan receiver program id; must implement an instruction that has tag of 0 and a signature of `(amount: u64)`.
+	//
+	// [9...] = [] receiverAccounts
+	// ··········· Accounts expected by the flash loan receiver program.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewFlashLoanInstructionBuilder creates a new `FlashLoan` instruction builder.
+func NewFlashLoanInstructionBuilder() *FlashLoan {
+	nd := &FlashLoan{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 9),
+	}
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount that is to be borrowed - u64::MAX for up to 100% of available liquidity.
+func (inst *FlashLoan) SetAmount(amount uint64) *FlashLoan {
+	inst.Amount = &amount
+	return inst
+}
+
+// SetSourceLiquidityAccount sets the "sourceLiquidity" account.
+// Source liquidity; reserve liquidity supply SPL Token account.
+func (inst *FlashLoan) SetSourceLiquidityAccount(sourceLiquidity ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(sourceLiquidity).WRITE()
+	return inst
+}
+
+// GetSourceLiquidityAccount gets the "sourceLiquidity" account.
+// Source liquidity; reserve liquidity supply SPL Token account.
+func (inst *FlashLoan) GetSourceLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetDestinationLiquidityAccount sets the "destinationLiquidity" account.
+// Destination liquidity; non-reserve liquidity SPL Token account.
+func (inst *FlashLoan) SetDestinationLiquidityAccount(destinationLiquidity ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(destinationLiquidity).WRITE()
+	return inst
+}
+
+// GetDestinationLiquidityAccount gets the "destinationLiquidity" account.
+// Destination liquidity; non-reserve liquidity SPL Token account.
+func (inst *FlashLoan) GetDestinationLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetReserveAccount sets the "reserve" account.
+// Reserve account.
+func (inst *FlashLoan) SetReserveAccount(reserve ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(reserve).WRITE()
+	return inst
+}
+
+// GetReserveAccount gets the "reserve" account.
+// Reserve account.
+func (inst *FlashLoan) GetReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetFlashLoanFeeReceiverAccount sets the "flashLoanFeeReceiver" account.
+// Flash loan fee receiver account; must match the reserve liquidity fee receiver.
+func (inst *FlashLoan) SetFlashLoanFeeReceiverAccount(flashLoanFeeReceiver ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(flashLoanFeeReceiver).WRITE()
+	return inst
+}
+
+// GetFlashLoanFeeReceiverAccount gets the "flashLoanFeeReceiver" account.
+// Flash loan fee receiver account; must match the reserve liquidity fee receiver.
+func (inst *FlashLoan) GetFlashLoanFeeReceiverAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetHostFeeReceiverAccount sets the "hostFeeReceiver" account.
+// Host fee receiver.
+func (inst *FlashLoan) SetHostFeeReceiverAccount(hostFeeReceiver ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(hostFeeReceiver).WRITE()
+	return inst
+}
+
+// GetHostFeeReceiverAccount gets the "hostFeeReceiver" account.
+// Host fee receiver.
+func (inst *FlashLoan) GetHostFeeReceiverAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *FlashLoan) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(lendingMarket)
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *FlashLoan) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetLendingMarketAuthorityAccount sets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *FlashLoan) SetLendingMarketAuthorityAccount(lendingMarketAuthority ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(lendingMarketAuthority)
+	return inst
+}
+
+// GetLendingMarketAuthorityAccount gets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *FlashLoan) GetLendingMarketAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *FlashLoan) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *FlashLoan) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetFlashLoanReceiverProgramAccount sets the "flashLoanReceiverProgram" account.
+// Flash loan receiver program id; must implement an instruction that has tag of 0 and a signature of `(amount: u64)`.
+func (inst *FlashLoan) SetFlashLoanReceiverProgramAccount(flashLoanReceiverProgram ag_solanago.PublicKey) *FlashLoan {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(flashLoanReceiverProgram)
+	return inst
+}
+
+// GetFlashLoanReceiverProgramAccount gets the "flashLoanReceiverProgram" account.
+// Flash loan receiver program id; must implement an instruction that has tag of 0 and a signature of `(amount: u64)`.
+func (inst *FlashLoan) GetFlashLoanReceiverProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// AppendReceiverAccount appends an account to the "receiverAccounts" accounts.
+// Accounts expected by the flash loan receiver program.
+func (inst *FlashLoan) AppendReceiverAccount(receiverAccount *ag_solanago.AccountMeta) *FlashLoan {
+	inst.AccountMetaSlice.Append(receiverAccount)
+	return inst
+}
+
+// GetReceiverAccounts gets the "receiverAccounts" accounts.
+// Accounts expected by the flash loan receiver program.
+func (inst *FlashLoan) GetReceiverAccounts() ag_solanago.AccountMetaSlice {
+	_, receiverAccounts := inst.AccountMetaSlice.SplitFrom(9)
+	return receiverAccounts
+}
+
+func (inst FlashLoan) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_FlashLoan),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst FlashLoan) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *FlashLoan) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.SourceLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.DestinationLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.Reserve is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.FlashLoanFeeReceiver is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.HostFeeReceiver is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.LendingMarketAuthority is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.FlashLoanReceiverProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *FlashLoan) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("FlashLoan")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Amount", *inst.Amount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("         sourceLiquidity", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("    destinationLiquidity", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                 reserve", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("    flashLoanFeeReceiver", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("         hostFeeReceiver", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("           lendingMarket", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("  lendingMarketAuthority", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("            tokenProgram", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("flashLoanReceiverProgram", inst.AccountMetaSlice.Get(8)))
+
+						receiverAccounts := inst.GetReceiverAccounts()
+						receiverAccountsBranch := accountsBranch.Child(fmt.Sprintf("receiverAccounts[len=%v]", len(receiverAccounts)))
+						for i, v := range receiverAccounts {
+							receiverAccountsBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj FlashLoan) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Amount` param:
+	err = encoder.Encode(obj.Amount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *FlashLoan) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Amount`:
+	err = decoder.Decode(&obj.Amount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewFlashLoanInstruction declares a new FlashLoan instruction with the provided parameters and accounts.
+func NewFlashLoanInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	sourceLiquidity ag_solanago.PublicKey,
+	destinationLiquidity ag_solanago.PublicKey,
+	reserve ag_solanago.PublicKey,
+	flashLoanFeeReceiver ag_solanago.PublicKey,
+	hostFeeReceiver ag_solanago.PublicKey,
+	lendingMarket ag_solanago.PublicKey,
+	lendingMarketAuthority ag_solanago.PublicKey,
+	flashLoanReceiverProgram ag_solanago.PublicKey,
+) *FlashLoan {
+	return NewFlashLoanInstructionBuilder().
+		SetAmount(amount).
+		SetSourceLiquidityAccount(sourceLiquidity).
+		SetDestinationLiquidityAccount(destinationLiquidity).
+		SetReserveAccount(reserve).
+		SetFlashLoanFeeReceiverAccount(flashLoanFeeReceiver).
+		SetHostFeeReceiverAccount(hostFeeReceiver).
+		SetLendingMarketAccount(lendingMarket).
+		SetLendingMarketAuthorityAccount(lendingMarketAuthority).
+		SetFlashLoanReceiverProgramAccount(flashLoanReceiverProgram)
+}
@@ -0,0 +1,239 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Initializes a new lending market.
+type InitLendingMarket struct {
+	// Owner authority which can add new reserves.
+	Owner *ag_solanago.PublicKey
+
+	// Currency market prices are quoted in
+	// e.g. "USD" null padded (`*b"USD\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0"`) or a SPL token mint pubkey.
+	QuoteCurrency *[32]byte
+
+	// [0] = [WRITE] lendingMarket
+	// ··········· Lending market account - uninitialized.
+	//
+	// [1] = [] rent
+	// ··········· Rent sysvar.
+	//
+	// [2] = [] tokenProgram
+	// ··········· Token program id.
+	//
+	// [3] = [] oracleProgram
+	// ··········· Oracle (Pyth) program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitLendingMarketInstructionBuilder creates a new `InitLendingMarket` instruction builder.
+func NewInitLendingMarketInstructionBuilder() *InitLendingMarket {
+	nd := &InitLendingMarket{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetOwner sets the "owner" parameter.
+// Owner authority which can add new reserves.
+func (inst *InitLendingMarket) SetOwner(owner ag_solanago.PublicKey) *InitLendingMarket {
+	inst.Owner = &owner
+	return inst
+}
+
+// SetQuoteCurrency sets the "quoteCurrency" parameter.
+// Currency market prices are quoted in
+// e.g. "USD" null padded (`*b"USD\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0"`) or a SPL token mint pubkey.
+func (inst *InitLendingMarket) SetQuoteCurrency(quoteCurrency [32]byte) *InitLendingMarket {
+	inst.QuoteCurrency = &quoteCurrency
+	return inst
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account - uninitialized.
+func (inst *InitLendingMarket) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *InitLendingMarket {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(lendingMarket).WRITE()
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account - uninitialized.
+func (inst *InitLendingMarket) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetRentAccount sets the "rent" account.
+// Rent sysvar.
+func (inst *InitLendingMarket) SetRentAccount(rent ag_solanago.PublicKey) *InitLendingMarket {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(rent)
+	return inst
+}
+
+// GetRentAccount gets the "rent" account.
+// Rent sysvar.
+func (inst *InitLendingMarket) GetRentAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *InitLendingMarket) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *InitLendingMarket {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *InitLendingMarket) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetOracleProgramAccount sets the "oracleProgram" account.
+// Oracle (Pyth) program id.
+func (inst *InitLendingMarket) SetOracleProgramAccount(oracleProgram ag_solanago.PublicKey) *InitLendingMarket {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(oracleProgram)
+	return inst
+}
+
+// GetOracleProgramAccount gets the "oracleProgram" account.
+// Oracle (Pyth) program id.
+func (inst *InitLendingMarket) GetOracleProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+func (inst InitLendingMarket) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_InitLendingMarket),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitLendingMarket) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitLendingMarket) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+		if inst.QuoteCurrency == nil {
+			return errors.New("QuoteCurrency parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Rent is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.OracleProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitLendingMarket) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitLendingMarket")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("        Owner", *inst.Owner))
+						paramsBranch.Child(ag_format.Param("QuoteCurrency", *inst.QuoteCurrency))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("lendingMarket", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("         rent", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta(" tokenProgram", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("oracleProgram", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (obj InitLendingMarket) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Owner` param:
+	err = encoder.Encode(obj.Owner)
+	if err != nil {
+		return err
+	}
+	// Serialize `QuoteCurrency` param:
+	err = encoder.Encode(obj.QuoteCurrency)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitLendingMarket) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Owner`:
+	err = decoder.Decode(&obj.Owner)
+	if err != nil {
+		return err
+	}
+	// Deserialize `QuoteCurrency`:
+	err = decoder.Decode(&obj.QuoteCurrency)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitLendingMarketInstruction declares a new InitLendingMarket instruction with the provided parameters and accounts.
+func NewInitLendingMarketInstruction(
+	// Parameters:
+	owner ag_solanago.PublicKey,
+	quoteCurrency [32]byte,
+	// Accounts:
+	lendingMarket ag_solanago.PublicKey,
+	oracleProgram ag_solanago.PublicKey,
+) *InitLendingMarket {
+	return NewInitLendingMarketInstructionBuilder().
+		SetOwner(owner).
+		SetQuoteCurrency(quoteCurrency).
+		SetLendingMarketAccount(lendingMarket).
+		SetOracleProgramAccount(oracleProgram)
+}
@@ -0,0 +1,522 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Initializes a new lending market reserve.
+type InitReserve struct {
+	// Initial amount of liquidity to deposit into the new reserve.
+	LiquidityAmount *uint64
+
+	// Reserve configuration values.
+	Config *ReserveConfig
+
+	// [0] = [WRITE] sourceLiquidity
+	// ··········· Source liquidity token account; $authority can transfer $liquidity_amount.
+	//
+	// [1] = [WRITE] destinationCollateral
+	// ··········· Destination collateral token account - uninitialized.
+	//
+	// [2] = [WRITE] reserve
+	// ··········· Reserve account - uninitialized.
+	//
+	// [3] = [] reserveLiquidityMint
+	// ··········· Reserve liquidity SPL Token mint.
+	//
+	// [4] = [WRITE] reserveLiquiditySupply
+	// ··········· Reserve liquidity supply SPL Token account - uninitialized.
+	//
+	// [5] = [WRITE] reserveLiquidityFeeReceiver
+	// ··········· Reserve liquidity fee receiver - uninitialized.
+	//
+	// [6] = [WRITE] reserveCollateralMint
+	// ··········· Reserve collateral SPL Token mint - uninitialized.
+	//
+	// [7] = [WRITE] reserveCollateralSupply
+	// ··········· Reserve collateral token supply - uninitialized.
+	//
+	// [8] = [] pythProduct
+	// ··········· Pyth product account.
+	//
+	// [9] = [] pythPrice
+	// ··········· Pyth price account; this will be the reserve liquidity oracle account.
+	//
+	// [10] = [] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [11] = [] lendingMarketAuthority
+	// ··········· Derived lending market authority.
+	//
+	// [12] = [SIGNER] lendingMarketOwner
+	// ··········· Lending market owner.
+	//
+	// [13] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority ($authority).
+	//
+	// [14] = [] clock
+	// ··········· Clock sysvar.
+	//
+	// [15] = [] rent
+	// ··········· Rent sysvar.
+	//
+	// [16] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitReserveInstructionBuilder creates a new `InitReserve` instruction builder.
+func NewInitReserveInstructionBuilder() *InitReserve {
+	nd := &InitReserve{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 17),
+	}
+	nd.AccountMetaSlice[14] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[15] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	nd.AccountMetaSlice[16] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetLiquidityAmount sets the "liquidityAmount" parameter.
+// Initial amount of liquidity to deposit into the new reserve.
+func (inst *InitReserve) SetLiquidityAmount(liquidityAmount uint64) *InitReserve {
+	inst.LiquidityAmount = &liquidityAmount
+	return inst
+}
+
+// SetConfig sets the "config" parameter.
+// Reserve configuration values.
+func (inst *InitReserve) SetConfig(config ReserveConfig) *InitReserve {
+	inst.Config = &config
+	return inst
+}
+
+// SetSourceLiquidityAccount sets the "sourceLiquidity" account.
+// Source liquidity token account; $authority can transfer $liquidity_amount.
+func (inst *InitReserve) SetSourceLiquidityAccount(sourceLiquidity ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(sourceLiquidity).WRITE()
+	return inst
+}
+
+// GetSourceLiquidityAccount gets the "sourceLiquidity" account.
+// Source liquidity token account; $authority can transfer $liquidity_amount.
+func (inst *InitReserve) GetSourceLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetDestinationCollateralAccount sets the "destinationCollateral" account.
+// Destination collateral token account - uninitialized.
+func (inst *InitReserve) SetDestinationCollateralAccount(destinationCollateral ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(destinationCollateral).WRITE()
+	return inst
+}
+
+// GetDestinationCollateralAccount gets the "destinationCollateral" account.
+// Destination collateral token account - uninitialized.
+func (inst *InitReserve) GetDestinationCollateralAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetReserveAccount sets the "reserve" account.
+// Reserve account - uninitialized.
+func (inst *InitReserve) SetReserveAccount(reserve ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(reserve).WRITE()
+	return inst
+}
+
+// GetReserveAccount gets the "reserve" account.
+// Reserve account - uninitialized.
+func (inst *InitReserve) GetReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetReserveLiquidityMintAccount sets the "reserveLiquidityMint" account.
+// Reserve liquidity SPL Token mint.
+func (inst *InitReserve) SetReserveLiquidityMintAccount(reserveLiquidityMint ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(reserveLiquidityMint)
+	return inst
+}
+
+// GetReserveLiquidityMintAccount gets the "reserveLiquidityMint" account.
+// Reserve liquidity SPL Token mint.
+func (inst *InitReserve) GetReserveLiquidityMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetReserveLiquiditySupplyAccount sets the "reserveLiquiditySupply" account.
+// Reserve liquidity supply SPL Token account - uninitialized.
+func (inst *InitReserve) SetReserveLiquiditySupplyAccount(reserveLiquiditySupply ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(reserveLiquiditySupply).WRITE()
+	return inst
+}
+
+// GetReserveLiquiditySupplyAccount gets the "reserveLiquiditySupply" account.
+// Reserve liquidity supply SPL Token account - uninitialized.
+func (inst *InitReserve) GetReserveLiquiditySupplyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetReserveLiquidityFeeReceiverAccount sets the "reserveLiquidityFeeReceiver" account.
+// Reserve liquidity fee receiver - uninitialized.
+func (inst *InitReserve) SetReserveLiquidityFeeReceiverAccount(reserveLiquidityFeeReceiver ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(reserveLiquidityFeeReceiver).WRITE()
+	return inst
+}
+
+// GetReserveLiquidityFeeReceiverAccount gets the "reserveLiquidityFeeReceiver" account.
+// Reserve liquidity fee receiver - uninitialized.
+func (inst *InitReserve) GetReserveLiquidityFeeReceiverAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetReserveCollateralMintAccount sets the "reserveCollateralMint" account.
+// Reserve collateral SPL Token mint - uninitialized.
+func (inst *InitReserve) SetReserveCollateralMintAccount(reserveCollateralMint ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(reserveCollateralMint).WRITE()
+	return inst
+}
+
+// GetReserveCollateralMintAccount gets the "reserveCollateralMint" account.
+// Reserve collateral SPL Token mint - uninitialized.
+func (inst *InitReserve) GetReserveCollateralMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetReserveCollateralSupplyAccount sets the "reserveCollateralSupply" account.
+// Reserve collateral token supply - uninitialized.
+func (inst *InitReserve) SetReserveCollateralSupplyAccount(reserveCollateralSupply ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(reserveCollateralSupply).WRITE()
+	return inst
+}
+
+// GetReserveCollateralSupplyAccount gets the "reserveCollateralSupply" account.
+// Reserve collateral token supply - uninitialized.
+func (inst *InitReserve) GetReserveCollateralSupplyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetPythProductAccount sets the "pythProduct" account.
+// Pyth product account.
+func (inst *InitReserve) SetPythProductAccount(pythProduct ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(pythProduct)
+	return inst
+}
+
+// GetPythProductAccount gets the "pythProduct" account.
+// Pyth product account.
+func (inst *InitReserve) GetPythProductAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetPythPriceAccount sets the "pythPrice" account.
+// Pyth price account; this will be the reserve liquidity oracle account.
+func (inst *InitReserve) SetPythPriceAccount(pythPrice ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(pythPrice)
+	return inst
+}
+
+// GetPythPriceAccount gets the "pythPrice" account.
+// Pyth price account; this will be the reserve liquidity oracle account.
+func (inst *InitReserve) GetPythPriceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *InitReserve) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(lendingMarket)
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *InitReserve) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(10)
+}
+
+// SetLendingMarketAuthorityAccount sets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *InitReserve) SetLendingMarketAuthorityAccount(lendingMarketAuthority ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[11] = ag_solanago.Meta(lendingMarketAuthority)
+	return inst
+}
+
+// GetLendingMarketAuthorityAccount gets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *InitReserve) GetLendingMarketAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(11)
+}
+
+// SetLendingMarketOwnerAccount sets the "lendingMarketOwner" account.
+// Lending market owner.
+func (inst *InitReserve) SetLendingMarketOwnerAccount(lendingMarketOwner ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[12] = ag_solanago.Meta(lendingMarketOwner).SIGNER()
+	return inst
+}
+
+// GetLendingMarketOwnerAccount gets the "lendingMarketOwner" account.
+// Lending market owner.
+func (inst *InitReserve) GetLendingMarketOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(12)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *InitReserve) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[13] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *InitReserve) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(13)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *InitReserve) SetClockAccount(clock ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[14] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *InitReserve) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(14)
+}
+
+// SetRentAccount sets the "rent" account.
+// Rent sysvar.
+func (inst *InitReserve) SetRentAccount(rent ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[15] = ag_solanago.Meta(rent)
+	return inst
+}
+
+// GetRentAccount gets the "rent" account.
+// Rent sysvar.
+func (inst *InitReserve) GetRentAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(15)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *InitReserve) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *InitReserve {
+	inst.AccountMetaSlice[16] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *InitReserve) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(16)
+}
+
+func (inst InitReserve) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_InitReserve),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitReserve) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitReserve) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.LiquidityAmount == nil {
+			return errors.New("LiquidityAmount parameter is not set")
+		}
+		if inst.Config == nil {
+			return errors.New("Config parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.SourceLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.DestinationCollateral is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.Reserve is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.ReserveLiquidityMint is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.ReserveLiquiditySupply is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.ReserveLiquidityFeeReceiver is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.ReserveCollateralMint is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.ReserveCollateralSupply is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.PythProduct is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.PythPrice is not set")
+		}
+		if inst.AccountMetaSlice[10] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[11] == nil {
+			return fmt.Errorf("accounts.LendingMarketAuthority is not set")
+		}
+		if inst.AccountMetaSlice[12] == nil {
+			return fmt.Errorf("accounts.LendingMarketOwner is not set")
+		}
+		if inst.AccountMetaSlice[13] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[14] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+		if inst.AccountMetaSlice[15] == nil {
+			return fmt.Errorf("accounts.Rent is not set")
+		}
+		if inst.AccountMetaSlice[16] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitReserve) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitReserve")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("LiquidityAmount", *inst.LiquidityAmount))
+						paramsBranch.Child(ag_format.Param("         Config", *inst.Config))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("            sourceLiquidity", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("      destinationCollateral", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                    reserve", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("       reserveLiquidityMint", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("     reserveLiquiditySupply", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("reserveLiquidityFeeReceiver", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("      reserveCollateralMint", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("    reserveCollateralSupply", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("                pythProduct", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("                  pythPrice", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("              lendingMarket", inst.AccountMetaSlice.Get(10)))
+						accountsBranch.Child(ag_format.Meta("     lendingMarketAuthority", inst.AccountMetaSlice.Get(11)))
+						accountsBranch.Child(ag_format.Meta("         lendingMarketOwner", inst.AccountMetaSlice.Get(12)))
+						accountsBranch.Child(ag_format.Meta("      userTransferAuthority", inst.AccountMetaSlice.Get(13)))
+						accountsBranch.Child(ag_format.Meta("                      clock", inst.AccountMetaSlice.Get(14)))
+						accountsBranch.Child(ag_format.Meta("                       rent", inst.AccountMetaSlice.Get(15)))
+						accountsBranch.Child(ag_format.Meta("               tokenProgram", inst.AccountMetaSlice.Get(16)))
+					})
+				})
+		})
+}
+
+func (obj InitReserve) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `LiquidityAmount` param:
+	err = encoder.Encode(obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `Config` param:
+	err = encoder.Encode(obj.Config)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitReserve) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `LiquidityAmount`:
+	err = decoder.Decode(&obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Config`:
+	err = decoder.Decode(&obj.Config)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitReserveInstruction declares a new InitReserve instruction with the provided parameters and accounts.
+func NewInitReserveInstruction(
+	// Parameters:
+	liquidityAmount uint64,
+	config ReserveConfig,
+	// Accounts:
+	sourceLiquidity ag_solanago.PublicKey,
+	destinationCollateral ag_solanago.PublicKey,
+	reserve ag_solanago.PublicKey,
+	reserveLiquidityMint ag_solanago.PublicKey,
+	reserveLiquiditySupply ag_solanago.PublicKey,
+	reserveLiquidityFeeReceiver ag_solanago.PublicKey,
+	reserveCollateralMint ag_solanago.PublicKey,
+	reserveCollateralSupply ag_solanago.PublicKey,
+	pythProduct ag_solanago.PublicKey,
+	pythPrice ag_solanago.PublicKey,
+	lendingMarket ag_solanago.PublicKey,
+	lendingMarketAuthority ag_solanago.PublicKey,
+	lendingMarketOwner ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+) *InitReserve {
+	return NewInitReserveInstructionBuilder().
+		SetLiquidityAmount(liquidityAmount).
+		SetConfig(config).
+		SetSourceLiquidityAccount(sourceLiquidity).
+		SetDestinationCollateralAccount(destinationCollateral).
+		SetReserveAccount(reserve).
+		SetReserveLiquidityMintAccount(reserveLiquidityMint).
+		SetReserveLiquiditySupplyAccount(reserveLiquiditySupply).
+		SetReserveLiquidityFeeReceiverAccount(reserveLiquidityFeeReceiver).
+		SetReserveCollateralMintAccount(reserveCollateralMint).
+		SetReserveCollateralSupplyAccount(reserveCollateralSupply).
+		SetPythProductAccount(pythProduct).
+		SetPythPriceAccount(pythPrice).
+		SetLendingMarketAccount(lendingMarket).
+		SetLendingMarketAuthorityAccount(lendingMarketAuthority).
+		SetLendingMarketOwnerAccount(lendingMarketOwner).
+		SetUserTransferAuthorityAccount(userTransferAuthority)
+}
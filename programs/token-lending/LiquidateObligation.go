@@ -0,0 +1,388 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Repay borrowed liquidity to a reserve to receive collateral at a discount from an unhealthy
+// obligation. Requires a refreshed obligation and reserves.
+type LiquidateObligation struct {
+	// Amount of liquidity to repay - u64::MAX for up to 100% of borrowed amount.
+	LiquidityAmount *uint64
+
+	// [0] = [WRITE] sourceLiquidity
+	// ··········· Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+	//
+	// [1] = [WRITE] destinationCollateral
+	// ··········· Destination collateral token account; minted by withdraw reserve collateral mint.
+	//
+	// [2] = [WRITE] repayReserve
+	// ··········· Repay reserve account - refreshed.
+	//
+	// [3] = [WRITE] repayReserveLiquiditySupply
+	// ··········· Repay reserve liquidity supply SPL Token account.
+	//
+	// [4] = [] withdrawReserve
+	// ··········· Withdraw reserve account - refreshed.
+	//
+	// [5] = [WRITE] withdrawReserveCollateralSupply
+	// ··········· Withdraw reserve collateral supply SPL Token account.
+	//
+	// [6] = [WRITE] obligation
+	// ··········· Obligation account - refreshed.
+	//
+	// [7] = [] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [8] = [] lendingMarketAuthority
+	// ··········· Derived lending market authority.
+	//
+	// [9] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority ($authority).
+	//
+	// [10] = [] clock
+	// ··········· Clock sysvar.
+	//
+	// [11] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewLiquidateObligationInstructionBuilder creates a new `LiquidateObligation` instruction builder.
+func NewLiquidateObligationInstructionBuilder() *LiquidateObligation {
+	nd := &LiquidateObligation{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 12),
+	}
+	nd.AccountMetaSlice[10] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[11] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetLiquidityAmount sets the "liquidityAmount" parameter.
+// Amount of liquidity to repay - u64::MAX for up to 100% of borrowed amount.
+func (inst *LiquidateObligation) SetLiquidityAmount(liquidityAmount uint64) *LiquidateObligation {
+	inst.LiquidityAmount = &liquidityAmount
+	return inst
+}
+
+// SetSourceLiquidityAccount sets the "sourceLiquidity" account.
+// Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+func (inst *LiquidateObligation) SetSourceLiquidityAccount(sourceLiquidity ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(sourceLiquidity).WRITE()
+	return inst
+}
+
+// GetSourceLiquidityAccount gets the "sourceLiquidity" account.
+// Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+func (inst *LiquidateObligation) GetSourceLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetDestinationCollateralAccount sets the "destinationCollateral" account.
+// Destination collateral token account; minted by withdraw reserve collateral mint.
+func (inst *LiquidateObligation) SetDestinationCollateralAccount(destinationCollateral ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(destinationCollateral).WRITE()
+	return inst
+}
+
+// GetDestinationCollateralAccount gets the "destinationCollateral" account.
+// Destination collateral token account; minted by withdraw reserve collateral mint.
+func (inst *LiquidateObligation) GetDestinationCollateralAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetRepayReserveAccount sets the "repayReserve" account.
+// Repay reserve account - refreshed.
+func (inst *LiquidateObligation) SetRepayReserveAccount(repayReserve ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(repayReserve).WRITE()
+	return inst
+}
+
+// GetRepayReserveAccount gets the "repayReserve" account.
+// Repay reserve account - refreshed.
+func (inst *LiquidateObligation) GetRepayReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetRepayReserveLiquiditySupplyAccount sets the "repayReserveLiquiditySupply" account.
+// Repay reserve liquidity supply SPL Token account.
+func (inst *LiquidateObligation) SetRepayReserveLiquiditySupplyAccount(repayReserveLiquiditySupply ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(repayReserveLiquiditySupply).WRITE()
+	return inst
+}
+
+// GetRepayReserveLiquiditySupplyAccount gets the "repayReserveLiquiditySupply" account.
+// Repay reserve liquidity supply SPL Token account.
+func (inst *LiquidateObligation) GetRepayReserveLiquiditySupplyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetWithdrawReserveAccount sets the "withdrawReserve" account.
+// Withdraw reserve account - refreshed.
+func (inst *LiquidateObligation) SetWithdrawReserveAccount(withdrawReserve ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(withdrawReserve)
+	return inst
+}
+
+// GetWithdrawReserveAccount gets the "withdrawReserve" account.
+// Withdraw reserve account - refreshed.
+func (inst *LiquidateObligation) GetWithdrawReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetWithdrawReserveCollateralSupplyAccount sets the "withdrawReserveCollateralSupply" account.
+// Withdraw reserve collateral supply SPL Token account.
+func (inst *LiquidateObligation) SetWithdrawReserveCollateralSupplyAccount(withdrawReserveCollateralSupply ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(withdrawReserveCollateralSupply).WRITE()
+	return inst
+}
+
+// GetWithdrawReserveCollateralSupplyAccount gets the "withdrawReserveCollateralSupply" account.
+// Withdraw reserve collateral supply SPL Token account.
+func (inst *LiquidateObligation) GetWithdrawReserveCollateralSupplyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetObligationAccount sets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *LiquidateObligation) SetObligationAccount(obligation ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(obligation).WRITE()
+	return inst
+}
+
+// GetObligationAccount gets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *LiquidateObligation) GetObligationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *LiquidateObligation) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(lendingMarket)
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *LiquidateObligation) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetLendingMarketAuthorityAccount sets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *LiquidateObligation) SetLendingMarketAuthorityAccount(lendingMarketAuthority ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(lendingMarketAuthority)
+	return inst
+}
+
+// GetLendingMarketAuthorityAccount gets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *LiquidateObligation) GetLendingMarketAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *LiquidateObligation) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *LiquidateObligation) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *LiquidateObligation) SetClockAccount(clock ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *LiquidateObligation) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(10)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *LiquidateObligation) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *LiquidateObligation {
+	inst.AccountMetaSlice[11] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *LiquidateObligation) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(11)
+}
+
+func (inst LiquidateObligation) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_LiquidateObligation),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst LiquidateObligation) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *LiquidateObligation) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.LiquidityAmount == nil {
+			return errors.New("LiquidityAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.SourceLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.DestinationCollateral is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.RepayReserve is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.RepayReserveLiquiditySupply is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.WithdrawReserve is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.WithdrawReserveCollateralSupply is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.Obligation is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.LendingMarketAuthority is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[10] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+		if inst.AccountMetaSlice[11] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *LiquidateObligation) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("LiquidateObligation")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("LiquidityAmount", *inst.LiquidityAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                sourceLiquidity", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("          destinationCollateral", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                   repayReserve", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("    repayReserveLiquiditySupply", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("                withdrawReserve", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("withdrawReserveCollateralSupply", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("                     obligation", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("                  lendingMarket", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("         lendingMarketAuthority", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("          userTransferAuthority", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("                          clock", inst.AccountMetaSlice.Get(10)))
+						accountsBranch.Child(ag_format.Meta("                   tokenProgram", inst.AccountMetaSlice.Get(11)))
+					})
+				})
+		})
+}
+
+func (obj LiquidateObligation) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `LiquidityAmount` param:
+	err = encoder.Encode(obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *LiquidateObligation) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `LiquidityAmount`:
+	err = decoder.Decode(&obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewLiquidateObligationInstruction declares a new LiquidateObligation instruction with the provided parameters and accounts.
+func NewLiquidateObligationInstruction(
+	// Parameters:
+	liquidityAmount uint64,
+	// Accounts:
+	sourceLiquidity ag_solanago.PublicKey,
+	destinationCollateral ag_solanago.PublicKey,
+	repayReserve ag_solanago.PublicKey,
+	repayReserveLiquiditySupply ag_solanago.PublicKey,
+	withdrawReserve ag_solanago.PublicKey,
+	withdrawReserveCollateralSupply ag_solanago.PublicKey,
+	obligation ag_solanago.PublicKey,
+	lendingMarket ag_solanago.PublicKey,
+	lendingMarketAuthority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+) *LiquidateObligation {
+	return NewLiquidateObligationInstructionBuilder().
+		SetLiquidityAmount(liquidityAmount).
+		SetSourceLiquidityAccount(sourceLiquidity).
+		SetDestinationCollateralAccount(destinationCollateral).
+		SetRepayReserveAccount(repayReserve).
+		SetRepayReserveLiquiditySupplyAccount(repayReserveLiquiditySupply).
+		SetWithdrawReserveAccount(withdrawReserve).
+		SetWithdrawReserveCollateralSupplyAccount(withdrawReserveCollateralSupply).
+		SetObligationAccount(obligation).
+		SetLendingMarketAccount(lendingMarket).
+		SetLendingMarketAuthorityAccount(lendingMarketAuthority).
+		SetUserTransferAuthorityAccount(userTransferAuthority)
+}
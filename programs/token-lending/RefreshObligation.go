@@ -0,0 +1,165 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Refresh an obligation's accrued interest and collateral and liquidity prices. Requires
+// refreshed reserves, as all obligation collateral deposit reserves in order, followed by all
+// liquidity borrow reserves in order.
+type RefreshObligation struct {
+	// [0] = [WRITE] obligation
+	// ··········· Obligation account.
+	//
+	// [1] = [] clock
+	// ··········· Clock sysvar.
+	//
+	// [2...] = [] reserves
+	// ··········· Collateral deposit reserve accounts followed by liquidity borrow reserve accounts; all refreshed, in order.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRefreshObligationInstructionBuilder creates a new `RefreshObligation` instruction builder.
+func NewRefreshObligationInstructionBuilder() *RefreshObligation {
+	nd := &RefreshObligation{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	return nd
+}
+
+// SetObligationAccount sets the "obligation" account.
+// Obligation account.
+func (inst *RefreshObligation) SetObligationAccount(obligation ag_solanago.PublicKey) *RefreshObligation {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(obligation).WRITE()
+	return inst
+}
+
+// GetObligationAccount gets the "obligation" account.
+// Obligation account.
+func (inst *RefreshObligation) GetObligationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *RefreshObligation) SetClockAccount(clock ag_solanago.PublicKey) *RefreshObligation {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *RefreshObligation) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// AppendReserveAccount appends an account to the "reserves" accounts.
+// Collateral deposit reserve accounts followed by liquidity borrow reserve accounts; all refreshed, in order.
+func (inst *RefreshObligation) AppendReserveAccount(reserve ag_solanago.PublicKey) *RefreshObligation {
+	inst.AccountMetaSlice.Append(ag_solanago.Meta(reserve))
+	return inst
+}
+
+// GetReservesAccounts gets the "reserves" accounts.
+// Collateral deposit reserve accounts followed by liquidity borrow reserve accounts; all refreshed, in order.
+func (inst *RefreshObligation) GetReservesAccounts() ag_solanago.AccountMetaSlice {
+	_, reserves := inst.AccountMetaSlice.SplitFrom(2)
+	return reserves
+}
+
+func (inst RefreshObligation) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_RefreshObligation),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RefreshObligation) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RefreshObligation) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Obligation is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *RefreshObligation) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("RefreshObligation")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("obligation", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("     clock", inst.AccountMetaSlice.Get(1)))
+
+						reserves := inst.GetReservesAccounts()
+						reservesBranch := accountsBranch.Child(fmt.Sprintf("reserves[len=%v]", len(reserves)))
+						for i, v := range reserves {
+							reservesBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj RefreshObligation) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *RefreshObligation) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewRefreshObligationInstruction declares a new RefreshObligation instruction with the provided parameters and accounts.
+func NewRefreshObligationInstruction(
+	// Accounts:
+	obligation ag_solanago.PublicKey,
+	reserves []ag_solanago.PublicKey,
+) *RefreshObligation {
+	inst := NewRefreshObligationInstructionBuilder().
+		SetObligationAccount(obligation)
+	for _, reserve := range reserves {
+		inst.AppendReserveAccount(reserve)
+	}
+	return inst
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Accrue interest and update market price of liquidity on a reserve.
+type RefreshReserve struct {
+	// [0] = [WRITE] reserve
+	// ··········· Reserve account.
+	//
+	// [1] = [] reserveLiquidityOracle
+	// ··········· Reserve liquidity oracle account; must be the Pyth price account specified at InitReserve.
+	//
+	// [2] = [] clock
+	// ··········· Clock sysvar.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRefreshReserveInstructionBuilder creates a new `RefreshReserve` instruction builder.
+func NewRefreshReserveInstructionBuilder() *RefreshReserve {
+	nd := &RefreshReserve{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	return nd
+}
+
+// SetReserveAccount sets the "reserve" account.
+// Reserve account.
+func (inst *RefreshReserve) SetReserveAccount(reserve ag_solanago.PublicKey) *RefreshReserve {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(reserve).WRITE()
+	return inst
+}
+
+// GetReserveAccount gets the "reserve" account.
+// Reserve account.
+func (inst *RefreshReserve) GetReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetReserveLiquidityOracleAccount sets the "reserveLiquidityOracle" account.
+// Reserve liquidity oracle account; must be the Pyth price account specified at InitReserve.
+func (inst *RefreshReserve) SetReserveLiquidityOracleAccount(reserveLiquidityOracle ag_solanago.PublicKey) *RefreshReserve {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(reserveLiquidityOracle)
+	return inst
+}
+
+// GetReserveLiquidityOracleAccount gets the "reserveLiquidityOracle" account.
+// Reserve liquidity oracle account; must be the Pyth price account specified at InitReserve.
+func (inst *RefreshReserve) GetReserveLiquidityOracleAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *RefreshReserve) SetClockAccount(clock ag_solanago.PublicKey) *RefreshReserve {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *RefreshReserve) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+func (inst RefreshReserve) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_RefreshReserve),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RefreshReserve) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RefreshReserve) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Reserve is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.ReserveLiquidityOracle is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *RefreshReserve) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("RefreshReserve")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("               reserve", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("reserveLiquidityOracle", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                 clock", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (obj RefreshReserve) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *RefreshReserve) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewRefreshReserveInstruction declares a new RefreshReserve instruction with the provided parameters and accounts.
+func NewRefreshReserveInstruction(
+	// Accounts:
+	reserve ag_solanago.PublicKey,
+	reserveLiquidityOracle ag_solanago.PublicKey,
+) *RefreshReserve {
+	return NewRefreshReserveInstructionBuilder().
+		SetReserveAccount(reserve).
+		SetReserveLiquidityOracleAccount(reserveLiquidityOracle)
+}
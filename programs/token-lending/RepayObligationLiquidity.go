@@ -0,0 +1,299 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Repay borrowed liquidity to a reserve. Requires a refreshed obligation and reserve.
+type RepayObligationLiquidity struct {
+	// Amount of liquidity to repay - u64::MAX for 100% of borrowed amount.
+	LiquidityAmount *uint64
+
+	// [0] = [WRITE] sourceLiquidity
+	// ··········· Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+	//
+	// [1] = [WRITE] destinationLiquidity
+	// ··········· Destination repay reserve liquidity supply SPL Token account.
+	//
+	// [2] = [WRITE] repayReserve
+	// ··········· Repay reserve account - refreshed.
+	//
+	// [3] = [WRITE] obligation
+	// ··········· Obligation account - refreshed.
+	//
+	// [4] = [] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [5] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority ($authority).
+	//
+	// [6] = [] clock
+	// ··········· Clock sysvar.
+	//
+	// [7] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRepayObligationLiquidityInstructionBuilder creates a new `RepayObligationLiquidity` instruction builder.
+func NewRepayObligationLiquidityInstructionBuilder() *RepayObligationLiquidity {
+	nd := &RepayObligationLiquidity{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 8),
+	}
+	nd.AccountMetaSlice[6] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetLiquidityAmount sets the "liquidityAmount" parameter.
+// Amount of liquidity to repay - u64::MAX for 100% of borrowed amount.
+func (inst *RepayObligationLiquidity) SetLiquidityAmount(liquidityAmount uint64) *RepayObligationLiquidity {
+	inst.LiquidityAmount = &liquidityAmount
+	return inst
+}
+
+// SetSourceLiquidityAccount sets the "sourceLiquidity" account.
+// Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+func (inst *RepayObligationLiquidity) SetSourceLiquidityAccount(sourceLiquidity ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(sourceLiquidity).WRITE()
+	return inst
+}
+
+// GetSourceLiquidityAccount gets the "sourceLiquidity" account.
+// Source liquidity token account; minted by repay reserve liquidity mint; $authority can transfer $liquidity_amount.
+func (inst *RepayObligationLiquidity) GetSourceLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetDestinationLiquidityAccount sets the "destinationLiquidity" account.
+// Destination repay reserve liquidity supply SPL Token account.
+func (inst *RepayObligationLiquidity) SetDestinationLiquidityAccount(destinationLiquidity ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(destinationLiquidity).WRITE()
+	return inst
+}
+
+// GetDestinationLiquidityAccount gets the "destinationLiquidity" account.
+// Destination repay reserve liquidity supply SPL Token account.
+func (inst *RepayObligationLiquidity) GetDestinationLiquidityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetRepayReserveAccount sets the "repayReserve" account.
+// Repay reserve account - refreshed.
+func (inst *RepayObligationLiquidity) SetRepayReserveAccount(repayReserve ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(repayReserve).WRITE()
+	return inst
+}
+
+// GetRepayReserveAccount gets the "repayReserve" account.
+// Repay reserve account - refreshed.
+func (inst *RepayObligationLiquidity) GetRepayReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetObligationAccount sets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *RepayObligationLiquidity) SetObligationAccount(obligation ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(obligation).WRITE()
+	return inst
+}
+
+// GetObligationAccount gets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *RepayObligationLiquidity) GetObligationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *RepayObligationLiquidity) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(lendingMarket)
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *RepayObligationLiquidity) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *RepayObligationLiquidity) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority ($authority).
+func (inst *RepayObligationLiquidity) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *RepayObligationLiquidity) SetClockAccount(clock ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *RepayObligationLiquidity) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *RepayObligationLiquidity) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *RepayObligationLiquidity {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *RepayObligationLiquidity) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+func (inst RepayObligationLiquidity) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_RepayObligationLiquidity),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RepayObligationLiquidity) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RepayObligationLiquidity) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.LiquidityAmount == nil {
+			return errors.New("LiquidityAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.SourceLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.DestinationLiquidity is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.RepayReserve is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.Obligation is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *RepayObligationLiquidity) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("RepayObligationLiquidity")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("LiquidityAmount", *inst.LiquidityAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("      sourceLiquidity", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta(" destinationLiquidity", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("         repayReserve", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("           obligation", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("        lendingMarket", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("                clock", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(7)))
+					})
+				})
+		})
+}
+
+func (obj RepayObligationLiquidity) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `LiquidityAmount` param:
+	err = encoder.Encode(obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *RepayObligationLiquidity) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `LiquidityAmount`:
+	err = decoder.Decode(&obj.LiquidityAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewRepayObligationLiquidityInstruction declares a new RepayObligationLiquidity instruction with the provided parameters and accounts.
+func NewRepayObligationLiquidityInstruction(
+	// Parameters:
+	liquidityAmount uint64,
+	// Accounts:
+	sourceLiquidity ag_solanago.PublicKey,
+	destinationLiquidity ag_solanago.PublicKey,
+	repayReserve ag_solanago.PublicKey,
+	obligation ag_solanago.PublicKey,
+	lendingMarket ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+) *RepayObligationLiquidity {
+	return NewRepayObligationLiquidityInstructionBuilder().
+		SetLiquidityAmount(liquidityAmount).
+		SetSourceLiquidityAccount(sourceLiquidity).
+		SetDestinationLiquidityAccount(destinationLiquidity).
+		SetRepayReserveAccount(repayReserve).
+		SetObligationAccount(obligation).
+		SetLendingMarketAccount(lendingMarket).
+		SetUserTransferAuthorityAccount(userTransferAuthority)
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Sets the new owner of a lending market.
+type SetLendingMarketOwner struct {
+	// The new owner.
+	NewOwner *ag_solanago.PublicKey
+
+	// [0] = [WRITE] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [1] = [SIGNER] owner
+	// ··········· Current owner.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetLendingMarketOwnerInstructionBuilder creates a new `SetLendingMarketOwner` instruction builder.
+func NewSetLendingMarketOwnerInstructionBuilder() *SetLendingMarketOwner {
+	nd := &SetLendingMarketOwner{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetNewOwner sets the "newOwner" parameter.
+// The new owner.
+func (inst *SetLendingMarketOwner) SetNewOwner(newOwner ag_solanago.PublicKey) *SetLendingMarketOwner {
+	inst.NewOwner = &newOwner
+	return inst
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *SetLendingMarketOwner) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *SetLendingMarketOwner {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(lendingMarket).WRITE()
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *SetLendingMarketOwner) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetOwnerAccount sets the "owner" account.
+// Current owner.
+func (inst *SetLendingMarketOwner) SetOwnerAccount(owner ag_solanago.PublicKey) *SetLendingMarketOwner {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(owner).SIGNER()
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// Current owner.
+func (inst *SetLendingMarketOwner) GetOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+func (inst SetLendingMarketOwner) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_SetLendingMarketOwner),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetLendingMarketOwner) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetLendingMarketOwner) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.NewOwner == nil {
+			return errors.New("NewOwner parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Owner is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *SetLendingMarketOwner) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("SetLendingMarketOwner")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("NewOwner", *inst.NewOwner))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("lendingMarket", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("        owner", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (obj SetLendingMarketOwner) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `NewOwner` param:
+	err = encoder.Encode(obj.NewOwner)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *SetLendingMarketOwner) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `NewOwner`:
+	err = decoder.Decode(&obj.NewOwner)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewSetLendingMarketOwnerInstruction declares a new SetLendingMarketOwner instruction with the provided parameters and accounts.
+func NewSetLendingMarketOwnerInstruction(
+	// Parameters:
+	newOwner ag_solanago.PublicKey,
+	// Accounts:
+	lendingMarket ag_solanago.PublicKey,
+	owner ag_solanago.PublicKey,
+) *SetLendingMarketOwner {
+	return NewSetLendingMarketOwnerInstructionBuilder().
+		SetNewOwner(newOwner).
+		SetLendingMarketAccount(lendingMarket).
+		SetOwnerAccount(owner)
+}
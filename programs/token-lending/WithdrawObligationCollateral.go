@@ -0,0 +1,321 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Withdraw collateral from an obligation. Requires a refreshed obligation and reserve.
+type WithdrawObligationCollateral struct {
+	// Amount of collateral tokens to withdraw - u64::MAX for up to 100% of deposited amount.
+	CollateralAmount *uint64
+
+	// [0] = [WRITE] sourceCollateral
+	// ··········· Source withdraw reserve collateral supply SPL Token account.
+	//
+	// [1] = [WRITE] destinationCollateral
+	// ··········· Destination collateral token account; minted by withdraw reserve collateral mint.
+	//
+	// [2] = [] withdrawReserve
+	// ··········· Withdraw reserve account - refreshed.
+	//
+	// [3] = [WRITE] obligation
+	// ··········· Obligation account - refreshed.
+	//
+	// [4] = [] lendingMarket
+	// ··········· Lending market account.
+	//
+	// [5] = [] lendingMarketAuthority
+	// ··········· Derived lending market authority.
+	//
+	// [6] = [SIGNER] obligationOwner
+	// ··········· Obligation owner.
+	//
+	// [7] = [] clock
+	// ··········· Clock sysvar.
+	//
+	// [8] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWithdrawObligationCollateralInstructionBuilder creates a new `WithdrawObligationCollateral` instruction builder.
+func NewWithdrawObligationCollateralInstructionBuilder() *WithdrawObligationCollateral {
+	nd := &WithdrawObligationCollateral{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 9),
+	}
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[8] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetCollateralAmount sets the "collateralAmount" parameter.
+// Amount of collateral tokens to withdraw - u64::MAX for up to 100% of deposited amount.
+func (inst *WithdrawObligationCollateral) SetCollateralAmount(collateralAmount uint64) *WithdrawObligationCollateral {
+	inst.CollateralAmount = &collateralAmount
+	return inst
+}
+
+// SetSourceCollateralAccount sets the "sourceCollateral" account.
+// Source withdraw reserve collateral supply SPL Token account.
+func (inst *WithdrawObligationCollateral) SetSourceCollateralAccount(sourceCollateral ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(sourceCollateral).WRITE()
+	return inst
+}
+
+// GetSourceCollateralAccount gets the "sourceCollateral" account.
+// Source withdraw reserve collateral supply SPL Token account.
+func (inst *WithdrawObligationCollateral) GetSourceCollateralAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetDestinationCollateralAccount sets the "destinationCollateral" account.
+// Destination collateral token account; minted by withdraw reserve collateral mint.
+func (inst *WithdrawObligationCollateral) SetDestinationCollateralAccount(destinationCollateral ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(destinationCollateral).WRITE()
+	return inst
+}
+
+// GetDestinationCollateralAccount gets the "destinationCollateral" account.
+// Destination collateral token account; minted by withdraw reserve collateral mint.
+func (inst *WithdrawObligationCollateral) GetDestinationCollateralAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetWithdrawReserveAccount sets the "withdrawReserve" account.
+// Withdraw reserve account - refreshed.
+func (inst *WithdrawObligationCollateral) SetWithdrawReserveAccount(withdrawReserve ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(withdrawReserve)
+	return inst
+}
+
+// GetWithdrawReserveAccount gets the "withdrawReserve" account.
+// Withdraw reserve account - refreshed.
+func (inst *WithdrawObligationCollateral) GetWithdrawReserveAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetObligationAccount sets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *WithdrawObligationCollateral) SetObligationAccount(obligation ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(obligation).WRITE()
+	return inst
+}
+
+// GetObligationAccount gets the "obligation" account.
+// Obligation account - refreshed.
+func (inst *WithdrawObligationCollateral) GetObligationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetLendingMarketAccount sets the "lendingMarket" account.
+// Lending market account.
+func (inst *WithdrawObligationCollateral) SetLendingMarketAccount(lendingMarket ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(lendingMarket)
+	return inst
+}
+
+// GetLendingMarketAccount gets the "lendingMarket" account.
+// Lending market account.
+func (inst *WithdrawObligationCollateral) GetLendingMarketAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetLendingMarketAuthorityAccount sets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *WithdrawObligationCollateral) SetLendingMarketAuthorityAccount(lendingMarketAuthority ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(lendingMarketAuthority)
+	return inst
+}
+
+// GetLendingMarketAuthorityAccount gets the "lendingMarketAuthority" account.
+// Derived lending market authority.
+func (inst *WithdrawObligationCollateral) GetLendingMarketAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetObligationOwnerAccount sets the "obligationOwner" account.
+// Obligation owner.
+func (inst *WithdrawObligationCollateral) SetObligationOwnerAccount(obligationOwner ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(obligationOwner).SIGNER()
+	return inst
+}
+
+// GetObligationOwnerAccount gets the "obligationOwner" account.
+// Obligation owner.
+func (inst *WithdrawObligationCollateral) GetObligationOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetClockAccount sets the "clock" account.
+// Clock sysvar.
+func (inst *WithdrawObligationCollateral) SetClockAccount(clock ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(clock)
+	return inst
+}
+
+// GetClockAccount gets the "clock" account.
+// Clock sysvar.
+func (inst *WithdrawObligationCollateral) GetClockAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawObligationCollateral) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *WithdrawObligationCollateral {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawObligationCollateral) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+func (inst WithdrawObligationCollateral) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_WithdrawObligationCollateral),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst WithdrawObligationCollateral) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *WithdrawObligationCollateral) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.CollateralAmount == nil {
+			return errors.New("CollateralAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.SourceCollateral is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.DestinationCollateral is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.WithdrawReserve is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.Obligation is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.LendingMarket is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.LendingMarketAuthority is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.ObligationOwner is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.Clock is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *WithdrawObligationCollateral) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("WithdrawObligationCollateral")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("CollateralAmount", *inst.CollateralAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("      sourceCollateral", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta(" destinationCollateral", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("       withdrawReserve", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("            obligation", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("         lendingMarket", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("lendingMarketAuthority", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("       obligationOwner", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("                 clock", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("          tokenProgram", inst.AccountMetaSlice.Get(8)))
+					})
+				})
+		})
+}
+
+func (obj WithdrawObligationCollateral) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `CollateralAmount` param:
+	err = encoder.Encode(obj.CollateralAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *WithdrawObligationCollateral) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `CollateralAmount`:
+	err = decoder.Decode(&obj.CollateralAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewWithdrawObligationCollateralInstruction declares a new WithdrawObligationCollateral instruction with the provided parameters and accounts.
+func NewWithdrawObligationCollateralInstruction(
+	// Parameters:
+	collateralAmount uint64,
+	// Accounts:
+	sourceCollateral ag_solanago.PublicKey,
+	destinationCollateral ag_solanago.PublicKey,
+	withdrawReserve ag_solanago.PublicKey,
+	obligation ag_solanago.PublicKey,
+	lendingMarket ag_solanago.PublicKey,
+	lendingMarketAuthority ag_solanago.PublicKey,
+	obligationOwner ag_solanago.PublicKey,
+) *WithdrawObligationCollateral {
+	return NewWithdrawObligationCollateralInstructionBuilder().
+		SetCollateralAmount(collateralAmount).
+		SetSourceCollateralAccount(sourceCollateral).
+		SetDestinationCollateralAccount(destinationCollateral).
+		SetWithdrawReserveAccount(withdrawReserve).
+		SetObligationAccount(obligation).
+		SetLendingMarketAccount(lendingMarket).
+		SetLendingMarketAuthorityAccount(lendingMarketAuthority).
+		SetObligationOwnerAccount(obligationOwner)
+}
@@ -0,0 +1,244 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The SPL Token Lending program is a lending protocol: lenders deposit
+// liquidity into reserves, and borrowers take loans against collateral.
+
+package tokenlending
+
+import (
+	"bytes"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_text "github.com/xmcontinue/solana-go/text"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.TokenLendingProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+}
+
+const ProgramName = "TokenLending"
+
+func init() {
+	if !ProgramID.IsZero() {
+		ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	}
+}
+
+const (
+	// Initializes a new lending market.
+	Instruction_InitLendingMarket uint8 = iota
+
+	// Sets the new owner of a lending market.
+	Instruction_SetLendingMarketOwner
+
+	// Initializes a new lending market reserve.
+	Instruction_InitReserve
+
+	// Accrue interest and update market price of liquidity on a reserve.
+	Instruction_RefreshReserve
+
+	// Deposit liquidity into a reserve in exchange for collateral. Collateral represents a share
+	// of the reserve liquidity pool.
+	Instruction_DepositReserveLiquidity
+
+	// Redeem collateral from a reserve in exchange for liquidity.
+	Instruction_RedeemReserveCollateral
+
+	// Initializes a new lending market obligation.
+	Instruction_InitObligation
+
+	// Refresh an obligation's accrued interest and collateral and liquidity prices. Requires
+	// refreshed reserves, as all obligation collateral deposit reserves in order, followed by all
+	// liquidity borrow reserves in order.
+	Instruction_RefreshObligation
+
+	// Deposit collateral to an obligation. Requires a refreshed reserve.
+	Instruction_DepositObligationCollateral
+
+	// Withdraw collateral from an obligation. Requires a refreshed obligation and reserve.
+	Instruction_WithdrawObligationCollateral
+
+	// Borrow liquidity from a reserve by depositing collateral tokens. Requires a refreshed
+	// obligation and reserve.
+	Instruction_BorrowObligationLiquidity
+
+	// Repay borrowed liquidity to a reserve. Requires a refreshed obligation and reserve.
+	Instruction_RepayObligationLiquidity
+
+	// Repay borrowed liquidity to a reserve to receive collateral at a discount from an unhealthy
+	// obligation. Requires a refreshed obligation and reserves.
+	Instruction_LiquidateObligation
+
+	// Make a flash loan.
+	Instruction_FlashLoan
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_InitLendingMarket:
+		return "InitLendingMarket"
+	case Instruction_SetLendingMarketOwner:
+		return "SetLendingMarketOwner"
+	case Instruction_InitReserve:
+		return "InitReserve"
+	case Instruction_RefreshReserve:
+		return "RefreshReserve"
+	case Instruction_DepositReserveLiquidity:
+		return "DepositReserveLiquidity"
+	case Instruction_RedeemReserveCollateral:
+		return "RedeemReserveCollateral"
+	case Instruction_InitObligation:
+		return "InitObligation"
+	case Instruction_RefreshObligation:
+		return "RefreshObligation"
+	case Instruction_DepositObligationCollateral:
+		return "DepositObligationCollateral"
+	case Instruction_WithdrawObligationCollateral:
+		return "WithdrawObligationCollateral"
+	case Instruction_BorrowObligationLiquidity:
+		return "BorrowObligationLiquidity"
+	case Instruction_RepayObligationLiquidity:
+		return "RepayObligationLiquidity"
+	case Instruction_LiquidateObligation:
+		return "LiquidateObligation"
+	case Instruction_FlashLoan:
+		return "FlashLoan"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+var InstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			Name: "InitLendingMarket", Type: (*InitLendingMarket)(nil),
+		},
+		{
+			Name: "SetLendingMarketOwner", Type: (*SetLendingMarketOwner)(nil),
+		},
+		{
+			Name: "InitReserve", Type: (*InitReserve)(nil),
+		},
+		{
+			Name: "RefreshReserve", Type: (*RefreshReserve)(nil),
+		},
+		{
+			Name: "DepositReserveLiquidity", Type: (*DepositReserveLiquidity)(nil),
+		},
+		{
+			Name: "RedeemReserveCollateral", Type: (*RedeemReserveCollateral)(nil),
+		},
+		{
+			Name: "InitObligation", Type: (*InitObligation)(nil),
+		},
+		{
+			Name: "RefreshObligation", Type: (*RefreshObligation)(nil),
+		},
+		{
+			Name: "DepositObligationCollateral", Type: (*DepositObligationCollateral)(nil),
+		},
+		{
+			Name: "WithdrawObligationCollateral", Type: (*WithdrawObligationCollateral)(nil),
+		},
+		{
+			Name: "BorrowObligationLiquidity", Type: (*BorrowObligationLiquidity)(nil),
+		},
+		{
+			Name: "RepayObligationLiquidity", Type: (*RepayObligationLiquidity)(nil),
+		},
+		{
+			Name: "LiquidateObligation", Type: (*LiquidateObligation)(nil),
+		},
+		{
+			Name: "FlashLoan", Type: (*FlashLoan)(nil),
+		},
+	},
+)
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
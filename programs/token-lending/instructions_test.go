@@ -0,0 +1,103 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+func TestRefreshObligation_Accounts(t *testing.T) {
+	obligation := ag_solanago.NewWallet().PublicKey()
+	reserves := ag_solanago.PublicKeySlice{
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+	}
+	inst, err := NewRefreshObligationInstruction(obligation, reserves).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_RefreshObligation}, data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 4)
+	require.Equal(t, ag_solanago.Meta(obligation).WRITE(), accounts[0])
+	require.Equal(t, ag_solanago.Meta(ag_solanago.SysVarClockPubkey), accounts[1])
+	require.Equal(t, reserves, ag_solanago.AccountMetaSlice(accounts[2:]).GetKeys())
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	require.Equal(t, reserves, decoded.Impl.(*RefreshObligation).GetReservesAccounts().GetKeys())
+}
+
+func TestInitReserve_Data(t *testing.T) {
+	config := ReserveConfig{
+		OptimalUtilizationRate: 80,
+		LoanToValueRatio:       50,
+		LiquidationBonus:       5,
+		LiquidationThreshold:   55,
+		OptimalBorrowRate:      4,
+		MaxBorrowRate:          30,
+		Fees: ReserveFees{
+			BorrowFeeWad:      1,
+			FlashLoanFeeWad:   2,
+			HostFeePercentage: 20,
+		},
+	}
+	inst := NewInitReserveInstructionBuilder().
+		SetLiquidityAmount(1000).
+		SetConfig(config).
+		Build()
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		Instruction_InitReserve,
+		0xe8, 0x03, 0, 0, 0, 0, 0, 0,
+		80, 50, 5, 55, 0, 4, 30,
+		1, 0, 0, 0, 0, 0, 0, 0,
+		2, 0, 0, 0, 0, 0, 0, 0,
+		20,
+	}, data)
+
+	decoded, err := DecodeInstruction(nil, data)
+	require.NoError(t, err)
+	require.Equal(t, config, *decoded.Impl.(*InitReserve).Config)
+	require.Equal(t, uint64(1000), *decoded.Impl.(*InitReserve).LiquidityAmount)
+}
+
+func TestBorrowObligationLiquidity_HostFee(t *testing.T) {
+	inst := NewBorrowObligationLiquidityInstruction(
+		100,
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+		ag_solanago.NewWallet().PublicKey(),
+	)
+	require.NoError(t, inst.Validate())
+	require.Len(t, inst.Build().Accounts(), 10)
+
+	hostFee := ag_solanago.NewWallet().PublicKey()
+	inst.SetHostFeeReceiverAccount(hostFee)
+	accounts := inst.Build().Accounts()
+	require.Len(t, accounts, 11)
+	require.Equal(t, ag_solanago.Meta(hostFee).WRITE(), accounts[10])
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"context"
+	"fmt"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// FetchLendingMarket fetches and decodes the provided lending market account.
+func FetchLendingMarket(ctx context.Context, rpcCli *rpc.Client, lendingMarket ag_solanago.PublicKey) (*LendingMarket, error) {
+	resp, err := rpcCli.GetAccountInfo(ctx, lendingMarket)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeLendingMarket(resp.Value.Data.GetBinary())
+}
+
+// FetchReserve fetches and decodes the provided reserve account.
+func FetchReserve(ctx context.Context, rpcCli *rpc.Client, reserve ag_solanago.PublicKey) (*Reserve, error) {
+	resp, err := rpcCli.GetAccountInfo(ctx, reserve)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeReserve(resp.Value.Data.GetBinary())
+}
+
+// FetchObligation fetches and decodes the provided obligation account.
+func FetchObligation(ctx context.Context, rpcCli *rpc.Client, obligation ag_solanago.PublicKey) (*Obligation, error) {
+	resp, err := rpcCli.GetAccountInfo(ctx, obligation)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeObligation(resp.Value.Data.GetBinary())
+}
+
+// FetchReserves fetches and decodes all the reserves of the provided lending market.
+func FetchReserves(ctx context.Context, rpcCli *rpc.Client, lendingMarket ag_solanago.PublicKey) (map[ag_solanago.PublicKey]*Reserve, error) {
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		ProgramID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					DataSize: RESERVE_SIZE,
+				},
+				{
+					// version (1) + last update (8 + 1)
+					Memcmp: &rpc.RPCFilterMemcmp{
+						Offset: 10,
+						Bytes:  lendingMarket[:],
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[ag_solanago.PublicKey]*Reserve, len(resp))
+	for _, keyedAcct := range resp {
+		reserve, err := DecodeReserve(keyedAcct.Account.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode reserve %s: %w", keyedAcct.Pubkey, err)
+		}
+		out[keyedAcct.Pubkey] = reserve
+	}
+	return out, nil
+}
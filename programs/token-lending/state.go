@@ -0,0 +1,295 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+// Current version of the program and all new accounts created.
+const PROGRAM_VERSION uint8 = 1
+
+const (
+	LENDING_MARKET_SIZE = 258
+	RESERVE_SIZE        = 571
+	OBLIGATION_SIZE     = 916
+)
+
+// Maximum number of deposits and borrows for an obligation.
+const MAX_OBLIGATION_RESERVES = 10
+
+const (
+	obligationCollateralSize = 56 // 32 + 8 + 16
+	obligationLiquiditySize  = 80 // 32 + 16 + 16 + 16
+)
+
+// LendingMarket is the state of a lending market.
+type LendingMarket struct {
+	// Version of lending market.
+	Version uint8
+	// Bump seed for derived authority address.
+	BumpSeed uint8
+	// Owner authority which can add new reserves.
+	Owner ag_solanago.PublicKey
+	// Currency market prices are quoted in
+	// e.g. "USD" null padded or a SPL token mint pubkey.
+	QuoteCurrency [32]byte
+	// Token program id.
+	TokenProgramID ag_solanago.PublicKey
+	// Oracle (Pyth) program id.
+	OracleProgramID ag_solanago.PublicKey
+
+	Padding [128]byte `json:"-"`
+}
+
+// Reserve is the state of a lending market reserve.
+type Reserve struct {
+	// Version of the struct.
+	Version uint8
+	// Last slot when supply and rates updated.
+	LastUpdate LastUpdate
+	// Lending market address.
+	LendingMarket ag_solanago.PublicKey
+	// Reserve liquidity.
+	Liquidity ReserveLiquidity
+	// Reserve collateral.
+	Collateral ReserveCollateral
+	// Reserve configuration values.
+	Config ReserveConfig
+
+	Padding [248]byte `json:"-"`
+}
+
+// ReserveLiquidity is the liquidity state of a reserve.
+type ReserveLiquidity struct {
+	// Reserve liquidity mint address.
+	MintPubkey ag_solanago.PublicKey
+	// Reserve liquidity mint decimals.
+	MintDecimals uint8
+	// Reserve liquidity supply address.
+	SupplyPubkey ag_solanago.PublicKey
+	// Reserve liquidity fee receiver address.
+	FeeReceiver ag_solanago.PublicKey
+	// Reserve liquidity oracle account.
+	OraclePubkey ag_solanago.PublicKey
+	// Reserve liquidity available.
+	AvailableAmount uint64
+	// Reserve liquidity borrowed.
+	BorrowedAmountWads Decimal
+	// Reserve liquidity cumulative borrow rate.
+	CumulativeBorrowRateWads Decimal
+	// Reserve liquidity market price in quote currency.
+	MarketPrice Decimal
+}
+
+// ReserveCollateral is the collateral state of a reserve.
+type ReserveCollateral struct {
+	// Reserve collateral mint address.
+	MintPubkey ag_solanago.PublicKey
+	// Reserve collateral mint supply, used for exchange rate.
+	MintTotalSupply uint64
+	// Reserve collateral supply address.
+	SupplyPubkey ag_solanago.PublicKey
+}
+
+// Obligation is the state of a lending market obligation.
+type Obligation struct {
+	// Version of the struct.
+	Version uint8
+	// Last update to collateral, liquidity, or their market values.
+	LastUpdate LastUpdate
+	// Lending market address.
+	LendingMarket ag_solanago.PublicKey
+	// Owner authority which can borrow liquidity.
+	Owner ag_solanago.PublicKey
+	// Deposited collateral for the obligation, unique by deposit reserve address.
+	Deposits []ObligationCollateral
+	// Borrowed liquidity for the obligation, unique by borrow reserve address.
+	Borrows []ObligationLiquidity
+	// Market value of deposits.
+	DepositedValue Decimal
+	// Market value of borrows.
+	BorrowedValue Decimal
+	// The maximum borrow value at the weighted average loan to value ratio.
+	AllowedBorrowValue Decimal
+	// The dangerous borrow value at the weighted average liquidation threshold.
+	UnhealthyBorrowValue Decimal
+}
+
+// ObligationCollateral is a collateral deposit of an obligation.
+type ObligationCollateral struct {
+	// Reserve collateral is deposited to.
+	DepositReserve ag_solanago.PublicKey
+	// Amount of collateral deposited.
+	DepositedAmount uint64
+	// Collateral market value in quote currency.
+	MarketValue Decimal
+}
+
+// ObligationLiquidity is a liquidity borrow of an obligation.
+type ObligationLiquidity struct {
+	// Reserve liquidity is borrowed from.
+	BorrowReserve ag_solanago.PublicKey
+	// Borrow rate used for calculating interest.
+	CumulativeBorrowRateWads Decimal
+	// Amount of liquidity borrowed plus interest.
+	BorrowedAmountWads Decimal
+	// Liquidity market value in quote currency.
+	MarketValue Decimal
+}
+
+func (obj *Obligation) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.Version, err = decoder.ReadUint8(); err != nil {
+		return err
+	}
+	if err = decoder.Decode(&obj.LastUpdate); err != nil {
+		return err
+	}
+	if err = decoder.Decode(&obj.LendingMarket); err != nil {
+		return err
+	}
+	if err = decoder.Decode(&obj.Owner); err != nil {
+		return err
+	}
+	for _, v := range []*Decimal{&obj.DepositedValue, &obj.BorrowedValue, &obj.AllowedBorrowValue, &obj.UnhealthyBorrowValue} {
+		if err = v.UnmarshalWithDecoder(decoder); err != nil {
+			return err
+		}
+	}
+	depositsLen, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	borrowsLen, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if int(depositsLen)+int(borrowsLen) > MAX_OBLIGATION_RESERVES {
+		return fmt.Errorf("too many obligation reserves: %d deposits and %d borrows", depositsLen, borrowsLen)
+	}
+	used := int(depositsLen)*obligationCollateralSize + int(borrowsLen)*obligationLiquiditySize
+	if used > obligationDataFlatSize {
+		return fmt.Errorf("obligation reserves overflow the data area: %d deposits and %d borrows need %d bytes, have %d", depositsLen, borrowsLen, used, obligationDataFlatSize)
+	}
+	// The deposits and borrows are packed one after the other
+	// in a fixed-size area; the rest of it is zeroed.
+	obj.Deposits = make([]ObligationCollateral, depositsLen)
+	for i := range obj.Deposits {
+		if err = decoder.Decode(&obj.Deposits[i]); err != nil {
+			return err
+		}
+	}
+	obj.Borrows = make([]ObligationLiquidity, borrowsLen)
+	for i := range obj.Borrows {
+		if err = decoder.Decode(&obj.Borrows[i]); err != nil {
+			return err
+		}
+	}
+	_, err = decoder.ReadNBytes(obligationDataFlatSize - used)
+	return err
+}
+
+func (obj Obligation) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if len(obj.Deposits)+len(obj.Borrows) > MAX_OBLIGATION_RESERVES {
+		return fmt.Errorf("too many obligation reserves: %d deposits and %d borrows", len(obj.Deposits), len(obj.Borrows))
+	}
+	used := len(obj.Deposits)*obligationCollateralSize + len(obj.Borrows)*obligationLiquiditySize
+	if used > obligationDataFlatSize {
+		return fmt.Errorf("obligation reserves overflow the data area: %d deposits and %d borrows need %d bytes, have %d", len(obj.Deposits), len(obj.Borrows), used, obligationDataFlatSize)
+	}
+	if err = encoder.WriteUint8(obj.Version); err != nil {
+		return err
+	}
+	if err = encoder.Encode(obj.LastUpdate); err != nil {
+		return err
+	}
+	if err = encoder.Encode(obj.LendingMarket); err != nil {
+		return err
+	}
+	if err = encoder.Encode(obj.Owner); err != nil {
+		return err
+	}
+	for _, v := range []Decimal{obj.DepositedValue, obj.BorrowedValue, obj.AllowedBorrowValue, obj.UnhealthyBorrowValue} {
+		if err = v.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteUint8(uint8(len(obj.Deposits))); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(uint8(len(obj.Borrows))); err != nil {
+		return err
+	}
+	for _, v := range obj.Deposits {
+		if err = encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+	for _, v := range obj.Borrows {
+		if err = encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+	return encoder.WriteBytes(make([]byte, obligationDataFlatSize-used), false)
+}
+
+// Size of the area of an obligation account that holds the deposits and the borrows.
+const obligationDataFlatSize = obligationCollateralSize + obligationLiquiditySize*(MAX_OBLIGATION_RESERVES-1)
+
+func decodeState(data []byte, size int, dst interface{}, name string) error {
+	if len(data) != size {
+		return fmt.Errorf("invalid %s account size: expected %d, got %d", name, size, len(data))
+	}
+	if err := ag_binary.NewBinDecoder(data).Decode(dst); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// DecodeLendingMarket decodes the given account bytes into a LendingMarket.
+func DecodeLendingMarket(data []byte) (*LendingMarket, error) {
+	var obj LendingMarket
+	if err := decodeState(data, LENDING_MARKET_SIZE, &obj, "lending market"); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// DecodeReserve decodes the given account bytes into a Reserve.
+func DecodeReserve(data []byte) (*Reserve, error) {
+	var obj Reserve
+	if err := decodeState(data, RESERVE_SIZE, &obj, "reserve"); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// DecodeObligation decodes the given account bytes into an Obligation.
+func DecodeObligation(data []byte) (*Obligation, error) {
+	var obj Obligation
+	if err := decodeState(data, OBLIGATION_SIZE, &obj, "obligation"); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// FindLendingMarketAuthorityAddress returns the derived authority of the
+// provided lending market, and its bump seed.
+func FindLendingMarketAuthorityAddress(lendingMarket ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress([][]byte{lendingMarket[:]}, ProgramID)
+}
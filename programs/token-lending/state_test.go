@@ -0,0 +1,173 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"bytes"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+func encodeState(t *testing.T, v interface{}) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(v))
+	return buf.Bytes()
+}
+
+func TestDecodeLendingMarket(t *testing.T) {
+	market := LendingMarket{
+		Version:         PROGRAM_VERSION,
+		BumpSeed:        255,
+		Owner:           ag_solanago.NewWallet().PublicKey(),
+		TokenProgramID:  ag_solanago.TokenProgramID,
+		OracleProgramID: ag_solanago.NewWallet().PublicKey(),
+	}
+	copy(market.QuoteCurrency[:], "USD")
+	data := encodeState(t, market)
+	require.Len(t, data, LENDING_MARKET_SIZE)
+
+	got, err := DecodeLendingMarket(data)
+	require.NoError(t, err)
+	require.Equal(t, market, *got)
+
+	obj, err := ag_solanago.DecodeAccount(ProgramID, data)
+	require.NoError(t, err)
+	require.Equal(t, got, obj)
+
+	_, err = DecodeLendingMarket(data[:LENDING_MARKET_SIZE-1])
+	require.Error(t, err)
+}
+
+func TestDecodeReserve(t *testing.T) {
+	reserve := Reserve{
+		Version:       PROGRAM_VERSION,
+		LastUpdate:    LastUpdate{Slot: 123456, Stale: true},
+		LendingMarket: ag_solanago.NewWallet().PublicKey(),
+		Liquidity: ReserveLiquidity{
+			MintPubkey:               ag_solanago.NewWallet().PublicKey(),
+			MintDecimals:             6,
+			SupplyPubkey:             ag_solanago.NewWallet().PublicKey(),
+			FeeReceiver:              ag_solanago.NewWallet().PublicKey(),
+			OraclePubkey:             ag_solanago.NewWallet().PublicKey(),
+			AvailableAmount:          1_000_000,
+			BorrowedAmountWads:       Decimal{Lo: 5 * WAD},
+			CumulativeBorrowRateWads: Decimal{Lo: WAD},
+			MarketPrice:              Decimal{Lo: 2 * WAD, Hi: 1},
+		},
+		Collateral: ReserveCollateral{
+			MintPubkey:      ag_solanago.NewWallet().PublicKey(),
+			MintTotalSupply: 42,
+			SupplyPubkey:    ag_solanago.NewWallet().PublicKey(),
+		},
+		Config: ReserveConfig{
+			OptimalUtilizationRate: 80,
+			LoanToValueRatio:       50,
+			LiquidationBonus:       5,
+			LiquidationThreshold:   55,
+			MinBorrowRate:          0,
+			OptimalBorrowRate:      4,
+			MaxBorrowRate:          30,
+			Fees: ReserveFees{
+				BorrowFeeWad:      100_000_000_000_000,
+				FlashLoanFeeWad:   3_000_000_000_000_000,
+				HostFeePercentage: 20,
+			},
+		},
+	}
+	data := encodeState(t, reserve)
+	require.Len(t, data, RESERVE_SIZE)
+
+	got, err := DecodeReserve(data)
+	require.NoError(t, err)
+	require.Equal(t, reserve, *got)
+
+	obj, err := ag_solanago.DecodeAccount(ProgramID, data)
+	require.NoError(t, err)
+	require.Equal(t, got, obj)
+	require.Equal(t, "5.000000000000000000", got.Liquidity.BorrowedAmountWads.String())
+}
+
+func TestDecodeObligation(t *testing.T) {
+	obligation := Obligation{
+		Version:       PROGRAM_VERSION,
+		LastUpdate:    LastUpdate{Slot: 99},
+		LendingMarket: ag_solanago.NewWallet().PublicKey(),
+		Owner:         ag_solanago.NewWallet().PublicKey(),
+		Deposits: []ObligationCollateral{
+			{
+				DepositReserve:  ag_solanago.NewWallet().PublicKey(),
+				DepositedAmount: 1000,
+				MarketValue:     Decimal{Lo: 10 * WAD},
+			},
+			{
+				DepositReserve:  ag_solanago.NewWallet().PublicKey(),
+				DepositedAmount: 2000,
+				MarketValue:     Decimal{Lo: 2 * WAD, Hi: 1},
+			},
+		},
+		Borrows: []ObligationLiquidity{
+			{
+				BorrowReserve:            ag_solanago.NewWallet().PublicKey(),
+				CumulativeBorrowRateWads: Decimal{Lo: WAD},
+				BorrowedAmountWads:       Decimal{Lo: 7 * WAD},
+				MarketValue:              Decimal{Lo: 7 * WAD},
+			},
+		},
+		DepositedValue:       Decimal{Lo: 3 * WAD, Hi: 1},
+		BorrowedValue:        Decimal{Lo: 7 * WAD},
+		AllowedBorrowValue:   Decimal{Lo: 15 * WAD / 10},
+		UnhealthyBorrowValue: Decimal{Lo: 16 * WAD / 10},
+	}
+	data := encodeState(t, obligation)
+	require.Len(t, data, OBLIGATION_SIZE)
+
+	got, err := DecodeObligation(data)
+	require.NoError(t, err)
+	require.Equal(t, obligation, *got)
+
+	obj, err := ag_solanago.DecodeAccount(ProgramID, data)
+	require.NoError(t, err)
+	require.Equal(t, got, obj)
+
+	{
+		// Too many positions:
+		tooMany := obligation
+		tooMany.Borrows = make([]ObligationLiquidity, MAX_OBLIGATION_RESERVES)
+		buf := new(bytes.Buffer)
+		require.Error(t, ag_binary.NewBinEncoder(buf).Encode(tooMany))
+	}
+	{
+		// Within the reserve limit, but larger than the data area:
+		overflow := obligation
+		overflow.Deposits = nil
+		overflow.Borrows = make([]ObligationLiquidity, MAX_OBLIGATION_RESERVES)
+		buf := new(bytes.Buffer)
+		err := ag_binary.NewBinEncoder(buf).Encode(overflow)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "0 deposits and 10 borrows")
+
+		// Same counts in account data:
+		crafted := append([]byte(nil), data...)
+		countsOffset := 1 + 9 + 32 + 32 + 4*16
+		crafted[countsOffset] = 0
+		crafted[countsOffset+1] = MAX_OBLIGATION_RESERVES
+		_, err = DecodeObligation(crafted)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "0 deposits and 10 borrows")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenlending
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// WAD is the scale of the fixed-point decimals of the program (18 decimal places).
+const WAD = 1_000_000_000_000_000_000
+
+// Decimal is an unsigned 128-bit fixed-point number with 18 decimal places,
+// as stored in reserve and obligation accounts.
+type Decimal ag_binary.Uint128
+
+// BigInt returns the scaled value of the decimal.
+func (d Decimal) BigInt() *big.Int {
+	return ag_binary.Uint128(d).BigInt()
+}
+
+// BigFloat returns the value of the decimal.
+func (d Decimal) BigFloat() *big.Float {
+	out := new(big.Float).SetInt(d.BigInt())
+	return out.Quo(out, new(big.Float).SetUint64(WAD))
+}
+
+func (d Decimal) String() string {
+	return d.BigFloat().Text('f', 18)
+}
+
+func (d Decimal) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return encoder.WriteUint128(ag_binary.Uint128(d), binary.LittleEndian)
+}
+
+func (d *Decimal) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	v, err := decoder.ReadUint128(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	*d = Decimal(v)
+	return nil
+}
+
+// LastUpdate tracks the slot of the last update of a reserve or obligation.
+type LastUpdate struct {
+	// Last slot when updated.
+	Slot uint64
+	// True when marked stale, false when slot updated.
+	Stale bool
+}
+
+// ReserveConfig is the configuration of a reserve.
+type ReserveConfig struct {
+	// Optimal utilization rate, as a percentage.
+	OptimalUtilizationRate uint8
+	// Target ratio of the value of borrows to deposits, as a percentage.
+	// 0 if use as collateral is disabled.
+	LoanToValueRatio uint8
+	// Bonus a liquidator gets when repaying part of an unhealthy obligation, as a percentage.
+	LiquidationBonus uint8
+	// Loan to value ratio at which an obligation can be liquidated, as a percentage.
+	LiquidationThreshold uint8
+	// Min borrow APY.
+	MinBorrowRate uint8
+	// Optimal (utilization) borrow APY.
+	OptimalBorrowRate uint8
+	// Max borrow APY.
+	MaxBorrowRate uint8
+	// Program owner fees assessed, separate from gains due to interest accrual.
+	Fees ReserveFees
+}
+
+// ReserveFees are the additional fee information on a reserve.
+//
+// These exist separately from interest accrual fees, and are specifically for the program owner
+// and frontend host. The fees are paid out as a percentage of liquidity token amounts during
+// repayments and liquidations.
+type ReserveFees struct {
+	// Fee assessed on BorrowObligationLiquidity, expressed as a Wad.
+	// Must be between 0 and 10^18, such that 10^18 = 1.
+	BorrowFeeWad uint64
+	// Fee for flash loan, expressed as a Wad.
+	FlashLoanFeeWad uint64
+	// Amount of fee going to host account, if provided in liquidate and repay.
+	HostFeePercentage uint8
+}
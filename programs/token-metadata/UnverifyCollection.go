@@ -0,0 +1,207 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// If a MetadataAccount has a verified collection, the collection authority
+// can unverify it.
+type UnverifyCollection struct {
+	// [0] = [WRITE] metadata
+	// ··········· Metadata account.
+	//
+	// [1] = [WRITE, SIGNER] collectionAuthority
+	// ··········· Collection update authority.
+	//
+	// [2] = [] collectionMint
+	// ··········· Mint of the collection.
+	//
+	// [3] = [] collection
+	// ··········· Metadata account of the collection.
+	//
+	// [4] = [] collectionMasterEdition
+	// ··········· Master edition V2 account of the collection token.
+	//
+	// [5] = [] collectionAuthorityRecord (optional)
+	// ··········· Collection authority record PDA, if the authority is a delegate.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUnverifyCollectionInstructionBuilder creates a new `UnverifyCollection` instruction builder.
+func NewUnverifyCollectionInstructionBuilder() *UnverifyCollection {
+	nd := &UnverifyCollection{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 5),
+	}
+	return nd
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *UnverifyCollection) SetMetadataAccount(metadata ag_solanago.PublicKey) *UnverifyCollection {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *UnverifyCollection) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetCollectionAuthorityAccount sets the "collectionAuthority" account.
+func (inst *UnverifyCollection) SetCollectionAuthorityAccount(collectionAuthority ag_solanago.PublicKey) *UnverifyCollection {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(collectionAuthority).WRITE().SIGNER()
+	return inst
+}
+
+// GetCollectionAuthorityAccount gets the "collectionAuthority" account.
+func (inst *UnverifyCollection) GetCollectionAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetCollectionMintAccount sets the "collectionMint" account.
+func (inst *UnverifyCollection) SetCollectionMintAccount(collectionMint ag_solanago.PublicKey) *UnverifyCollection {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(collectionMint)
+	return inst
+}
+
+// GetCollectionMintAccount gets the "collectionMint" account.
+func (inst *UnverifyCollection) GetCollectionMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetCollectionAccount sets the "collection" (metadata) account.
+func (inst *UnverifyCollection) SetCollectionAccount(collection ag_solanago.PublicKey) *UnverifyCollection {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(collection)
+	return inst
+}
+
+// GetCollectionAccount gets the "collection" (metadata) account.
+func (inst *UnverifyCollection) GetCollectionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetCollectionMasterEditionAccount sets the "collectionMasterEdition" account.
+func (inst *UnverifyCollection) SetCollectionMasterEditionAccount(collectionMasterEdition ag_solanago.PublicKey) *UnverifyCollection {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(collectionMasterEdition)
+	return inst
+}
+
+// GetCollectionMasterEditionAccount gets the "collectionMasterEdition" account.
+func (inst *UnverifyCollection) GetCollectionMasterEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetCollectionAuthorityRecordAccount sets the optional "collectionAuthorityRecord" account.
+func (inst *UnverifyCollection) SetCollectionAuthorityRecordAccount(collectionAuthorityRecord ag_solanago.PublicKey) *UnverifyCollection {
+	if len(inst.AccountMetaSlice) < 6 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(collectionAuthorityRecord)
+	return inst
+}
+
+// GetCollectionAuthorityRecordAccount gets the optional "collectionAuthorityRecord" account.
+func (inst *UnverifyCollection) GetCollectionAuthorityRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+func (inst UnverifyCollection) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_UnverifyCollection),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UnverifyCollection) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UnverifyCollection) Validate() error {
+	// Check whether all (required) accounts are set:
+	names := []string{"Metadata", "CollectionAuthority", "CollectionMint", "Collection", "CollectionMasterEdition"}
+	for i, name := range names {
+		if inst.AccountMetaSlice.Get(i) == nil {
+			return fmt.Errorf("accounts.%s is not set", name)
+		}
+	}
+	if len(inst.AccountMetaSlice) > 6 {
+		return errors.New("too many accounts")
+	}
+	return nil
+}
+
+func (inst *UnverifyCollection) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UnverifyCollection")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params[len=0]").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("      collectionAuthority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("           collectionMint", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("               collection", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("  collectionMasterEdition", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("collectionAuthorityRecord", inst.AccountMetaSlice.Get(5)))
+					})
+				})
+		})
+}
+
+// NewUnverifyCollectionInstruction declares a new UnverifyCollection instruction with the provided accounts.
+// The metadata accounts and the collection master edition are derived from the mints.
+func NewUnverifyCollectionInstruction(
+	mint ag_solanago.PublicKey,
+	collectionMint ag_solanago.PublicKey,
+	collectionAuthority ag_solanago.PublicKey,
+) (*UnverifyCollection, error) {
+	metadata, _, err := ag_solanago.FindTokenMetadataAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("error while FindTokenMetadataAddress: %w", err)
+	}
+	collection, _, err := ag_solanago.FindTokenMetadataAddress(collectionMint)
+	if err != nil {
+		return nil, fmt.Errorf("error while FindTokenMetadataAddress: %w", err)
+	}
+	collectionMasterEdition, _, err := ag_solanago.FindTokenMasterEditionAddress(collectionMint)
+	if err != nil {
+		return nil, fmt.Errorf("error while FindTokenMasterEditionAddress: %w", err)
+	}
+	return NewUnverifyCollectionInstructionBuilder().
+		SetMetadataAccount(metadata).
+		SetCollectionAuthorityAccount(collectionAuthority).
+		SetCollectionMintAccount(collectionMint).
+		SetCollectionAccount(collection).
+		SetCollectionMasterEditionAccount(collectionMasterEdition), nil
+}
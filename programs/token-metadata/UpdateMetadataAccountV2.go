@@ -0,0 +1,171 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Update a Metadata with is_mutable as a parameter.
+// All the parameters are optional; only the set ones are updated.
+type UpdateMetadataAccountV2 struct {
+	Data                *DataV2                `bin:"optional"`
+	UpdateAuthority     *ag_solanago.PublicKey `bin:"optional"`
+	PrimarySaleHappened *bool                  `bin:"optional"`
+	IsMutable           *bool                  `bin:"optional"`
+
+	// [0] = [WRITE] metadata
+	// ··········· Metadata account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· Update authority key.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateMetadataAccountV2InstructionBuilder creates a new `UpdateMetadataAccountV2` instruction builder.
+func NewUpdateMetadataAccountV2InstructionBuilder() *UpdateMetadataAccountV2 {
+	nd := &UpdateMetadataAccountV2{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetData sets the "data" parameter.
+func (inst *UpdateMetadataAccountV2) SetData(data DataV2) *UpdateMetadataAccountV2 {
+	inst.Data = &data
+	return inst
+}
+
+// SetUpdateAuthority sets the "updateAuthority" parameter (the new update authority).
+func (inst *UpdateMetadataAccountV2) SetUpdateAuthority(updateAuthority ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.UpdateAuthority = &updateAuthority
+	return inst
+}
+
+// SetPrimarySaleHappened sets the "primarySaleHappened" parameter.
+func (inst *UpdateMetadataAccountV2) SetPrimarySaleHappened(primarySaleHappened bool) *UpdateMetadataAccountV2 {
+	inst.PrimarySaleHappened = &primarySaleHappened
+	return inst
+}
+
+// SetIsMutable sets the "isMutable" parameter.
+func (inst *UpdateMetadataAccountV2) SetIsMutable(isMutable bool) *UpdateMetadataAccountV2 {
+	inst.IsMutable = &isMutable
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *UpdateMetadataAccountV2) SetMetadataAccount(metadata ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *UpdateMetadataAccountV2) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account (the current update authority).
+func (inst *UpdateMetadataAccountV2) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+func (inst *UpdateMetadataAccountV2) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+func (inst UpdateMetadataAccountV2) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_UpdateMetadataAccountV2),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateMetadataAccountV2) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateMetadataAccountV2) Validate() error {
+	// Check the (optional) parameters:
+	{
+		if inst.Data != nil {
+			if err := inst.Data.Validate(); err != nil {
+				return fmt.Errorf("invalid Data parameter: %w", err)
+			}
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateMetadataAccountV2) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateMetadataAccountV2")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("               Data (OPT)", inst.Data))
+						paramsBranch.Child(ag_format.Param("    UpdateAuthority (OPT)", inst.UpdateAuthority))
+						paramsBranch.Child(ag_format.Param("PrimarySaleHappened (OPT)", inst.PrimarySaleHappened))
+						paramsBranch.Child(ag_format.Param("          IsMutable (OPT)", inst.IsMutable))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+// NewUpdateMetadataAccountV2Instruction declares a new UpdateMetadataAccountV2 instruction with the provided accounts;
+// set the fields to update with the setters.
+func NewUpdateMetadataAccountV2Instruction(
+	// Accounts:
+	metadata ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey,
+) *UpdateMetadataAccountV2 {
+	return NewUpdateMetadataAccountV2InstructionBuilder().
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
@@ -0,0 +1,252 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"fmt"
+	"strings"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+type Metadata struct {
+	Key             Key
+	UpdateAuthority ag_solanago.PublicKey
+	Mint            ag_solanago.PublicKey
+	Data            Data
+
+	// Immutable, once flipped, all sales of this metadata are considered secondary.
+	PrimarySaleHappened bool
+	// Whether or not the data struct is mutable, default is not.
+	IsMutable bool
+	// nonce for easy calculation of editions, if present.
+	EditionNonce *uint8 `bin:"optional"`
+	// Since we cannot easily change Metadata, we add the new DataV2 fields here at the end.
+	TokenStandard *TokenStandard `bin:"optional"`
+	// Collection.
+	Collection *Collection `bin:"optional"`
+	// Uses.
+	Uses *Uses `bin:"optional"`
+	// Collection Details.
+	CollectionDetails *CollectionDetails `bin:"optional"`
+	// Programmable Config.
+	ProgrammableConfig *ProgrammableConfig `bin:"optional"`
+}
+
+// trimPadding removes the NUL padding that the program adds to
+// the name, symbol and uri of older metadata accounts.
+func trimPadding(s string) string {
+	return strings.TrimRight(s, "\x00")
+}
+
+func (obj *Metadata) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	{
+		v, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		obj.Key = Key(v)
+		if obj.Key != KeyMetadataV1 {
+			return fmt.Errorf("invalid metadata key: %v", obj.Key)
+		}
+	}
+	{
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.UpdateAuthority = ag_solanago.PublicKeyFromBytes(v)
+	}
+	{
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.Mint = ag_solanago.PublicKeyFromBytes(v)
+	}
+	{
+		err = decoder.Decode(&obj.Data)
+		if err != nil {
+			return err
+		}
+		obj.Data.Name = trimPadding(obj.Data.Name)
+		obj.Data.Symbol = trimPadding(obj.Data.Symbol)
+		obj.Data.Uri = trimPadding(obj.Data.Uri)
+	}
+	obj.PrimarySaleHappened, err = decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+	obj.IsMutable, err = decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+
+	// The following fields were added over time, and older
+	// accounts might not have them:
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		v, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		obj.EditionNonce = &v
+	}
+
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		v, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		ts := TokenStandard(v)
+		obj.TokenStandard = &ts
+	}
+
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		obj.Collection = new(Collection)
+		if err := decoder.Decode(obj.Collection); err != nil {
+			return err
+		}
+	}
+
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		obj.Uses = new(Uses)
+		if err := decoder.Decode(obj.Uses); err != nil {
+			return err
+		}
+	}
+
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		obj.CollectionDetails = new(CollectionDetails)
+		if err := obj.CollectionDetails.UnmarshalWithDecoder(decoder); err != nil {
+			return err
+		}
+	}
+
+	if !decoder.HasRemaining() {
+		return nil
+	}
+	if ok, err := decoder.ReadOption(); err != nil {
+		return err
+	} else if ok {
+		obj.ProgrammableConfig = new(ProgrammableConfig)
+		if err := obj.ProgrammableConfig.UnmarshalWithDecoder(decoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMetadata decodes the given account bytes into a Metadata.
+func DecodeMetadata(data []byte) (*Metadata, error) {
+	var obj Metadata
+	if err := ag_binary.NewBorshDecoder(data).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode metadata: %w", err)
+	}
+	return &obj, nil
+}
+
+type MasterEditionV2 struct {
+	Key       Key
+	Supply    uint64
+	MaxSupply *uint64 `bin:"optional"`
+}
+
+type Edition struct {
+	Key Key
+	// Points at MasterEdition struct.
+	Parent ag_solanago.PublicKey
+	// Starting at 0 for master record, this is incremented for each edition minted.
+	Edition uint64
+}
+
+// DecodeMasterEdition decodes the given account bytes into a MasterEditionV2.
+func DecodeMasterEdition(data []byte) (*MasterEditionV2, error) {
+	var obj MasterEditionV2
+	if err := ag_binary.NewBorshDecoder(data).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode master edition: %w", err)
+	}
+	if obj.Key != KeyMasterEditionV2 {
+		return nil, fmt.Errorf("invalid master edition key: %v", obj.Key)
+	}
+	return &obj, nil
+}
+
+// DecodeEdition decodes the given account bytes into an Edition.
+func DecodeEdition(data []byte) (*Edition, error) {
+	var obj Edition
+	if err := ag_binary.NewBorshDecoder(data).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode edition: %w", err)
+	}
+	if obj.Key != KeyEditionV1 {
+		return nil, fmt.Errorf("invalid edition key: %v", obj.Key)
+	}
+	return &obj, nil
+}
+
+func registerAccountDecoders(programID ag_solanago.PublicKey) {
+	ag_solanago.RegisterAccountDecoder(programID, ag_solanago.AccountLayout{
+		Name:          "Metadata",
+		Discriminator: []byte{byte(KeyMetadataV1)},
+		Decode:        decodeMetadataAccount,
+	})
+	ag_solanago.RegisterAccountDecoder(programID, ag_solanago.AccountLayout{
+		Name:          "MasterEditionV2",
+		Discriminator: []byte{byte(KeyMasterEditionV2)},
+		Decode:        decodeMasterEditionAccount,
+	})
+	ag_solanago.RegisterAccountDecoder(programID, ag_solanago.AccountLayout{
+		Name:          "Edition",
+		Discriminator: []byte{byte(KeyEditionV1)},
+		Decode:        decodeEditionAccount,
+	})
+}
+
+func decodeMetadataAccount(data []byte) (interface{}, error) {
+	return DecodeMetadata(data)
+}
+
+func decodeMasterEditionAccount(data []byte) (interface{}, error) {
+	return DecodeMasterEdition(data)
+}
+
+func decodeEditionAccount(data []byte) (interface{}, error) {
+	return DecodeEdition(data)
+}
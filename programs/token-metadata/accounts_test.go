@@ -0,0 +1,134 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"bytes"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+	"github.com/xmcontinue/solana-go"
+)
+
+func encodeBorshT(t *testing.T, v interface{}) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBorshEncoder(buf).Encode(v))
+	return buf.Bytes()
+}
+
+func TestDecodeMetadata(t *testing.T) {
+	updateAuthority := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	mint := solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	collectionMint := solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+
+	nonce := uint8(254)
+	standard := TokenStandardNonFungible
+	expected := Metadata{
+		Key:             KeyMetadataV1,
+		UpdateAuthority: updateAuthority,
+		Mint:            mint,
+		Data: Data{
+			Name:                 "Grape #1",
+			Symbol:               "GRAPE",
+			Uri:                  "https://example.com/1.json",
+			SellerFeeBasisPoints: 500,
+			Creators: &[]Creator{
+				{Address: updateAuthority, Verified: true, Share: 100},
+			},
+		},
+		PrimarySaleHappened: true,
+		IsMutable:           true,
+		EditionNonce:        &nonce,
+		TokenStandard:       &standard,
+		Collection:          &Collection{Verified: true, Key: collectionMint},
+		Uses:                &Uses{UseMethod: UseMethodMultiple, Remaining: 3, Total: 5},
+		CollectionDetails:   &CollectionDetails{Size: 42},
+		ProgrammableConfig:  &ProgrammableConfig{RuleSet: collectionMint.ToPointer()},
+	}
+
+	t.Run("full", func(t *testing.T) {
+		data := encodeBorshT(t, expected)
+		// Accounts are allocated with a fixed size, and padded with zeros:
+		data = append(data, make([]byte, 32)...)
+
+		got, err := DecodeMetadata(data)
+		require.NoError(t, err)
+		require.Equal(t, expected, *got)
+	})
+
+	t.Run("padded strings and no optional fields", func(t *testing.T) {
+		old := Metadata{
+			Key:             KeyMetadataV1,
+			UpdateAuthority: updateAuthority,
+			Mint:            mint,
+			Data: Data{
+				Name:   "Grape #1" + string(make([]byte, 24)),
+				Symbol: "GRAPE" + string(make([]byte, 5)),
+				Uri:    "https://example.com/1.json" + string(make([]byte, 10)),
+			},
+			IsMutable: true,
+		}
+		data := encodeBorshT(t, old)
+		// Truncate after `is_mutable` (layout of the oldest accounts):
+		data = data[:len(data)-6]
+
+		got, err := DecodeMetadata(data)
+		require.NoError(t, err)
+		require.Equal(t, "Grape #1", got.Data.Name)
+		require.Equal(t, "GRAPE", got.Data.Symbol)
+		require.Equal(t, "https://example.com/1.json", got.Data.Uri)
+		require.Nil(t, got.Data.Creators)
+		require.Nil(t, got.EditionNonce)
+		require.Nil(t, got.TokenStandard)
+		require.Nil(t, got.Collection)
+		require.True(t, got.IsMutable)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		data := encodeBorshT(t, MasterEditionV2{Key: KeyMasterEditionV2})
+		_, err := DecodeMetadata(data)
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeEditions(t *testing.T) {
+	maxSupply := uint64(10)
+	master := MasterEditionV2{Key: KeyMasterEditionV2, Supply: 3, MaxSupply: &maxSupply}
+	gotMaster, err := DecodeMasterEdition(encodeBorshT(t, master))
+	require.NoError(t, err)
+	require.Equal(t, master, *gotMaster)
+
+	edition := Edition{
+		Key:     KeyEditionV1,
+		Parent:  solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F"),
+		Edition: 2,
+	}
+	gotEdition, err := DecodeEdition(encodeBorshT(t, edition))
+	require.NoError(t, err)
+	require.Equal(t, edition, *gotEdition)
+
+	_, err = DecodeEdition(encodeBorshT(t, master))
+	require.Error(t, err)
+	_, err = DecodeMasterEdition(encodeBorshT(t, edition))
+	require.Error(t, err)
+
+	obj, err := solana.DecodeAccount(ProgramID, encodeBorshT(t, master))
+	require.NoError(t, err)
+	require.Equal(t, gotMaster, obj)
+	obj, err = solana.DecodeAccount(ProgramID, encodeBorshT(t, edition))
+	require.NoError(t, err)
+	require.Equal(t, gotEdition, obj)
+}
@@ -0,0 +1,186 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The Metaplex Token Metadata program attaches additional data
+// (name, symbol, uri, creators, collection, editions) to SPL token mints.
+
+package tokenmetadata
+
+import (
+	"bytes"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_text "github.com/xmcontinue/solana-go/text"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.TokenMetadataProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+}
+
+const ProgramName = "TokenMetadata"
+
+func init() {
+	if !ProgramID.IsZero() {
+		ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+		registerAccountDecoders(ProgramID)
+	}
+}
+
+// NOTE: the program has many more (mostly deprecated) instructions;
+// only the ones supported by this package are listed here.
+const (
+	// Update a Metadata with is_mutable as a parameter.
+	Instruction_UpdateMetadataAccountV2 uint8 = 15
+
+	// Register a Metadata as a Master Edition V2, which means Edition V2s can be minted.
+	// Henceforth, no further tokens will be mintable from this primary mint.
+	// Will throw an error if more than one token exists, and will throw an error if less than one token exists in this primary mint.
+	Instruction_CreateMasterEditionV3 uint8 = 17
+
+	// If a MetadataAccount has a collection allowed field and the collection authority signs,
+	// the collection is verified.
+	Instruction_VerifyCollection uint8 = 18
+
+	// If a MetadataAccount has a verified collection,
+	// the collection authority can unverify it.
+	Instruction_UnverifyCollection uint8 = 22
+
+	// Create Metadata object.
+	Instruction_CreateMetadataAccountV3 uint8 = 33
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_UpdateMetadataAccountV2:
+		return "UpdateMetadataAccountV2"
+	case Instruction_CreateMasterEditionV3:
+		return "CreateMasterEditionV3"
+	case Instruction_VerifyCollection:
+		return "VerifyCollection"
+	case Instruction_UnverifyCollection:
+		return "UnverifyCollection"
+	case Instruction_CreateMetadataAccountV3:
+		return "CreateMetadataAccountV3"
+	default:
+		return ""
+	}
+}
+
+// newInstructionImpl returns a new instance of the instruction type for the given ID.
+// The instruction IDs of this program are not contiguous, so a variant definition
+// (which assigns IDs by position) cannot be used.
+func newInstructionImpl(id uint8) (interface{}, bool) {
+	switch id {
+	case Instruction_UpdateMetadataAccountV2:
+		return new(UpdateMetadataAccountV2), true
+	case Instruction_CreateMasterEditionV3:
+		return new(CreateMasterEditionV3), true
+	case Instruction_VerifyCollection:
+		return new(VerifyCollection), true
+	case Instruction_UnverifyCollection:
+		return new(UnverifyCollection), true
+	case Instruction_CreateMetadataAccountV3:
+		return new(CreateMetadataAccountV3), true
+	default:
+		return nil, false
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBorshEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	id, err := decoder.ReadUint8()
+	if err != nil {
+		return fmt.Errorf("unable to read variant type: %w", err)
+	}
+	impl, ok := newInstructionImpl(id)
+	if !ok {
+		return fmt.Errorf("unsupported instruction ID %d", id)
+	}
+	if err := decoder.Decode(impl); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", InstructionIDToName(id), err)
+	}
+	inst.TypeID = ag_binary.TypeIDFromUint8(id)
+	inst.Impl = impl
+	return nil
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBorshDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
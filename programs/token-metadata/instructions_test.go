@@ -0,0 +1,162 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xmcontinue/solana-go"
+)
+
+var (
+	testMint      = solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	testAuthority = solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	testPayer     = solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+)
+
+func decodeRoundTrip(t *testing.T, ix *Instruction) *Instruction {
+	data, err := ix.Data()
+	require.NoError(t, err)
+	decoded, err := DecodeInstruction(ix.Accounts(), data)
+	require.NoError(t, err)
+	require.Equal(t, ix.TypeID, decoded.TypeID)
+	require.Equal(t, ix.Accounts(), decoded.Accounts())
+	return decoded
+}
+
+func TestCreateMetadataAccountV3(t *testing.T) {
+	data := DataV2{
+		Name:                 "Grape",
+		Symbol:               "GRAPE",
+		Uri:                  "https://example.com/grape.json",
+		SellerFeeBasisPoints: 250,
+		Creators:             &[]Creator{{Address: testAuthority, Share: 100}},
+	}
+	builder, err := NewCreateMetadataAccountV3Instruction(data, true, testMint, testAuthority, testPayer, testAuthority, true)
+	require.NoError(t, err)
+	ix, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	metadata, _, err := solana.FindTokenMetadataAddress(testMint)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]*solana.AccountMeta{
+			solana.Meta(metadata).WRITE(),
+			solana.Meta(testMint),
+			solana.Meta(testAuthority).SIGNER(),
+			solana.Meta(testPayer).WRITE().SIGNER(),
+			solana.Meta(testAuthority).SIGNER(),
+			solana.Meta(solana.SystemProgramID),
+		},
+		ix.Accounts(),
+	)
+
+	raw, err := ix.Data()
+	require.NoError(t, err)
+	require.Equal(t, Instruction_CreateMetadataAccountV3, raw[0])
+	// name: u32 length + bytes
+	require.Equal(t, []byte{5, 0, 0, 0, 'G', 'r', 'a', 'p', 'e'}, raw[1:10])
+
+	got := decodeRoundTrip(t, ix).Impl.(*CreateMetadataAccountV3)
+	require.Equal(t, data, *got.Data)
+	require.True(t, *got.IsMutable)
+	require.Nil(t, got.CollectionDetails)
+
+	// Creator shares must add up to 100:
+	data.Creators = &[]Creator{{Address: testAuthority, Share: 50}}
+	builder.SetData(data)
+	_, err = builder.ValidateAndBuild()
+	require.Error(t, err)
+}
+
+func TestUpdateMetadataAccountV2(t *testing.T) {
+	metadata, _, err := solana.FindTokenMetadataAddress(testMint)
+	require.NoError(t, err)
+
+	ix, err := NewUpdateMetadataAccountV2Instruction(metadata, testAuthority).
+		SetUpdateAuthority(testPayer).
+		SetPrimarySaleHappened(true).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	raw, err := ix.Data()
+	require.NoError(t, err)
+	expected := []byte{Instruction_UpdateMetadataAccountV2, 0, 1}
+	expected = append(expected, testPayer[:]...)
+	expected = append(expected, 1, 1, 0)
+	require.Equal(t, expected, raw)
+
+	got := decodeRoundTrip(t, ix).Impl.(*UpdateMetadataAccountV2)
+	require.Nil(t, got.Data)
+	require.Equal(t, testPayer, *got.UpdateAuthority)
+	require.True(t, *got.PrimarySaleHappened)
+	require.Nil(t, got.IsMutable)
+}
+
+func TestCreateMasterEditionV3(t *testing.T) {
+	maxSupply := uint64(0)
+	builder, err := NewCreateMasterEditionV3Instruction(&maxSupply, testMint, testAuthority, testAuthority, testPayer)
+	require.NoError(t, err)
+	ix, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	raw, err := ix.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_CreateMasterEditionV3, 1, 0, 0, 0, 0, 0, 0, 0, 0}, raw)
+
+	edition, _, err := solana.FindTokenMasterEditionAddress(testMint)
+	require.NoError(t, err)
+	require.Equal(t, edition, builder.GetEditionAccount().PublicKey)
+	require.Len(t, ix.Accounts(), 8)
+
+	got := decodeRoundTrip(t, ix).Impl.(*CreateMasterEditionV3)
+	require.Equal(t, uint64(0), *got.MaxSupply)
+}
+
+func TestVerifyCollection(t *testing.T) {
+	collectionMint := solana.MustPublicKeyFromBase58("H7ATJQGhwG8Uf8sUntUognFpsKixPy2buFnXkvyNbGUb")
+
+	builder, err := NewVerifyCollectionInstruction(testMint, collectionMint, testAuthority, testPayer)
+	require.NoError(t, err)
+	ix, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	raw, err := ix.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_VerifyCollection}, raw)
+	require.Len(t, ix.Accounts(), 6)
+	decodeRoundTrip(t, ix)
+
+	builder.SetCollectionAuthorityRecordAccount(testPayer)
+	ix, err = builder.ValidateAndBuild()
+	require.NoError(t, err)
+	require.Len(t, ix.Accounts(), 7)
+
+	unverify, err := NewUnverifyCollectionInstruction(testMint, collectionMint, testAuthority)
+	require.NoError(t, err)
+	ix, err = unverify.ValidateAndBuild()
+	require.NoError(t, err)
+	require.Len(t, ix.Accounts(), 5)
+	raw, err = ix.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_UnverifyCollection}, raw)
+	decodeRoundTrip(t, ix)
+}
+
+func TestDecodeInstruction_Unsupported(t *testing.T) {
+	_, err := DecodeInstruction(nil, []byte{0})
+	require.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// FetchMetadata fetches and decodes the Metadata account of the provided mint.
+func FetchMetadata(ctx context.Context, rpcCli *rpc.Client, mint solana.PublicKey) (*Metadata, error) {
+	addr, _, err := solana.FindTokenMetadataAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find metadata address: %w", err)
+	}
+	acctInfo, err := rpcCli.GetAccountInfo(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get metadata account %s: %w", addr, err)
+	}
+	return DecodeMetadata(acctInfo.Value.Data.GetBinary())
+}
+
+// FetchMasterEdition fetches and decodes the MasterEditionV2 account of the provided mint.
+func FetchMasterEdition(ctx context.Context, rpcCli *rpc.Client, mint solana.PublicKey) (*MasterEditionV2, error) {
+	addr, _, err := solana.FindTokenMasterEditionAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find master edition address: %w", err)
+	}
+	acctInfo, err := rpcCli.GetAccountInfo(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get master edition account %s: %w", addr, err)
+	}
+	return DecodeMasterEdition(acctInfo.Value.Data.GetBinary())
+}
+
+// FetchEdition fetches and decodes the Edition account of the provided (printed edition) mint.
+func FetchEdition(ctx context.Context, rpcCli *rpc.Client, mint solana.PublicKey) (*Edition, error) {
+	addr, _, err := solana.FindTokenEditionAddress(mint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find edition address: %w", err)
+	}
+	acctInfo, err := rpcCli.GetAccountInfo(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get edition account %s: %w", addr, err)
+	}
+	return DecodeEdition(acctInfo.Value.Data.GetBinary())
+}
@@ -0,0 +1,248 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+// Key is the first byte of every account owned by the token metadata program,
+// and identifies the account type.
+type Key ag_binary.BorshEnum
+
+const (
+	KeyUninitialized Key = iota
+	KeyEditionV1
+	KeyMasterEditionV1
+	KeyReservationListV1
+	KeyMetadataV1
+	KeyReservationListV2
+	KeyMasterEditionV2
+	KeyEditionMarker
+	KeyUseAuthorityRecord
+	KeyCollectionAuthorityRecord
+	KeyTokenOwnedEscrow
+	KeyTokenRecord
+	KeyMetadataDelegate
+)
+
+type TokenStandard ag_binary.BorshEnum
+
+const (
+	// This is a master edition.
+	TokenStandardNonFungible TokenStandard = iota
+	// A token with metadata that can also have attributes, sometimes called Semi Fungible.
+	TokenStandardFungibleAsset
+	// A token with simple metadata.
+	TokenStandardFungible
+	// This is a limited edition.
+	TokenStandardNonFungibleEdition
+	// Non-fungible token with programmable configuration.
+	TokenStandardProgrammableNonFungible
+)
+
+func (ts TokenStandard) String() string {
+	switch ts {
+	case TokenStandardNonFungible:
+		return "NonFungible"
+	case TokenStandardFungibleAsset:
+		return "FungibleAsset"
+	case TokenStandardFungible:
+		return "Fungible"
+	case TokenStandardNonFungibleEdition:
+		return "NonFungibleEdition"
+	case TokenStandardProgrammableNonFungible:
+		return "ProgrammableNonFungible"
+	default:
+		return fmt.Sprintf("TokenStandard(%d)", ts)
+	}
+}
+
+type UseMethod ag_binary.BorshEnum
+
+const (
+	UseMethodBurn UseMethod = iota
+	UseMethodMultiple
+	UseMethodSingle
+)
+
+// Maximum lengths of the metadata strings.
+const (
+	MAX_NAME_LENGTH     = 32
+	MAX_SYMBOL_LENGTH   = 10
+	MAX_URI_LENGTH      = 200
+	MAX_CREATOR_LIMIT   = 5
+	MAX_BASIS_POINTS    = 10000
+	MAX_CREATOR_SHARE   = 100
+	MAX_METADATA_LENGTH = 679
+)
+
+type Creator struct {
+	Address  ag_solanago.PublicKey
+	Verified bool
+	// In percentages, NOT basis points.
+	Share uint8
+}
+
+type Collection struct {
+	Verified bool
+	Key      ag_solanago.PublicKey
+}
+
+type Uses struct {
+	UseMethod UseMethod
+	Remaining uint64
+	Total     uint64
+}
+
+// CollectionDetails is only set on collection parent NFTs (sized collections).
+type CollectionDetails struct {
+	// V1 is the only variant.
+	Size uint64
+}
+
+func (obj CollectionDetails) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Variant V1:
+	err = encoder.WriteUint8(0)
+	if err != nil {
+		return err
+	}
+	return encoder.WriteUint64(obj.Size, ag_binary.LE)
+}
+
+func (obj *CollectionDetails) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	variant, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if variant != 0 {
+		return fmt.Errorf("unknown CollectionDetails variant: %v", variant)
+	}
+	obj.Size, err = decoder.ReadUint64(ag_binary.LE)
+	return err
+}
+
+// ProgrammableConfig is only set on programmable NFTs.
+type ProgrammableConfig struct {
+	// V1 is the only variant.
+	RuleSet *ag_solanago.PublicKey `bin:"optional"`
+}
+
+func (obj ProgrammableConfig) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Variant V1:
+	err = encoder.WriteUint8(0)
+	if err != nil {
+		return err
+	}
+	err = encoder.WriteOption(obj.RuleSet != nil)
+	if err != nil {
+		return err
+	}
+	if obj.RuleSet != nil {
+		return encoder.WriteBytes(obj.RuleSet[:], false)
+	}
+	return nil
+}
+
+func (obj *ProgrammableConfig) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	variant, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if variant != 0 {
+		return fmt.Errorf("unknown ProgrammableConfig variant: %v", variant)
+	}
+	ok, err := decoder.ReadOption()
+	if err != nil {
+		return err
+	}
+	if ok {
+		buf, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.RuleSet = ag_solanago.PublicKeyFromBytes(buf).ToPointer()
+	}
+	return nil
+}
+
+// Data is the (V1) data stored in a Metadata account.
+type Data struct {
+	// The name of the asset.
+	Name string
+	// The symbol for the asset.
+	Symbol string
+	// URI pointing to JSON representing the asset.
+	Uri string
+	// Royalty basis points that goes to creators in secondary sales (0-10000).
+	SellerFeeBasisPoints uint16
+	// Array of creators, optional.
+	Creators *[]Creator `bin:"optional"`
+}
+
+// DataV2 is the data provided when creating or updating a Metadata account.
+type DataV2 struct {
+	// The name of the asset.
+	Name string
+	// The symbol for the asset.
+	Symbol string
+	// URI pointing to JSON representing the asset.
+	Uri string
+	// Royalty basis points that goes to creators in secondary sales (0-10000).
+	SellerFeeBasisPoints uint16
+	// Array of creators, optional.
+	Creators *[]Creator `bin:"optional"`
+	// Collection.
+	Collection *Collection `bin:"optional"`
+	// Uses.
+	Uses *Uses `bin:"optional"`
+}
+
+// Validate checks the data against the limits enforced by the program.
+func (data DataV2) Validate() error {
+	if len(data.Name) > MAX_NAME_LENGTH {
+		return fmt.Errorf("name too long; got %v, but max is %v", len(data.Name), MAX_NAME_LENGTH)
+	}
+	if len(data.Symbol) > MAX_SYMBOL_LENGTH {
+		return fmt.Errorf("symbol too long; got %v, but max is %v", len(data.Symbol), MAX_SYMBOL_LENGTH)
+	}
+	if len(data.Uri) > MAX_URI_LENGTH {
+		return fmt.Errorf("uri too long; got %v, but max is %v", len(data.Uri), MAX_URI_LENGTH)
+	}
+	if data.SellerFeeBasisPoints > MAX_BASIS_POINTS {
+		return fmt.Errorf("seller fee basis points too high; got %v, but max is %v", data.SellerFeeBasisPoints, MAX_BASIS_POINTS)
+	}
+	if data.Creators != nil {
+		creators := *data.Creators
+		if len(creators) > MAX_CREATOR_LIMIT {
+			return fmt.Errorf("too many creators; got %v, but max is %v", len(creators), MAX_CREATOR_LIMIT)
+		}
+		if len(creators) == 0 {
+			return errors.New("creators must be at least one if set")
+		}
+		total := 0
+		for _, creator := range creators {
+			total += int(creator.Share)
+		}
+		if total != MAX_CREATOR_SHARE {
+			return fmt.Errorf("creator shares must add up to %v; got %v", MAX_CREATOR_SHARE, total)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Deposit both types of tokens into the pool.  The output is a "pool"
+// token representing ownership in the pool. Inputs are converted to
+// the current ratio.
+type DepositAllTokenTypes struct {
+	// Pool token amount to transfer. token_a and token_b amount are set by
+	// the current exchange rate and size of the pool.
+	PoolTokenAmount *uint64
+
+	// Maximum token A amount to deposit, prevents excessive slippage.
+	MaximumTokenAAmount *uint64
+
+	// Maximum token B amount to deposit, prevents excessive slippage.
+	MaximumTokenBAmount *uint64
+
+	// [0] = [] swap
+	// ··········· Token-swap.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority.
+	//
+	// [2] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority.
+	//
+	// [3] = [WRITE] sourceA
+	// ··········· Token A user transfer authority can transfer amount.
+	//
+	// [4] = [WRITE] sourceB
+	// ··········· Token B user transfer authority can transfer amount.
+	//
+	// [5] = [WRITE] tokenA
+	// ··········· Token A base account to deposit into.
+	//
+	// [6] = [WRITE] tokenB
+	// ··········· Token B base account to deposit into.
+	//
+	// [7] = [WRITE] poolMint
+	// ··········· Pool mint account, swap authority is the owner.
+	//
+	// [8] = [WRITE] destination
+	// ··········· Pool account to deposit the generated tokens, user is the owner.
+	//
+	// [9] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewDepositAllTokenTypesInstructionBuilder creates a new `DepositAllTokenTypes` instruction builder.
+func NewDepositAllTokenTypesInstructionBuilder() *DepositAllTokenTypes {
+	nd := &DepositAllTokenTypes{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 10),
+	}
+	nd.AccountMetaSlice[9] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetPoolTokenAmount sets the "poolTokenAmount" parameter.
+// Pool token amount to transfer. token_a and token_b amount are set by
+// the current exchange rate and size of the pool.
+func (inst *DepositAllTokenTypes) SetPoolTokenAmount(poolTokenAmount uint64) *DepositAllTokenTypes {
+	inst.PoolTokenAmount = &poolTokenAmount
+	return inst
+}
+
+// SetMaximumTokenAAmount sets the "maximumTokenAAmount" parameter.
+// Maximum token A amount to deposit, prevents excessive slippage.
+func (inst *DepositAllTokenTypes) SetMaximumTokenAAmount(maximumTokenAAmount uint64) *DepositAllTokenTypes {
+	inst.MaximumTokenAAmount = &maximumTokenAAmount
+	return inst
+}
+
+// SetMaximumTokenBAmount sets the "maximumTokenBAmount" parameter.
+// Maximum token B amount to deposit, prevents excessive slippage.
+func (inst *DepositAllTokenTypes) SetMaximumTokenBAmount(maximumTokenBAmount uint64) *DepositAllTokenTypes {
+	inst.MaximumTokenBAmount = &maximumTokenBAmount
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// Token-swap.
+func (inst *DepositAllTokenTypes) SetSwapAccount(swap ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap)
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// Token-swap.
+func (inst *DepositAllTokenTypes) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority.
+func (inst *DepositAllTokenTypes) SetAuthorityAccount(authority ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority.
+func (inst *DepositAllTokenTypes) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *DepositAllTokenTypes) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *DepositAllTokenTypes) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetSourceAAccount sets the "sourceA" account.
+// Token A user transfer authority can transfer amount.
+func (inst *DepositAllTokenTypes) SetSourceAAccount(sourceA ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(sourceA).WRITE()
+	return inst
+}
+
+// GetSourceAAccount gets the "sourceA" account.
+// Token A user transfer authority can transfer amount.
+func (inst *DepositAllTokenTypes) GetSourceAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetSourceBAccount sets the "sourceB" account.
+// Token B user transfer authority can transfer amount.
+func (inst *DepositAllTokenTypes) SetSourceBAccount(sourceB ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(sourceB).WRITE()
+	return inst
+}
+
+// GetSourceBAccount gets the "sourceB" account.
+// Token B user transfer authority can transfer amount.
+func (inst *DepositAllTokenTypes) GetSourceBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetTokenAAccount sets the "tokenA" account.
+// Token A base account to deposit into.
+func (inst *DepositAllTokenTypes) SetTokenAAccount(tokenA ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(tokenA).WRITE()
+	return inst
+}
+
+// GetTokenAAccount gets the "tokenA" account.
+// Token A base account to deposit into.
+func (inst *DepositAllTokenTypes) GetTokenAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetTokenBAccount sets the "tokenB" account.
+// Token B base account to deposit into.
+func (inst *DepositAllTokenTypes) SetTokenBAccount(tokenB ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(tokenB).WRITE()
+	return inst
+}
+
+// GetTokenBAccount gets the "tokenB" account.
+// Token B base account to deposit into.
+func (inst *DepositAllTokenTypes) GetTokenBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *DepositAllTokenTypes) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *DepositAllTokenTypes) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetDestinationAccount sets the "destination" account.
+// Pool account to deposit the generated tokens, user is the owner.
+func (inst *DepositAllTokenTypes) SetDestinationAccount(destination ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// Pool account to deposit the generated tokens, user is the owner.
+func (inst *DepositAllTokenTypes) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *DepositAllTokenTypes) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *DepositAllTokenTypes {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *DepositAllTokenTypes) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+func (inst DepositAllTokenTypes) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_DepositAllTokenTypes),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst DepositAllTokenTypes) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *DepositAllTokenTypes) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.PoolTokenAmount == nil {
+			return errors.New("PoolTokenAmount parameter is not set")
+		}
+		if inst.MaximumTokenAAmount == nil {
+			return errors.New("MaximumTokenAAmount parameter is not set")
+		}
+		if inst.MaximumTokenBAmount == nil {
+			return errors.New("MaximumTokenBAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.SourceA is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.SourceB is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.TokenA is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.TokenB is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.Destination is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *DepositAllTokenTypes) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("DepositAllTokenTypes")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("    PoolTokenAmount", *inst.PoolTokenAmount))
+						paramsBranch.Child(ag_format.Param("MaximumTokenAAmount", *inst.MaximumTokenAAmount))
+						paramsBranch.Child(ag_format.Param("MaximumTokenBAmount", *inst.MaximumTokenBAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("            authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("              sourceA", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("              sourceB", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("               tokenA", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("               tokenB", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("             poolMint", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("          destination", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(9)))
+					})
+				})
+		})
+}
+
+func (obj DepositAllTokenTypes) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `PoolTokenAmount` param:
+	err = encoder.Encode(obj.PoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MaximumTokenAAmount` param:
+	err = encoder.Encode(obj.MaximumTokenAAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MaximumTokenBAmount` param:
+	err = encoder.Encode(obj.MaximumTokenBAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *DepositAllTokenTypes) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `PoolTokenAmount`:
+	err = decoder.Decode(&obj.PoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MaximumTokenAAmount`:
+	err = decoder.Decode(&obj.MaximumTokenAAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MaximumTokenBAmount`:
+	err = decoder.Decode(&obj.MaximumTokenBAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewDepositAllTokenTypesInstruction declares a new DepositAllTokenTypes instruction with the provided parameters and accounts.
+func NewDepositAllTokenTypesInstruction(
+	// Parameters:
+	poolTokenAmount uint64,
+	maximumTokenAAmount uint64,
+	maximumTokenBAmount uint64,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+	sourceA ag_solanago.PublicKey,
+	sourceB ag_solanago.PublicKey,
+	tokenA ag_solanago.PublicKey,
+	tokenB ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	destination ag_solanago.PublicKey,
+) *DepositAllTokenTypes {
+	return NewDepositAllTokenTypesInstructionBuilder().
+		SetPoolTokenAmount(poolTokenAmount).
+		SetMaximumTokenAAmount(maximumTokenAAmount).
+		SetMaximumTokenBAmount(maximumTokenBAmount).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetUserTransferAuthorityAccount(userTransferAuthority).
+		SetSourceAAccount(sourceA).
+		SetSourceBAccount(sourceB).
+		SetTokenAAccount(tokenA).
+		SetTokenBAccount(tokenB).
+		SetPoolMintAccount(poolMint).
+		SetDestinationAccount(destination)
+}
@@ -0,0 +1,352 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Deposit one type of tokens into the pool.  The output is a "pool" token
+// representing ownership into the pool. Input token is converted as if
+// a swap and deposit all token types were performed.
+type DepositSingleTokenTypeExactAmountIn struct {
+	// Token amount to deposit.
+	SourceTokenAmount *uint64
+
+	// Pool token amount to receive in exchange. The amount is set by
+	// the current exchange rate and size of the pool.
+	MinimumPoolTokenAmount *uint64
+
+	// [0] = [] swap
+	// ··········· Token-swap.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority.
+	//
+	// [2] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority.
+	//
+	// [3] = [WRITE] source
+	// ··········· Token (A|B) source account, amount is transferable by user transfer authority.
+	//
+	// [4] = [WRITE] swapTokenA
+	// ··········· Token A swap account, may deposit INTO.
+	//
+	// [5] = [WRITE] swapTokenB
+	// ··········· Token B swap account, may deposit INTO.
+	//
+	// [6] = [WRITE] poolMint
+	// ··········· Pool mint account, swap authority is the owner.
+	//
+	// [7] = [WRITE] destination
+	// ··········· Pool account to deposit the generated tokens, user is the owner.
+	//
+	// [8] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewDepositSingleTokenTypeExactAmountInInstructionBuilder creates a new `DepositSingleTokenTypeExactAmountIn` instruction builder.
+func NewDepositSingleTokenTypeExactAmountInInstructionBuilder() *DepositSingleTokenTypeExactAmountIn {
+	nd := &DepositSingleTokenTypeExactAmountIn{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 9),
+	}
+	nd.AccountMetaSlice[8] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetSourceTokenAmount sets the "sourceTokenAmount" parameter.
+// Token amount to deposit.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetSourceTokenAmount(sourceTokenAmount uint64) *DepositSingleTokenTypeExactAmountIn {
+	inst.SourceTokenAmount = &sourceTokenAmount
+	return inst
+}
+
+// SetMinimumPoolTokenAmount sets the "minimumPoolTokenAmount" parameter.
+// Pool token amount to receive in exchange. The amount is set by
+// the current exchange rate and size of the pool.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetMinimumPoolTokenAmount(minimumPoolTokenAmount uint64) *DepositSingleTokenTypeExactAmountIn {
+	inst.MinimumPoolTokenAmount = &minimumPoolTokenAmount
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// Token-swap.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetSwapAccount(swap ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap)
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// Token-swap.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetAuthorityAccount(authority ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetSourceAccount sets the "source" account.
+// Token (A|B) source account, amount is transferable by user transfer authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetSourceAccount(source ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// Token (A|B) source account, amount is transferable by user transfer authority.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetSourceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetSwapTokenAAccount sets the "swapTokenA" account.
+// Token A swap account, may deposit INTO.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetSwapTokenAAccount(swapTokenA ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(swapTokenA).WRITE()
+	return inst
+}
+
+// GetSwapTokenAAccount gets the "swapTokenA" account.
+// Token A swap account, may deposit INTO.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetSwapTokenAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetSwapTokenBAccount sets the "swapTokenB" account.
+// Token B swap account, may deposit INTO.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetSwapTokenBAccount(swapTokenB ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(swapTokenB).WRITE()
+	return inst
+}
+
+// GetSwapTokenBAccount gets the "swapTokenB" account.
+// Token B swap account, may deposit INTO.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetSwapTokenBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetDestinationAccount sets the "destination" account.
+// Pool account to deposit the generated tokens, user is the owner.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetDestinationAccount(destination ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// Pool account to deposit the generated tokens, user is the owner.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *DepositSingleTokenTypeExactAmountIn) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *DepositSingleTokenTypeExactAmountIn {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *DepositSingleTokenTypeExactAmountIn) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+func (inst DepositSingleTokenTypeExactAmountIn) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_DepositSingleTokenTypeExactAmountIn),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst DepositSingleTokenTypeExactAmountIn) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *DepositSingleTokenTypeExactAmountIn) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.SourceTokenAmount == nil {
+			return errors.New("SourceTokenAmount parameter is not set")
+		}
+		if inst.MinimumPoolTokenAmount == nil {
+			return errors.New("MinimumPoolTokenAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.Source is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.SwapTokenA is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.SwapTokenB is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.Destination is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *DepositSingleTokenTypeExactAmountIn) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("DepositSingleTokenTypeExactAmountIn")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("     SourceTokenAmount", *inst.SourceTokenAmount))
+						paramsBranch.Child(ag_format.Param("MinimumPoolTokenAmount", *inst.MinimumPoolTokenAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("            authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("               source", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("           swapTokenA", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("           swapTokenB", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("             poolMint", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("          destination", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(8)))
+					})
+				})
+		})
+}
+
+func (obj DepositSingleTokenTypeExactAmountIn) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `SourceTokenAmount` param:
+	err = encoder.Encode(obj.SourceTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MinimumPoolTokenAmount` param:
+	err = encoder.Encode(obj.MinimumPoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *DepositSingleTokenTypeExactAmountIn) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `SourceTokenAmount`:
+	err = decoder.Decode(&obj.SourceTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MinimumPoolTokenAmount`:
+	err = decoder.Decode(&obj.MinimumPoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewDepositSingleTokenTypeExactAmountInInstruction declares a new DepositSingleTokenTypeExactAmountIn instruction with the provided parameters and accounts.
+func NewDepositSingleTokenTypeExactAmountInInstruction(
+	// Parameters:
+	sourceTokenAmount uint64,
+	minimumPoolTokenAmount uint64,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+	source ag_solanago.PublicKey,
+	swapTokenA ag_solanago.PublicKey,
+	swapTokenB ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	destination ag_solanago.PublicKey,
+) *DepositSingleTokenTypeExactAmountIn {
+	return NewDepositSingleTokenTypeExactAmountInInstructionBuilder().
+		SetSourceTokenAmount(sourceTokenAmount).
+		SetMinimumPoolTokenAmount(minimumPoolTokenAmount).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetUserTransferAuthorityAccount(userTransferAuthority).
+		SetSourceAccount(source).
+		SetSwapTokenAAccount(swapTokenA).
+		SetSwapTokenBAccount(swapTokenB).
+		SetPoolMintAccount(poolMint).
+		SetDestinationAccount(destination)
+}
@@ -0,0 +1,354 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Initializes a new swap.
+type Initialize struct {
+	// Nonce used to create a valid program address.
+	Nonce *uint8
+
+	// All swap fees.
+	Fees *Fees
+
+	// Swap curve info for the pool, including the type of curve and the
+	// calculator parameters.
+	SwapCurve *SwapCurve
+
+	// [0] = [WRITE] swap
+	// ··········· New Token-swap to create.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority derived from `create_program_address(&[Token-swap account])`.
+	//
+	// [2] = [] tokenA
+	// ··········· Token A account; must be empty, not owned by the swap authority.
+	//
+	// [3] = [] tokenB
+	// ··········· Token B account; must be empty, not owned by the swap authority.
+	//
+	// [4] = [WRITE] poolMint
+	// ··········· Pool token mint; must be empty, owned by the swap authority.
+	//
+	// [5] = [] feeAccount
+	// ··········· Pool token account to deposit trading and withdraw fees.
+	//
+	// [6] = [WRITE] destination
+	// ··········· Pool token account to deposit the initial pool token supply.
+	//
+	// [7] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeInstructionBuilder creates a new `Initialize` instruction builder.
+func NewInitializeInstructionBuilder() *Initialize {
+	nd := &Initialize{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 8),
+	}
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetNonce sets the "nonce" parameter.
+// Nonce used to create a valid program address.
+func (inst *Initialize) SetNonce(nonce uint8) *Initialize {
+	inst.Nonce = &nonce
+	return inst
+}
+
+// SetFees sets the "fees" parameter.
+// All swap fees.
+func (inst *Initialize) SetFees(fees Fees) *Initialize {
+	inst.Fees = &fees
+	return inst
+}
+
+// SetSwapCurve sets the "swapCurve" parameter.
+// Swap curve info for the pool, including the type of curve and the
+// calculator parameters.
+func (inst *Initialize) SetSwapCurve(swapCurve SwapCurve) *Initialize {
+	inst.SwapCurve = &swapCurve
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// New Token-swap to create.
+func (inst *Initialize) SetSwapAccount(swap ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap).WRITE()
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// New Token-swap to create.
+func (inst *Initialize) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority derived from `create_program_address(&[Token-swap account])`.
+func (inst *Initialize) SetAuthorityAccount(authority ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority derived from `create_program_address(&[Token-swap account])`.
+func (inst *Initialize) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetTokenAAccount sets the "tokenA" account.
+// Token A account; must be empty, not owned by the swap authority.
+func (inst *Initialize) SetTokenAAccount(tokenA ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(tokenA)
+	return inst
+}
+
+// GetTokenAAccount gets the "tokenA" account.
+// Token A account; must be empty, not owned by the swap authority.
+func (inst *Initialize) GetTokenAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetTokenBAccount sets the "tokenB" account.
+// Token B account; must be empty, not owned by the swap authority.
+func (inst *Initialize) SetTokenBAccount(tokenB ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(tokenB)
+	return inst
+}
+
+// GetTokenBAccount gets the "tokenB" account.
+// Token B account; must be empty, not owned by the swap authority.
+func (inst *Initialize) GetTokenBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool token mint; must be empty, owned by the swap authority.
+func (inst *Initialize) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool token mint; must be empty, owned by the swap authority.
+func (inst *Initialize) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetFeeAccountAccount sets the "feeAccount" account.
+// Pool token account to deposit trading and withdraw fees.
+func (inst *Initialize) SetFeeAccountAccount(feeAccount ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(feeAccount)
+	return inst
+}
+
+// GetFeeAccountAccount gets the "feeAccount" account.
+// Pool token account to deposit trading and withdraw fees.
+func (inst *Initialize) GetFeeAccountAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetDestinationAccount sets the "destination" account.
+// Pool token account to deposit the initial pool token supply.
+func (inst *Initialize) SetDestinationAccount(destination ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// Pool token account to deposit the initial pool token supply.
+func (inst *Initialize) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *Initialize) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *Initialize) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+func (inst Initialize) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Initialize),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Initialize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Initialize) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Nonce == nil {
+			return errors.New("Nonce parameter is not set")
+		}
+		if inst.Fees == nil {
+			return errors.New("Fees parameter is not set")
+		}
+		if inst.SwapCurve == nil {
+			return errors.New("SwapCurve parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.TokenA is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.TokenB is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.FeeAccount is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.Destination is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *Initialize) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Initialize")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("    Nonce", *inst.Nonce))
+						paramsBranch.Child(ag_format.Param("     Fees", *inst.Fees))
+						paramsBranch.Child(ag_format.Param("SwapCurve", *inst.SwapCurve))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("        swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("   authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("      tokenA", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("      tokenB", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("    poolMint", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("  feeAccount", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta(" destination", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("tokenProgram", inst.AccountMetaSlice.Get(7)))
+					})
+				})
+		})
+}
+
+func (obj Initialize) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Nonce` param:
+	err = encoder.Encode(obj.Nonce)
+	if err != nil {
+		return err
+	}
+	// Serialize `Fees` param:
+	err = encoder.Encode(obj.Fees)
+	if err != nil {
+		return err
+	}
+	// Serialize `SwapCurve` param:
+	err = encoder.Encode(obj.SwapCurve)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Initialize) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Nonce`:
+	err = decoder.Decode(&obj.Nonce)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Fees`:
+	err = decoder.Decode(&obj.Fees)
+	if err != nil {
+		return err
+	}
+	// Deserialize `SwapCurve`:
+	err = decoder.Decode(&obj.SwapCurve)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeInstruction declares a new Initialize instruction with the provided parameters and accounts.
+func NewInitializeInstruction(
+	// Parameters:
+	nonce uint8,
+	fees Fees,
+	swapCurve SwapCurve,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	tokenA ag_solanago.PublicKey,
+	tokenB ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	feeAccount ag_solanago.PublicKey,
+	destination ag_solanago.PublicKey,
+) *Initialize {
+	return NewInitializeInstructionBuilder().
+		SetNonce(nonce).
+		SetFees(fees).
+		SetSwapCurve(swapCurve).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetTokenAAccount(tokenA).
+		SetTokenBAccount(tokenB).
+		SetPoolMintAccount(poolMint).
+		SetFeeAccountAccount(feeAccount).
+		SetDestinationAccount(destination)
+}
@@ -0,0 +1,387 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Swap the tokens in the pool.
+type Swap struct {
+	// Source amount to transfer, output to destination is based on the exchange rate.
+	AmountIn *uint64
+
+	// Minimum amount of destination token to output, prevents excessive slippage.
+	MinimumAmountOut *uint64
+
+	// [0] = [] swap
+	// ··········· Token-swap.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority.
+	//
+	// [2] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority.
+	//
+	// [3] = [WRITE] source
+	// ··········· Token (A|B) source account, amount is transferable by user transfer authority.
+	//
+	// [4] = [WRITE] swapSource
+	// ··········· Token (A|B) base account to swap into; must be the source token.
+	//
+	// [5] = [WRITE] swapDestination
+	// ··········· Token (A|B) base account to swap from; must be the destination token.
+	//
+	// [6] = [WRITE] destination
+	// ··········· Token (A|B) destination account assigned to the user.
+	//
+	// [7] = [WRITE] poolMint
+	// ··········· Pool token mint, to generate trading fees.
+	//
+	// [8] = [WRITE] poolFee
+	// ··········· Fee account, to receive trading fees.
+	//
+	// [9] = [] tokenProgram
+	// ··········· Token program id.
+	//
+	// [10] = [WRITE] hostFee
+	// ··········· Host fee account to receive additional trading fees (optional).
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSwapInstructionBuilder creates a new `Swap` instruction builder.
+func NewSwapInstructionBuilder() *Swap {
+	nd := &Swap{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 11),
+	}
+	nd.AccountMetaSlice[9] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetAmountIn sets the "amountIn" parameter.
+// Source amount to transfer, output to destination is based on the exchange rate.
+func (inst *Swap) SetAmountIn(amountIn uint64) *Swap {
+	inst.AmountIn = &amountIn
+	return inst
+}
+
+// SetMinimumAmountOut sets the "minimumAmountOut" parameter.
+// Minimum amount of destination token to output, prevents excessive slippage.
+func (inst *Swap) SetMinimumAmountOut(minimumAmountOut uint64) *Swap {
+	inst.MinimumAmountOut = &minimumAmountOut
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// Token-swap.
+func (inst *Swap) SetSwapAccount(swap ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap)
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// Token-swap.
+func (inst *Swap) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority.
+func (inst *Swap) SetAuthorityAccount(authority ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority.
+func (inst *Swap) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *Swap) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *Swap) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetSourceAccount sets the "source" account.
+// Token (A|B) source account, amount is transferable by user transfer authority.
+func (inst *Swap) SetSourceAccount(source ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// Token (A|B) source account, amount is transferable by user transfer authority.
+func (inst *Swap) GetSourceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetSwapSourceAccount sets the "swapSource" account.
+// Token (A|B) base account to swap into; must be the source token.
+func (inst *Swap) SetSwapSourceAccount(swapSource ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(swapSource).WRITE()
+	return inst
+}
+
+// GetSwapSourceAccount gets the "swapSource" account.
+// Token (A|B) base account to swap into; must be the source token.
+func (inst *Swap) GetSwapSourceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetSwapDestinationAccount sets the "swapDestination" account.
+// Token (A|B) base account to swap from; must be the destination token.
+func (inst *Swap) SetSwapDestinationAccount(swapDestination ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(swapDestination).WRITE()
+	return inst
+}
+
+// GetSwapDestinationAccount gets the "swapDestination" account.
+// Token (A|B) base account to swap from; must be the destination token.
+func (inst *Swap) GetSwapDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetDestinationAccount sets the "destination" account.
+// Token (A|B) destination account assigned to the user.
+func (inst *Swap) SetDestinationAccount(destination ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// Token (A|B) destination account assigned to the user.
+func (inst *Swap) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool token mint, to generate trading fees.
+func (inst *Swap) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool token mint, to generate trading fees.
+func (inst *Swap) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetPoolFeeAccount sets the "poolFee" account.
+// Fee account, to receive trading fees.
+func (inst *Swap) SetPoolFeeAccount(poolFee ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(poolFee).WRITE()
+	return inst
+}
+
+// GetPoolFeeAccount gets the "poolFee" account.
+// Fee account, to receive trading fees.
+func (inst *Swap) GetPoolFeeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *Swap) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *Swap) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+// SetHostFeeAccount sets the "hostFee" account.
+// Host fee account to receive additional trading fees (optional).
+func (inst *Swap) SetHostFeeAccount(hostFee ag_solanago.PublicKey) *Swap {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(hostFee).WRITE()
+	return inst
+}
+
+// GetHostFeeAccount gets the "hostFee" account.
+// Host fee account to receive additional trading fees (optional).
+func (inst *Swap) GetHostFeeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(10)
+}
+
+func (inst Swap) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Swap),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Swap) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Swap) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.AmountIn == nil {
+			return errors.New("AmountIn parameter is not set")
+		}
+		if inst.MinimumAmountOut == nil {
+			return errors.New("MinimumAmountOut parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.Source is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.SwapSource is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.SwapDestination is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.Destination is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.PoolFee is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *Swap) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Swap")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("        AmountIn", *inst.AmountIn))
+						paramsBranch.Child(ag_format.Param("MinimumAmountOut", *inst.MinimumAmountOut))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("            authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("               source", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("           swapSource", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("      swapDestination", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("          destination", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("             poolMint", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("              poolFee", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("              hostFee", inst.AccountMetaSlice.Get(10)))
+					})
+				})
+		})
+}
+
+func (obj Swap) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `AmountIn` param:
+	err = encoder.Encode(obj.AmountIn)
+	if err != nil {
+		return err
+	}
+	// Serialize `MinimumAmountOut` param:
+	err = encoder.Encode(obj.MinimumAmountOut)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Swap) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `AmountIn`:
+	err = decoder.Decode(&obj.AmountIn)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MinimumAmountOut`:
+	err = decoder.Decode(&obj.MinimumAmountOut)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewSwapInstruction declares a new Swap instruction with the provided parameters and accounts.
+func NewSwapInstruction(
+	// Parameters:
+	amountIn uint64,
+	minimumAmountOut uint64,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+	source ag_solanago.PublicKey,
+	swapSource ag_solanago.PublicKey,
+	swapDestination ag_solanago.PublicKey,
+	destination ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	poolFee ag_solanago.PublicKey,
+) *Swap {
+	return NewSwapInstructionBuilder().
+		SetAmountIn(amountIn).
+		SetMinimumAmountOut(minimumAmountOut).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetUserTransferAuthorityAccount(userTransferAuthority).
+		SetSourceAccount(source).
+		SetSwapSourceAccount(swapSource).
+		SetSwapDestinationAccount(swapDestination).
+		SetDestinationAccount(destination).
+		SetPoolMintAccount(poolMint).
+		SetPoolFeeAccount(poolFee)
+}
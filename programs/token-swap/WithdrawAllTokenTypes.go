@@ -0,0 +1,422 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Withdraw both types of tokens from the pool at the current ratio, given
+// pool tokens.  The pool tokens are burned in exchange for an equivalent
+// amount of token A and B.
+type WithdrawAllTokenTypes struct {
+	// Amount of pool tokens to burn. User receives an output of token a
+	// and b based on the percentage of the pool tokens that are returned.
+	PoolTokenAmount *uint64
+
+	// Minimum amount of token A to receive, prevents excessive slippage.
+	MinimumTokenAAmount *uint64
+
+	// Minimum amount of token B to receive, prevents excessive slippage.
+	MinimumTokenBAmount *uint64
+
+	// [0] = [] swap
+	// ··········· Token-swap.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority.
+	//
+	// [2] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority.
+	//
+	// [3] = [WRITE] poolMint
+	// ··········· Pool mint account, swap authority is the owner.
+	//
+	// [4] = [WRITE] source
+	// ··········· Source pool account, amount is transferable by user transfer authority.
+	//
+	// [5] = [WRITE] tokenA
+	// ··········· Token A swap account to withdraw from.
+	//
+	// [6] = [WRITE] tokenB
+	// ··········· Token B swap account to withdraw from.
+	//
+	// [7] = [WRITE] destinationA
+	// ··········· Token A user account to credit.
+	//
+	// [8] = [WRITE] destinationB
+	// ··········· Token B user account to credit.
+	//
+	// [9] = [WRITE] feeAccount
+	// ··········· Fee account, to receive withdrawal fees.
+	//
+	// [10] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWithdrawAllTokenTypesInstructionBuilder creates a new `WithdrawAllTokenTypes` instruction builder.
+func NewWithdrawAllTokenTypesInstructionBuilder() *WithdrawAllTokenTypes {
+	nd := &WithdrawAllTokenTypes{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 11),
+	}
+	nd.AccountMetaSlice[10] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetPoolTokenAmount sets the "poolTokenAmount" parameter.
+// Amount of pool tokens to burn. User receives an output of token a
+// and b based on the percentage of the pool tokens that are returned.
+func (inst *WithdrawAllTokenTypes) SetPoolTokenAmount(poolTokenAmount uint64) *WithdrawAllTokenTypes {
+	inst.PoolTokenAmount = &poolTokenAmount
+	return inst
+}
+
+// SetMinimumTokenAAmount sets the "minimumTokenAAmount" parameter.
+// Minimum amount of token A to receive, prevents excessive slippage.
+func (inst *WithdrawAllTokenTypes) SetMinimumTokenAAmount(minimumTokenAAmount uint64) *WithdrawAllTokenTypes {
+	inst.MinimumTokenAAmount = &minimumTokenAAmount
+	return inst
+}
+
+// SetMinimumTokenBAmount sets the "minimumTokenBAmount" parameter.
+// Minimum amount of token B to receive, prevents excessive slippage.
+func (inst *WithdrawAllTokenTypes) SetMinimumTokenBAmount(minimumTokenBAmount uint64) *WithdrawAllTokenTypes {
+	inst.MinimumTokenBAmount = &minimumTokenBAmount
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// Token-swap.
+func (inst *WithdrawAllTokenTypes) SetSwapAccount(swap ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap)
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// Token-swap.
+func (inst *WithdrawAllTokenTypes) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority.
+func (inst *WithdrawAllTokenTypes) SetAuthorityAccount(authority ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority.
+func (inst *WithdrawAllTokenTypes) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *WithdrawAllTokenTypes) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *WithdrawAllTokenTypes) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *WithdrawAllTokenTypes) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *WithdrawAllTokenTypes) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetSourceAccount sets the "source" account.
+// Source pool account, amount is transferable by user transfer authority.
+func (inst *WithdrawAllTokenTypes) SetSourceAccount(source ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// Source pool account, amount is transferable by user transfer authority.
+func (inst *WithdrawAllTokenTypes) GetSourceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetTokenAAccount sets the "tokenA" account.
+// Token A swap account to withdraw from.
+func (inst *WithdrawAllTokenTypes) SetTokenAAccount(tokenA ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(tokenA).WRITE()
+	return inst
+}
+
+// GetTokenAAccount gets the "tokenA" account.
+// Token A swap account to withdraw from.
+func (inst *WithdrawAllTokenTypes) GetTokenAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetTokenBAccount sets the "tokenB" account.
+// Token B swap account to withdraw from.
+func (inst *WithdrawAllTokenTypes) SetTokenBAccount(tokenB ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(tokenB).WRITE()
+	return inst
+}
+
+// GetTokenBAccount gets the "tokenB" account.
+// Token B swap account to withdraw from.
+func (inst *WithdrawAllTokenTypes) GetTokenBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetDestinationAAccount sets the "destinationA" account.
+// Token A user account to credit.
+func (inst *WithdrawAllTokenTypes) SetDestinationAAccount(destinationA ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(destinationA).WRITE()
+	return inst
+}
+
+// GetDestinationAAccount gets the "destinationA" account.
+// Token A user account to credit.
+func (inst *WithdrawAllTokenTypes) GetDestinationAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetDestinationBAccount sets the "destinationB" account.
+// Token B user account to credit.
+func (inst *WithdrawAllTokenTypes) SetDestinationBAccount(destinationB ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(destinationB).WRITE()
+	return inst
+}
+
+// GetDestinationBAccount gets the "destinationB" account.
+// Token B user account to credit.
+func (inst *WithdrawAllTokenTypes) GetDestinationBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetFeeAccountAccount sets the "feeAccount" account.
+// Fee account, to receive withdrawal fees.
+func (inst *WithdrawAllTokenTypes) SetFeeAccountAccount(feeAccount ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(feeAccount).WRITE()
+	return inst
+}
+
+// GetFeeAccountAccount gets the "feeAccount" account.
+// Fee account, to receive withdrawal fees.
+func (inst *WithdrawAllTokenTypes) GetFeeAccountAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawAllTokenTypes) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *WithdrawAllTokenTypes {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawAllTokenTypes) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(10)
+}
+
+func (inst WithdrawAllTokenTypes) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_WithdrawAllTokenTypes),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst WithdrawAllTokenTypes) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *WithdrawAllTokenTypes) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.PoolTokenAmount == nil {
+			return errors.New("PoolTokenAmount parameter is not set")
+		}
+		if inst.MinimumTokenAAmount == nil {
+			return errors.New("MinimumTokenAAmount parameter is not set")
+		}
+		if inst.MinimumTokenBAmount == nil {
+			return errors.New("MinimumTokenBAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.Source is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.TokenA is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.TokenB is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.DestinationA is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.DestinationB is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.FeeAccount is not set")
+		}
+		if inst.AccountMetaSlice[10] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *WithdrawAllTokenTypes) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("WithdrawAllTokenTypes")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("    PoolTokenAmount", *inst.PoolTokenAmount))
+						paramsBranch.Child(ag_format.Param("MinimumTokenAAmount", *inst.MinimumTokenAAmount))
+						paramsBranch.Child(ag_format.Param("MinimumTokenBAmount", *inst.MinimumTokenBAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("            authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("             poolMint", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("               source", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("               tokenA", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("               tokenB", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("         destinationA", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("         destinationB", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("           feeAccount", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(10)))
+					})
+				})
+		})
+}
+
+func (obj WithdrawAllTokenTypes) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `PoolTokenAmount` param:
+	err = encoder.Encode(obj.PoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MinimumTokenAAmount` param:
+	err = encoder.Encode(obj.MinimumTokenAAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MinimumTokenBAmount` param:
+	err = encoder.Encode(obj.MinimumTokenBAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *WithdrawAllTokenTypes) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `PoolTokenAmount`:
+	err = decoder.Decode(&obj.PoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MinimumTokenAAmount`:
+	err = decoder.Decode(&obj.MinimumTokenAAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MinimumTokenBAmount`:
+	err = decoder.Decode(&obj.MinimumTokenBAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewWithdrawAllTokenTypesInstruction declares a new WithdrawAllTokenTypes instruction with the provided parameters and accounts.
+func NewWithdrawAllTokenTypesInstruction(
+	// Parameters:
+	poolTokenAmount uint64,
+	minimumTokenAAmount uint64,
+	minimumTokenBAmount uint64,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	source ag_solanago.PublicKey,
+	tokenA ag_solanago.PublicKey,
+	tokenB ag_solanago.PublicKey,
+	destinationA ag_solanago.PublicKey,
+	destinationB ag_solanago.PublicKey,
+	feeAccount ag_solanago.PublicKey,
+) *WithdrawAllTokenTypes {
+	return NewWithdrawAllTokenTypesInstructionBuilder().
+		SetPoolTokenAmount(poolTokenAmount).
+		SetMinimumTokenAAmount(minimumTokenAAmount).
+		SetMinimumTokenBAmount(minimumTokenBAmount).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetUserTransferAuthorityAccount(userTransferAuthority).
+		SetPoolMintAccount(poolMint).
+		SetSourceAccount(source).
+		SetTokenAAccount(tokenA).
+		SetTokenBAccount(tokenB).
+		SetDestinationAAccount(destinationA).
+		SetDestinationBAccount(destinationB).
+		SetFeeAccountAccount(feeAccount)
+}
@@ -0,0 +1,373 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_format "github.com/xmcontinue/solana-go/text/format"
+)
+
+// Withdraw one token type from the pool at the current ratio given the
+// exact amount out expected.
+type WithdrawSingleTokenTypeExactAmountOut struct {
+	// Amount of token A or B to receive.
+	DestinationTokenAmount *uint64
+
+	// Maximum amount of pool tokens to burn. User receives an output of token A
+	// or B based on the percentage of the pool tokens that are returned.
+	MaximumPoolTokenAmount *uint64
+
+	// [0] = [] swap
+	// ··········· Token-swap.
+	//
+	// [1] = [] authority
+	// ··········· Swap authority.
+	//
+	// [2] = [SIGNER] userTransferAuthority
+	// ··········· User transfer authority.
+	//
+	// [3] = [WRITE] poolMint
+	// ··········· Pool mint account, swap authority is the owner.
+	//
+	// [4] = [WRITE] source
+	// ··········· Source pool account, amount is transferable by user transfer authority.
+	//
+	// [5] = [WRITE] swapTokenA
+	// ··········· Token A swap account to withdraw from.
+	//
+	// [6] = [WRITE] swapTokenB
+	// ··········· Token B swap account to withdraw from.
+	//
+	// [7] = [WRITE] destination
+	// ··········· Token (A|B) user account to credit.
+	//
+	// [8] = [WRITE] feeAccount
+	// ··········· Fee account, to receive withdrawal fees.
+	//
+	// [9] = [] tokenProgram
+	// ··········· Token program id.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWithdrawSingleTokenTypeExactAmountOutInstructionBuilder creates a new `WithdrawSingleTokenTypeExactAmountOut` instruction builder.
+func NewWithdrawSingleTokenTypeExactAmountOutInstructionBuilder() *WithdrawSingleTokenTypeExactAmountOut {
+	nd := &WithdrawSingleTokenTypeExactAmountOut{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 10),
+	}
+	nd.AccountMetaSlice[9] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// SetDestinationTokenAmount sets the "destinationTokenAmount" parameter.
+// Amount of token A or B to receive.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetDestinationTokenAmount(destinationTokenAmount uint64) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.DestinationTokenAmount = &destinationTokenAmount
+	return inst
+}
+
+// SetMaximumPoolTokenAmount sets the "maximumPoolTokenAmount" parameter.
+// Maximum amount of pool tokens to burn. User receives an output of token A
+// or B based on the percentage of the pool tokens that are returned.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetMaximumPoolTokenAmount(maximumPoolTokenAmount uint64) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.MaximumPoolTokenAmount = &maximumPoolTokenAmount
+	return inst
+}
+
+// SetSwapAccount sets the "swap" account.
+// Token-swap.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetSwapAccount(swap ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(swap)
+	return inst
+}
+
+// GetSwapAccount gets the "swap" account.
+// Token-swap.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetSwapAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Swap authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetAuthorityAccount(authority ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Swap authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetUserTransferAuthorityAccount sets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetUserTransferAuthorityAccount(userTransferAuthority ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(userTransferAuthority).SIGNER()
+	return inst
+}
+
+// GetUserTransferAuthorityAccount gets the "userTransferAuthority" account.
+// User transfer authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetUserTransferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// SetPoolMintAccount sets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetPoolMintAccount(poolMint ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(poolMint).WRITE()
+	return inst
+}
+
+// GetPoolMintAccount gets the "poolMint" account.
+// Pool mint account, swap authority is the owner.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetPoolMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+// SetSourceAccount sets the "source" account.
+// Source pool account, amount is transferable by user transfer authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetSourceAccount(source ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// Source pool account, amount is transferable by user transfer authority.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetSourceAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+// SetSwapTokenAAccount sets the "swapTokenA" account.
+// Token A swap account to withdraw from.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetSwapTokenAAccount(swapTokenA ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(swapTokenA).WRITE()
+	return inst
+}
+
+// GetSwapTokenAAccount gets the "swapTokenA" account.
+// Token A swap account to withdraw from.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetSwapTokenAAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(5)
+}
+
+// SetSwapTokenBAccount sets the "swapTokenB" account.
+// Token B swap account to withdraw from.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetSwapTokenBAccount(swapTokenB ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(swapTokenB).WRITE()
+	return inst
+}
+
+// GetSwapTokenBAccount gets the "swapTokenB" account.
+// Token B swap account to withdraw from.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetSwapTokenBAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+// SetDestinationAccount sets the "destination" account.
+// Token (A|B) user account to credit.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetDestinationAccount(destination ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// Token (A|B) user account to credit.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(7)
+}
+
+// SetFeeAccountAccount sets the "feeAccount" account.
+// Fee account, to receive withdrawal fees.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetFeeAccountAccount(feeAccount ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(feeAccount).WRITE()
+	return inst
+}
+
+// GetFeeAccountAccount gets the "feeAccount" account.
+// Fee account, to receive withdrawal fees.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetFeeAccountAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(8)
+}
+
+// SetTokenProgramAccount sets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) SetTokenProgramAccount(tokenProgram ag_solanago.PublicKey) *WithdrawSingleTokenTypeExactAmountOut {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+// GetTokenProgramAccount gets the "tokenProgram" account.
+// Token program id.
+func (inst *WithdrawSingleTokenTypeExactAmountOut) GetTokenProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(9)
+}
+
+func (inst WithdrawSingleTokenTypeExactAmountOut) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_WithdrawSingleTokenTypeExactAmountOut),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst WithdrawSingleTokenTypeExactAmountOut) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *WithdrawSingleTokenTypeExactAmountOut) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.DestinationTokenAmount == nil {
+			return errors.New("DestinationTokenAmount parameter is not set")
+		}
+		if inst.MaximumPoolTokenAmount == nil {
+			return errors.New("MaximumPoolTokenAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return fmt.Errorf("accounts.Swap is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return fmt.Errorf("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return fmt.Errorf("accounts.UserTransferAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return fmt.Errorf("accounts.PoolMint is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return fmt.Errorf("accounts.Source is not set")
+		}
+		if inst.AccountMetaSlice[5] == nil {
+			return fmt.Errorf("accounts.SwapTokenA is not set")
+		}
+		if inst.AccountMetaSlice[6] == nil {
+			return fmt.Errorf("accounts.SwapTokenB is not set")
+		}
+		if inst.AccountMetaSlice[7] == nil {
+			return fmt.Errorf("accounts.Destination is not set")
+		}
+		if inst.AccountMetaSlice[8] == nil {
+			return fmt.Errorf("accounts.FeeAccount is not set")
+		}
+		if inst.AccountMetaSlice[9] == nil {
+			return fmt.Errorf("accounts.TokenProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *WithdrawSingleTokenTypeExactAmountOut) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("WithdrawSingleTokenTypeExactAmountOut")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("DestinationTokenAmount", *inst.DestinationTokenAmount))
+						paramsBranch.Child(ag_format.Param("MaximumPoolTokenAmount", *inst.MaximumPoolTokenAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 swap", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("            authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("userTransferAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("             poolMint", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("               source", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("           swapTokenA", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("           swapTokenB", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("          destination", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("           feeAccount", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("         tokenProgram", inst.AccountMetaSlice.Get(9)))
+					})
+				})
+		})
+}
+
+func (obj WithdrawSingleTokenTypeExactAmountOut) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `DestinationTokenAmount` param:
+	err = encoder.Encode(obj.DestinationTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Serialize `MaximumPoolTokenAmount` param:
+	err = encoder.Encode(obj.MaximumPoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *WithdrawSingleTokenTypeExactAmountOut) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `DestinationTokenAmount`:
+	err = decoder.Decode(&obj.DestinationTokenAmount)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MaximumPoolTokenAmount`:
+	err = decoder.Decode(&obj.MaximumPoolTokenAmount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewWithdrawSingleTokenTypeExactAmountOutInstruction declares a new WithdrawSingleTokenTypeExactAmountOut instruction with the provided parameters and accounts.
+func NewWithdrawSingleTokenTypeExactAmountOutInstruction(
+	// Parameters:
+	destinationTokenAmount uint64,
+	maximumPoolTokenAmount uint64,
+	// Accounts:
+	swap ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	userTransferAuthority ag_solanago.PublicKey,
+	poolMint ag_solanago.PublicKey,
+	source ag_solanago.PublicKey,
+	swapTokenA ag_solanago.PublicKey,
+	swapTokenB ag_solanago.PublicKey,
+	destination ag_solanago.PublicKey,
+	feeAccount ag_solanago.PublicKey,
+) *WithdrawSingleTokenTypeExactAmountOut {
+	return NewWithdrawSingleTokenTypeExactAmountOutInstructionBuilder().
+		SetDestinationTokenAmount(destinationTokenAmount).
+		SetMaximumPoolTokenAmount(maximumPoolTokenAmount).
+		SetSwapAccount(swap).
+		SetAuthorityAccount(authority).
+		SetUserTransferAuthorityAccount(userTransferAuthority).
+		SetPoolMintAccount(poolMint).
+		SetSourceAccount(source).
+		SetSwapTokenAAccount(swapTokenA).
+		SetSwapTokenBAccount(swapTokenB).
+		SetDestinationAccount(destination).
+		SetFeeAccountAccount(feeAccount)
+}
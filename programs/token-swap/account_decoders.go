@@ -0,0 +1,37 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+func init() {
+	if !ProgramID.IsZero() {
+		registerAccountDecoders(ProgramID)
+	}
+}
+
+func registerAccountDecoders(programID ag_solanago.PublicKey) {
+	ag_solanago.RegisterAccountDecoder(programID, ag_solanago.AccountLayout{
+		Name:   "SwapV1",
+		Size:   SWAP_SIZE,
+		Decode: decodeSwapAccount,
+	})
+}
+
+func decodeSwapAccount(data []byte) (interface{}, error) {
+	return DecodeSwap(data)
+}
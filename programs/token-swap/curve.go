@@ -0,0 +1,380 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrCalculationFailure is returned when a curve calculation overflows,
+	// underflows, divides by zero or produces a zero output.
+	ErrCalculationFailure = errors.New("calculation failure")
+	// ErrUnsupportedCurve is returned for unknown curve types.
+	ErrUnsupportedCurve = errors.New("unsupported curve type")
+)
+
+// Number of coins in a stable swap pool.
+const stableNCoins = 2
+
+// Maximum number of Newton iterations of the stable curve calculations.
+const stableIterations = 32
+
+var maxUint64 = new(big.Int).SetUint64(^uint64(0))
+
+// SwapResult is the result of a swap calculation.
+type SwapResult struct {
+	// New amount of source token.
+	NewSwapSourceAmount uint64
+	// New amount of destination token.
+	NewSwapDestinationAmount uint64
+	// Amount of source token swapped (includes fees).
+	SourceAmountSwapped uint64
+	// Amount of destination token swapped.
+	DestinationAmountSwapped uint64
+	// Amount of source tokens going to pool holders.
+	TradeFee uint64
+	// Amount of source tokens going to owner.
+	OwnerFee uint64
+}
+
+// calculateFee computes the fee of amount as numerator/denominator,
+// with a minimum of 1 if the fee is not zero.
+func calculateFee(amount, numerator, denominator uint64) (uint64, error) {
+	if numerator == 0 || amount == 0 {
+		return 0, nil
+	}
+	if denominator == 0 {
+		return 0, ErrCalculationFailure
+	}
+	fee := new(big.Int).Mul(u(amount), u(numerator))
+	fee.Quo(fee, u(denominator))
+	if fee.Sign() == 0 {
+		return 1, nil
+	}
+	return toUint64(fee)
+}
+
+// TradingFee returns the trading fee owed to the pool on the given amount.
+func (fees Fees) TradingFee(amount uint64) (uint64, error) {
+	return calculateFee(amount, fees.TradeFeeNumerator, fees.TradeFeeDenominator)
+}
+
+// OwnerTradingFee returns the trading fee owed to the owner on the given amount.
+func (fees Fees) OwnerTradingFee(amount uint64) (uint64, error) {
+	return calculateFee(amount, fees.OwnerTradeFeeNumerator, fees.OwnerTradeFeeDenominator)
+}
+
+// OwnerWithdrawFee returns the fee, in pool tokens, owed to the owner
+// when withdrawing the given amount of pool tokens.
+func (fees Fees) OwnerWithdrawFee(poolTokens uint64) (uint64, error) {
+	return calculateFee(poolTokens, fees.OwnerWithdrawFeeNumerator, fees.OwnerWithdrawFeeDenominator)
+}
+
+// HostFee returns the part of the owner fee that goes to the host.
+func (fees Fees) HostFee(ownerFee uint64) (uint64, error) {
+	return calculateFee(ownerFee, fees.HostFeeNumerator, fees.HostFeeDenominator)
+}
+
+// Swap calculates the result of swapping sourceAmount tokens into a pool
+// holding swapSourceAmount of the source token and swapDestinationAmount
+// of the destination token, including fees.
+//
+// The direction tells which of the pool tokens is the source: the
+// ConstantPrice and Offset curves treat token A and token B differently.
+func (curve SwapCurve) Swap(
+	sourceAmount uint64,
+	swapSourceAmount uint64,
+	swapDestinationAmount uint64,
+	direction TradeDirection,
+	fees Fees,
+) (*SwapResult, error) {
+	tradeFee, err := fees.TradingFee(sourceAmount)
+	if err != nil {
+		return nil, err
+	}
+	ownerFee, err := fees.OwnerTradingFee(sourceAmount)
+	if err != nil {
+		return nil, err
+	}
+	totalFees := tradeFee + ownerFee
+	if totalFees < tradeFee || totalFees > sourceAmount {
+		return nil, ErrCalculationFailure
+	}
+
+	var sourceSwapped, destinationSwapped *big.Int
+	switch curve.CurveType {
+	case CurveTypeConstantProduct:
+		sourceSwapped, destinationSwapped, err = constantProductSwap(
+			u(sourceAmount-totalFees),
+			u(swapSourceAmount),
+			u(swapDestinationAmount),
+		)
+	case CurveTypeConstantPrice:
+		sourceSwapped, destinationSwapped, err = constantPriceSwap(
+			curve.Parameter,
+			u(sourceAmount-totalFees),
+			direction,
+		)
+	case CurveTypeStable:
+		sourceSwapped, destinationSwapped, err = stableSwap(
+			curve.Parameter,
+			u(sourceAmount-totalFees),
+			u(swapSourceAmount),
+			u(swapDestinationAmount),
+		)
+	case CurveTypeOffset:
+		sourceSwapped, destinationSwapped, err = offsetSwap(
+			curve.Parameter,
+			u(sourceAmount-totalFees),
+			u(swapSourceAmount),
+			u(swapDestinationAmount),
+			direction,
+		)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve.CurveType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sourceSwapped.Add(sourceSwapped, u(totalFees))
+	newSource := new(big.Int).Add(u(swapSourceAmount), sourceSwapped)
+	newDestination := new(big.Int).Sub(u(swapDestinationAmount), destinationSwapped)
+
+	out := &SwapResult{
+		TradeFee: tradeFee,
+		OwnerFee: ownerFee,
+	}
+	if out.NewSwapSourceAmount, err = toUint64(newSource); err != nil {
+		return nil, err
+	}
+	if out.NewSwapDestinationAmount, err = toUint64(newDestination); err != nil {
+		return nil, err
+	}
+	if out.SourceAmountSwapped, err = toUint64(sourceSwapped); err != nil {
+		return nil, err
+	}
+	if out.DestinationAmountSwapped, err = toUint64(destinationSwapped); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Quote calculates the result of swapping amountIn tokens with this pool,
+// given the current balances of its source and destination token accounts.
+func (swap *SwapV1) Quote(
+	amountIn uint64,
+	swapSourceAmount uint64,
+	swapDestinationAmount uint64,
+	direction TradeDirection,
+) (*SwapResult, error) {
+	return swap.SwapCurve.Swap(amountIn, swapSourceAmount, swapDestinationAmount, direction, swap.Fees)
+}
+
+// constantProductSwap calculates a swap with the Uniswap-style constant
+// product curve, rounding against the swapper.
+func constantProductSwap(sourceAmount, swapSourceAmount, swapDestinationAmount *big.Int) (*big.Int, *big.Int, error) {
+	invariant := new(big.Int).Mul(swapSourceAmount, swapDestinationAmount)
+	newSwapSourceAmount := new(big.Int).Add(swapSourceAmount, sourceAmount)
+	newSwapDestinationAmount, newSwapSourceAmount, err := ceilDiv(invariant, newSwapSourceAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceSwapped := new(big.Int).Sub(newSwapSourceAmount, swapSourceAmount)
+	destinationSwapped := new(big.Int).Sub(swapDestinationAmount, newSwapDestinationAmount)
+	if sourceSwapped.Sign() < 0 || destinationSwapped.Sign() < 0 {
+		return nil, nil, ErrCalculationFailure
+	}
+	return sourceSwapped, destinationSwapped, nil
+}
+
+// constantPriceSwap calculates a swap with the constant price curve,
+// where one token B is worth tokenBPrice tokens A. When buying token B,
+// the source amount is floored to a multiple of the price so that the
+// swapper does not pay for a fraction of a token.
+func constantPriceSwap(tokenBPrice uint64, sourceAmount *big.Int, direction TradeDirection) (*big.Int, *big.Int, error) {
+	if tokenBPrice == 0 {
+		return nil, nil, ErrCalculationFailure
+	}
+	price := u(tokenBPrice)
+	switch direction {
+	case TradeDirectionAtoB:
+		destinationSwapped, remainder := new(big.Int).QuoRem(sourceAmount, price, new(big.Int))
+		return new(big.Int).Sub(sourceAmount, remainder), destinationSwapped, nil
+	case TradeDirectionBtoA:
+		return new(big.Int).Set(sourceAmount), new(big.Int).Mul(sourceAmount, price), nil
+	default:
+		return nil, nil, fmt.Errorf("invalid trade direction %d", direction)
+	}
+}
+
+// offsetSwap calculates a swap with the offset curve: a constant product
+// curve where tokenBOffset is added to the token B side of the pool.
+func offsetSwap(tokenBOffset uint64, sourceAmount, swapSourceAmount, swapDestinationAmount *big.Int, direction TradeDirection) (*big.Int, *big.Int, error) {
+	offset := u(tokenBOffset)
+	switch direction {
+	case TradeDirectionAtoB:
+		swapDestinationAmount = new(big.Int).Add(swapDestinationAmount, offset)
+	case TradeDirectionBtoA:
+		swapSourceAmount = new(big.Int).Add(swapSourceAmount, offset)
+	default:
+		return nil, nil, fmt.Errorf("invalid trade direction %d", direction)
+	}
+	return constantProductSwap(sourceAmount, swapSourceAmount, swapDestinationAmount)
+}
+
+// ceilDiv divides, rounding the quotient up and adjusting the divisor
+// to the smallest value that yields the rounded-up quotient.
+func ceilDiv(dividend, divisor *big.Int) (*big.Int, *big.Int, error) {
+	if divisor.Sign() == 0 {
+		return nil, nil, ErrCalculationFailure
+	}
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+	if quotient.Sign() == 0 {
+		return nil, nil, ErrCalculationFailure
+	}
+	divisor = new(big.Int).Set(divisor)
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+		divisor.QuoRem(dividend, quotient, remainder)
+		if remainder.Sign() > 0 {
+			divisor.Add(divisor, big.NewInt(1))
+		}
+	}
+	return quotient, divisor, nil
+}
+
+// stableSwap calculates a swap with the StableSwap curve,
+// with the provided amplification coefficient.
+func stableSwap(amp uint64, sourceAmount, swapSourceAmount, swapDestinationAmount *big.Int) (*big.Int, *big.Int, error) {
+	if sourceAmount.Sign() == 0 {
+		return new(big.Int), new(big.Int), nil
+	}
+	leverage := new(big.Int).Mul(u(amp), big.NewInt(stableNCoins))
+	newSourceAmount := new(big.Int).Add(swapSourceAmount, sourceAmount)
+	d, err := stableComputeD(leverage, swapSourceAmount, swapDestinationAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	newDestinationAmount, err := stableComputeNewDestinationAmount(leverage, newSourceAmount, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	destinationSwapped := new(big.Int).Sub(swapDestinationAmount, newDestinationAmount)
+	if destinationSwapped.Sign() < 0 {
+		return nil, nil, ErrCalculationFailure
+	}
+	return new(big.Int).Set(sourceAmount), destinationSwapped, nil
+}
+
+// stableComputeD computes the stable swap invariant (D),
+// iterating with Newton's method until convergence.
+func stableComputeD(leverage, amountA, amountB *big.Int) (*big.Int, error) {
+	sumX := new(big.Int).Add(amountA, amountB)
+	if sumX.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	amountATimesCoins := new(big.Int).Mul(amountA, big.NewInt(stableNCoins))
+	amountBTimesCoins := new(big.Int).Mul(amountB, big.NewInt(stableNCoins))
+	if amountATimesCoins.Sign() == 0 || amountBTimesCoins.Sign() == 0 {
+		return nil, ErrCalculationFailure
+	}
+
+	d := new(big.Int).Set(sumX)
+	for i := 0; i < stableIterations; i++ {
+		dProduct := new(big.Int).Set(d)
+		dProduct.Mul(dProduct, d).Quo(dProduct, amountATimesCoins)
+		dProduct.Mul(dProduct, d).Quo(dProduct, amountBTimesCoins)
+		dPrevious := d
+		next, err := stableCalculateStep(d, leverage, sumX, dProduct)
+		if err != nil {
+			return nil, err
+		}
+		d = next
+		if d.Cmp(dPrevious) == 0 {
+			break
+		}
+	}
+	return d, nil
+}
+
+// stableCalculateStep computes one Newton iteration of D:
+//
+//	d = (leverage * sum_x + d_product * n_coins) * initial_d / ((leverage - 1) * initial_d + (n_coins + 1) * d_product)
+func stableCalculateStep(initialD, leverage, sumX, dProduct *big.Int) (*big.Int, error) {
+	leverageMul := new(big.Int).Mul(leverage, sumX)
+	dPMul := new(big.Int).Mul(dProduct, big.NewInt(stableNCoins))
+	lVal := new(big.Int).Add(leverageMul, dPMul)
+	lVal.Mul(lVal, initialD)
+
+	leverageSub := new(big.Int).Sub(leverage, big.NewInt(1))
+	leverageSub.Mul(leverageSub, initialD)
+	nCoinsSum := new(big.Int).Mul(dProduct, big.NewInt(stableNCoins+1))
+	rVal := new(big.Int).Add(leverageSub, nCoinsSum)
+	if rVal.Sign() <= 0 {
+		return nil, ErrCalculationFailure
+	}
+	return lVal.Quo(lVal, rVal), nil
+}
+
+// stableComputeNewDestinationAmount solves y**2 + b*y = c for the new
+// amount of the destination token, given the new amount of the source
+// token and the invariant D.
+func stableComputeNewDestinationAmount(leverage, newSourceAmount, d *big.Int) (*big.Int, error) {
+	if leverage.Sign() == 0 || newSourceAmount.Sign() == 0 {
+		return nil, ErrCalculationFailure
+	}
+	// c = D ** (n + 1) / (n ** 2 * prod' * A)
+	c := new(big.Int).Exp(d, big.NewInt(stableNCoins+1), nil)
+	divisor := new(big.Int).Mul(newSourceAmount, big.NewInt(stableNCoins*stableNCoins))
+	divisor.Mul(divisor, leverage)
+	c.Quo(c, divisor)
+
+	// b = sum' + D / (A * n**n)
+	b := new(big.Int).Quo(d, leverage)
+	b.Add(b, newSourceAmount)
+
+	y := new(big.Int).Set(d)
+	for i := 0; i < stableIterations; i++ {
+		yPrev := y
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(y, big.NewInt(2))
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		if denominator.Sign() <= 0 {
+			return nil, ErrCalculationFailure
+		}
+		y = numerator.Quo(numerator, denominator)
+		if y.Cmp(yPrev) == 0 {
+			break
+		}
+	}
+	return y, nil
+}
+
+func u(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
+
+func toUint64(v *big.Int) (uint64, error) {
+	if v.Sign() < 0 || v.Cmp(maxUint64) > 0 {
+		return 0, ErrCalculationFailure
+	}
+	return v.Uint64(), nil
+}
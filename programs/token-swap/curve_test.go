@@ -0,0 +1,160 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstantProductSwapRounding(t *testing.T) {
+	tests := []struct {
+		sourceAmount          uint64
+		swapSourceAmount      uint64
+		swapDestinationAmount uint64
+		expectedSource        uint64
+		expectedDestination   uint64
+	}{
+		{10, 4_000_000, 70_000_000_000, 10, 174_999},
+		{20, 30_000 - 20, 10_000, 18, 6},
+		{19, 30_000 - 20, 10_000, 18, 6},
+		{18, 30_000 - 20, 10_000, 18, 6},
+		{10, 20_000, 30_000, 10, 14},
+		{10, 20_000 - 9, 30_000, 10, 14},
+		{10, 20_000 - 10, 30_000, 10, 15},
+		{100, 60_000, 30_000, 99, 49},
+	}
+	for _, tt := range tests {
+		source, destination, err := constantProductSwap(u(tt.sourceAmount), u(tt.swapSourceAmount), u(tt.swapDestinationAmount))
+		require.NoError(t, err)
+		require.Equal(t, tt.expectedSource, source.Uint64())
+		require.Equal(t, tt.expectedDestination, destination.Uint64())
+	}
+}
+
+func TestSwapCurve_Swap(t *testing.T) {
+	fees := Fees{
+		TradeFeeNumerator:        25,
+		TradeFeeDenominator:      10_000,
+		OwnerTradeFeeNumerator:   5,
+		OwnerTradeFeeDenominator: 10_000,
+	}
+
+	t.Run("constant product", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveTypeConstantProduct}
+		res, err := curve.Swap(1_000_000, 1_000_000_000, 2_000_000_000, TradeDirectionAtoB, fees)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2_500), res.TradeFee)
+		require.Equal(t, uint64(500), res.OwnerFee)
+		require.Equal(t, uint64(1_000_000), res.SourceAmountSwapped)
+		require.Equal(t, uint64(1_001_000_000), res.NewSwapSourceAmount)
+		require.Equal(t, uint64(2_000_000_000)-res.DestinationAmountSwapped, res.NewSwapDestinationAmount)
+
+		// The invariant must never decrease:
+		before := new(big.Int).Mul(u(1_000_000_000), u(2_000_000_000))
+		after := new(big.Int).Mul(u(res.NewSwapSourceAmount), u(res.NewSwapDestinationAmount))
+		require.True(t, after.Cmp(before) >= 0)
+		// A 0.3% fee plus price impact:
+		require.Less(t, res.DestinationAmountSwapped, uint64(1_994_000))
+		require.Greater(t, res.DestinationAmountSwapped, uint64(1_990_000))
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveTypeStable, Parameter: 100}
+		res, err := curve.Swap(1_000_000, 1_000_000_000, 1_000_000_000, TradeDirectionAtoB, Fees{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1_000_000), res.SourceAmountSwapped)
+		// Close to 1:1 for a balanced pool, but never more:
+		require.LessOrEqual(t, res.DestinationAmountSwapped, uint64(1_000_000))
+		require.Greater(t, res.DestinationAmountSwapped, uint64(999_000))
+
+		// A stable curve has less price impact than a constant product one:
+		cp, err := SwapCurve{CurveType: CurveTypeConstantProduct}.Swap(1_000_000, 1_000_000_000, 1_000_000_000, TradeDirectionAtoB, Fees{})
+		require.NoError(t, err)
+		require.Greater(t, res.DestinationAmountSwapped, cp.DestinationAmountSwapped)
+	})
+
+	t.Run("constant price", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveTypeConstantPrice, Parameter: 3}
+
+		// Buying token B floors the source amount to a multiple of the price:
+		res, err := curve.Swap(10, 1_000, 1_000, TradeDirectionAtoB, Fees{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(9), res.SourceAmountSwapped)
+		require.Equal(t, uint64(3), res.DestinationAmountSwapped)
+		require.Equal(t, uint64(1_009), res.NewSwapSourceAmount)
+		require.Equal(t, uint64(997), res.NewSwapDestinationAmount)
+
+		// Selling token B gets the full price:
+		res, err = curve.Swap(10, 1_000, 1_000, TradeDirectionBtoA, Fees{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), res.SourceAmountSwapped)
+		require.Equal(t, uint64(30), res.DestinationAmountSwapped)
+
+		_, err = SwapCurve{CurveType: CurveTypeConstantPrice}.Swap(10, 1_000, 1_000, TradeDirectionAtoB, Fees{})
+		require.True(t, errors.Is(err, ErrCalculationFailure))
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveTypeOffset, Parameter: 1_000_000}
+
+		// The offset is added to the token B side, whichever the direction:
+		res, err := curve.Swap(1_000, 1_000_000, 2_000_000, TradeDirectionAtoB, Fees{})
+		require.NoError(t, err)
+		cp, err := SwapCurve{CurveType: CurveTypeConstantProduct}.Swap(1_000, 1_000_000, 3_000_000, TradeDirectionAtoB, Fees{})
+		require.NoError(t, err)
+		require.Equal(t, cp.DestinationAmountSwapped, res.DestinationAmountSwapped)
+		require.Equal(t, uint64(2_000_000)-res.DestinationAmountSwapped, res.NewSwapDestinationAmount)
+
+		res, err = curve.Swap(1_000, 2_000_000, 1_000_000, TradeDirectionBtoA, Fees{})
+		require.NoError(t, err)
+		cp, err = SwapCurve{CurveType: CurveTypeConstantProduct}.Swap(1_000, 3_000_000, 1_000_000, TradeDirectionBtoA, Fees{})
+		require.NoError(t, err)
+		require.Equal(t, cp.DestinationAmountSwapped, res.DestinationAmountSwapped)
+		require.Equal(t, uint64(2_001_000), res.NewSwapSourceAmount)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveType(42), Parameter: 100}
+		_, err := curve.Swap(1_000, 1_000_000, 1_000_000, TradeDirectionAtoB, fees)
+		require.True(t, errors.Is(err, ErrUnsupportedCurve))
+	})
+
+	t.Run("empty pool", func(t *testing.T) {
+		curve := SwapCurve{CurveType: CurveTypeConstantProduct}
+		_, err := curve.Swap(1_000, 0, 0, TradeDirectionAtoB, fees)
+		require.True(t, errors.Is(err, ErrCalculationFailure))
+	})
+}
+
+func TestFees(t *testing.T) {
+	fees := Fees{
+		TradeFeeNumerator:   1,
+		TradeFeeDenominator: 1_000,
+	}
+	fee, err := fees.TradingFee(10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), fee, "non-zero fees are at least 1")
+
+	fee, err = fees.OwnerTradingFee(10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), fee)
+
+	_, err = Fees{TradeFeeNumerator: 1}.TradingFee(10)
+	require.Error(t, err)
+}
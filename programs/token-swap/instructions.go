@@ -0,0 +1,182 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The SPL Token Swap program implements a Uniswap-like exchange
+// for SPL tokens, with a pluggable curve.
+
+package tokenswap
+
+import (
+	"bytes"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_treeout "github.com/gagliardetto/treeout"
+	ag_solanago "github.com/xmcontinue/solana-go"
+	ag_text "github.com/xmcontinue/solana-go/text"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.TokenSwapProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+}
+
+const ProgramName = "TokenSwap"
+
+func init() {
+	if !ProgramID.IsZero() {
+		ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	}
+}
+
+const (
+	// Initializes a new swap.
+	Instruction_Initialize uint8 = iota
+
+	// Swap the tokens in the pool.
+	Instruction_Swap
+
+	// Deposit both types of tokens into the pool.  The output is a "pool"
+	// token representing ownership in the pool. Inputs are converted to
+	// the current ratio.
+	Instruction_DepositAllTokenTypes
+
+	// Withdraw both types of tokens from the pool at the current ratio, given
+	// pool tokens.  The pool tokens are burned in exchange for an equivalent
+	// amount of token A and B.
+	Instruction_WithdrawAllTokenTypes
+
+	// Deposit one type of tokens into the pool.  The output is a "pool" token
+	// representing ownership into the pool. Input token is converted as if
+	// a swap and deposit all token types were performed.
+	Instruction_DepositSingleTokenTypeExactAmountIn
+
+	// Withdraw one token type from the pool at the current ratio given the
+	// exact amount out expected.
+	Instruction_WithdrawSingleTokenTypeExactAmountOut
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_Initialize:
+		return "Initialize"
+	case Instruction_Swap:
+		return "Swap"
+	case Instruction_DepositAllTokenTypes:
+		return "DepositAllTokenTypes"
+	case Instruction_WithdrawAllTokenTypes:
+		return "WithdrawAllTokenTypes"
+	case Instruction_DepositSingleTokenTypeExactAmountIn:
+		return "DepositSingleTokenTypeExactAmountIn"
+	case Instruction_WithdrawSingleTokenTypeExactAmountOut:
+		return "WithdrawSingleTokenTypeExactAmountOut"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+var InstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			Name: "Initialize", Type: (*Initialize)(nil),
+		},
+		{
+			Name: "Swap", Type: (*Swap)(nil),
+		},
+		{
+			Name: "DepositAllTokenTypes", Type: (*DepositAllTokenTypes)(nil),
+		},
+		{
+			Name: "WithdrawAllTokenTypes", Type: (*WithdrawAllTokenTypes)(nil),
+		},
+		{
+			Name: "DepositSingleTokenTypeExactAmountIn", Type: (*DepositSingleTokenTypeExactAmountIn)(nil),
+		},
+		{
+			Name: "WithdrawSingleTokenTypeExactAmountOut", Type: (*WithdrawSingleTokenTypeExactAmountOut)(nil),
+		},
+	},
+)
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"context"
+	"fmt"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// FetchSwap fetches and decodes the state of the provided swap account.
+func FetchSwap(ctx context.Context, rpcCli *rpc.Client, swap ag_solanago.PublicKey) (*SwapV1, error) {
+	resp, err := rpcCli.GetAccountInfo(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+	out, err := DecodeSwap(resp.Value.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode swap %s: %w", swap, err)
+	}
+	return out, nil
+}
+
+// FetchSwaps fetches and decodes all the swaps owned by the program.
+func FetchSwaps(ctx context.Context, rpcCli *rpc.Client) (map[ag_solanago.PublicKey]*SwapV1, error) {
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		ProgramID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					DataSize: SWAP_SIZE,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[ag_solanago.PublicKey]*SwapV1, len(resp))
+	for _, keyedAcct := range resp {
+		swap, err := DecodeSwap(keyedAcct.Account.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode swap %s: %w", keyedAcct.Pubkey, err)
+		}
+		out[keyedAcct.Pubkey] = swap
+	}
+	return out, nil
+}
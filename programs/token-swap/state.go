@@ -0,0 +1,92 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+// SWAP_SIZE is the size of a swap account: 1 byte of version plus the SwapV1 state.
+const SWAP_SIZE = 324
+
+// The only swap state version.
+const SwapVersionV1 uint8 = 1
+
+// SwapV1 is the state of a pool.
+type SwapV1 struct {
+	// Version of the swap state; always SwapVersionV1.
+	Version uint8
+
+	// Initialized state.
+	IsInitialized bool
+
+	// Nonce used in program address.
+	// The program address is created deterministically with the nonce,
+	// swap program id, and swap account pubkey.  This program address has
+	// authority over the swap's token A account, token B account, and pool
+	// token mint.
+	Nonce uint8
+
+	// Program ID of the tokens being exchanged.
+	TokenProgramID ag_solanago.PublicKey
+
+	// Token A.
+	TokenA ag_solanago.PublicKey
+	// Token B.
+	TokenB ag_solanago.PublicKey
+
+	// Pool tokens are issued when A or B tokens are deposited.
+	// Pool tokens can be withdrawn back to the original A or B token.
+	PoolMint ag_solanago.PublicKey
+
+	// Mint information for token A.
+	TokenAMint ag_solanago.PublicKey
+	// Mint information for token B.
+	TokenBMint ag_solanago.PublicKey
+
+	// Pool token account to receive trading and / or withdrawal fees.
+	PoolFeeAccount ag_solanago.PublicKey
+
+	// All fee information.
+	Fees Fees
+
+	// Swap curve parameters, to be unpacked and used by the SwapCurve, which
+	// calculates swaps, deposits, and withdrawals.
+	SwapCurve SwapCurve
+}
+
+// DecodeSwap decodes the given account bytes into a SwapV1.
+func DecodeSwap(data []byte) (*SwapV1, error) {
+	if len(data) < SWAP_SIZE {
+		return nil, fmt.Errorf("invalid swap account size: expected %d, got %d", SWAP_SIZE, len(data))
+	}
+	var obj SwapV1
+	if err := ag_binary.NewBinDecoder(data).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("unable to decode swap: %w", err)
+	}
+	if obj.Version != SwapVersionV1 {
+		return nil, fmt.Errorf("unsupported swap version: %d", obj.Version)
+	}
+	return &obj, nil
+}
+
+// FindAuthorityAddress returns the program address that has authority
+// over the token accounts and pool mint of the provided swap, and its nonce.
+func FindAuthorityAddress(swap ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress([][]byte{swap[:]}, ProgramID)
+}
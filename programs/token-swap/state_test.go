@@ -0,0 +1,72 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"bytes"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+func TestDecodeSwap(t *testing.T) {
+	swap := SwapV1{
+		Version:        SwapVersionV1,
+		IsInitialized:  true,
+		Nonce:          254,
+		TokenProgramID: ag_solanago.TokenProgramID,
+		TokenA:         ag_solanago.NewWallet().PublicKey(),
+		TokenB:         ag_solanago.NewWallet().PublicKey(),
+		PoolMint:       ag_solanago.NewWallet().PublicKey(),
+		TokenAMint:     ag_solanago.NewWallet().PublicKey(),
+		TokenBMint:     ag_solanago.NewWallet().PublicKey(),
+		PoolFeeAccount: ag_solanago.NewWallet().PublicKey(),
+		Fees: Fees{
+			TradeFeeNumerator:           25,
+			TradeFeeDenominator:         10000,
+			OwnerTradeFeeNumerator:      5,
+			OwnerTradeFeeDenominator:    10000,
+			OwnerWithdrawFeeNumerator:   0,
+			OwnerWithdrawFeeDenominator: 0,
+			HostFeeNumerator:            20,
+			HostFeeDenominator:          100,
+		},
+		SwapCurve: SwapCurve{
+			CurveType: CurveTypeStable,
+			Parameter: 100,
+		},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(swap))
+	require.Equal(t, SWAP_SIZE, buf.Len())
+
+	got, err := DecodeSwap(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, swap, *got)
+
+	obj, err := ag_solanago.DecodeAccount(ProgramID, buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, got, obj)
+
+	_, err = DecodeSwap(buf.Bytes()[:SWAP_SIZE-1])
+	require.Error(t, err)
+
+	data := buf.Bytes()
+	data[0] = 2
+	_, err = DecodeSwap(data)
+	require.Error(t, err)
+}
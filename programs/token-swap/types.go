@@ -0,0 +1,125 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenswap
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Fees are the fees paid to the pool (trade fees) and to the owner
+// of the pool (owner fees) on swaps, deposits and withdrawals.
+// Each fee is expressed as a numerator/denominator fraction.
+type Fees struct {
+	// Trade fees are extra token amounts that are held inside the token
+	// accounts during a trade, making the value of liquidity tokens rise.
+	TradeFeeNumerator   uint64
+	TradeFeeDenominator uint64
+
+	// Owner trading fees are extra token amounts that are held inside the token
+	// accounts during a trade, with the equivalent in pool tokens minted to
+	// the owner of the program.
+	OwnerTradeFeeNumerator   uint64
+	OwnerTradeFeeDenominator uint64
+
+	// Owner withdraw fees are extra liquidity pool token amounts that are
+	// sent to the owner on every withdrawal.
+	OwnerWithdrawFeeNumerator   uint64
+	OwnerWithdrawFeeDenominator uint64
+
+	// Host trading fees are a proportion of the owner trading fees, sent to an
+	// extra account provided during the trade.
+	HostFeeNumerator   uint64
+	HostFeeDenominator uint64
+}
+
+type CurveType uint8
+
+const (
+	// Uniswap-style constant product curve, invariant = token_a_amount * token_b_amount.
+	CurveTypeConstantProduct CurveType = iota
+	// Flat line, always providing 1:1 from one token to another.
+	CurveTypeConstantPrice
+	// Stable, like uniswap, but with wide zone of 1:1 instead of one point.
+	CurveTypeStable
+	// Offset curve, like Uniswap, but the token B side has a faked offset.
+	CurveTypeOffset
+)
+
+func (typ CurveType) String() string {
+	switch typ {
+	case CurveTypeConstantProduct:
+		return "ConstantProduct"
+	case CurveTypeConstantPrice:
+		return "ConstantPrice"
+	case CurveTypeStable:
+		return "Stable"
+	case CurveTypeOffset:
+		return "Offset"
+	default:
+		return fmt.Sprintf("CurveType(%d)", uint8(typ))
+	}
+}
+
+// SwapCurve is the curve type of a pool, with the parameters of its calculator.
+//
+// On chain, the calculator parameters are packed into a fixed 32-byte area;
+// only the first 8 bytes are used by the curves that have a parameter:
+// the token B price for ConstantPrice, the amplification coefficient for Stable,
+// and the token B offset for Offset.
+type SwapCurve struct {
+	CurveType CurveType
+	// The curve parameter; zero for ConstantProduct.
+	Parameter uint64
+}
+
+func (obj SwapCurve) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	err = encoder.WriteUint8(uint8(obj.CurveType))
+	if err != nil {
+		return err
+	}
+	calculator := make([]byte, 32)
+	binary.LittleEndian.PutUint64(calculator, obj.Parameter)
+	return encoder.WriteBytes(calculator, false)
+}
+
+func (obj *SwapCurve) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	{
+		v, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		obj.CurveType = CurveType(v)
+	}
+	calculator, err := decoder.ReadNBytes(32)
+	if err != nil {
+		return err
+	}
+	obj.Parameter = binary.LittleEndian.Uint64(calculator)
+	return nil
+}
+
+// TradeDirection is the direction of a trade, since curves can be specialized
+// to treat each token differently.
+type TradeDirection uint8
+
+const (
+	// Input token A, output token B.
+	TradeDirectionAtoB TradeDirection = iota
+	// Input token B, output token A.
+	TradeDirectionBtoA
+)
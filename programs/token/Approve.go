@@ -101,13 +101,13 @@ func (inst *Approve) GetDelegateAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The source account owner.
 func (inst *Approve) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *Approve {
-	inst.Accounts[2] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The source account owner.
+func (inst *Approve) SetOwner(owner Authority) *Approve {
+	inst.Accounts[2], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -117,6 +117,11 @@ func (inst *Approve) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *Approve) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst Approve) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -131,13 +131,13 @@ func (inst *ApproveChecked) GetDelegateAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The source account owner.
 func (inst *ApproveChecked) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *ApproveChecked {
-	inst.Accounts[3] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[3].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The source account owner.
+func (inst *ApproveChecked) SetOwner(owner Authority) *ApproveChecked {
+	inst.Accounts[3], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -147,6 +147,11 @@ func (inst *ApproveChecked) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[3]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *ApproveChecked) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[3], inst.Signers)
+}
+
 func (inst ApproveChecked) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -116,13 +116,13 @@ func (inst *BurnChecked) GetMintAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The account's owner/delegate.
 func (inst *BurnChecked) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *BurnChecked {
-	inst.Accounts[2] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The account's owner/delegate.
+func (inst *BurnChecked) SetOwner(owner Authority) *BurnChecked {
+	inst.Accounts[2], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -132,6 +132,11 @@ func (inst *BurnChecked) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *BurnChecked) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst BurnChecked) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
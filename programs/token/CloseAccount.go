@@ -92,13 +92,13 @@ func (inst *CloseAccount) GetDestinationAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The account's owner.
 func (inst *CloseAccount) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *CloseAccount {
-	inst.Accounts[2] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The account's owner.
+func (inst *CloseAccount) SetOwner(owner Authority) *CloseAccount {
+	inst.Accounts[2], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -108,6 +108,11 @@ func (inst *CloseAccount) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *CloseAccount) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst CloseAccount) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
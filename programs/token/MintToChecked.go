@@ -114,13 +114,13 @@ func (inst *MintToChecked) GetDestinationAccount() *ag_solanago.AccountMeta {
 // SetAuthorityAccount sets the "authority" account.
 // The mint's minting authority.
 func (inst *MintToChecked) SetAuthorityAccount(authority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *MintToChecked {
-	inst.Accounts[2] = ag_solanago.Meta(authority)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetAuthority(NewAuthority(authority, multisigSigners...))
+}
+
+// SetAuthority sets the "authority" account and, if it is a multisig, its signers.
+// The mint's minting authority.
+func (inst *MintToChecked) SetAuthority(authority Authority) *MintToChecked {
+	inst.Accounts[2], inst.Signers = authority.accountMetas()
 	return inst
 }
 
@@ -130,6 +130,11 @@ func (inst *MintToChecked) GetAuthorityAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetAuthority gets the "authority" account and its multisig signers.
+func (inst *MintToChecked) GetAuthority() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst MintToChecked) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
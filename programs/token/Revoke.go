@@ -75,13 +75,13 @@ func (inst *Revoke) GetSourceAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The source account's owner.
 func (inst *Revoke) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *Revoke {
-	inst.Accounts[1] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[1].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The source account's owner.
+func (inst *Revoke) SetOwner(owner Authority) *Revoke {
+	inst.Accounts[1], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -91,6 +91,11 @@ func (inst *Revoke) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[1]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *Revoke) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[1], inst.Signers)
+}
+
 func (inst Revoke) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -94,13 +94,13 @@ func (inst *SetAuthority) GetSubjectAccount() *ag_solanago.AccountMeta {
 // SetAuthorityAccount sets the "authority" account.
 // The current authority of the mint or account.
 func (inst *SetAuthority) SetAuthorityAccount(authority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *SetAuthority {
-	inst.Accounts[1] = ag_solanago.Meta(authority)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[1].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetAuthority(NewAuthority(authority, multisigSigners...))
+}
+
+// SetAuthority sets the "authority" account and, if it is a multisig, its signers.
+// The current authority of the mint or account.
+func (inst *SetAuthority) SetAuthority(authority Authority) *SetAuthority {
+	inst.Accounts[1], inst.Signers = authority.accountMetas()
 	return inst
 }
 
@@ -110,6 +110,11 @@ func (inst *SetAuthority) GetAuthorityAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[1]
 }
 
+// GetAuthority gets the "authority" account and its multisig signers.
+func (inst *SetAuthority) GetAuthority() Authority {
+	return authorityFromAccountMetas(inst.Accounts[1], inst.Signers)
+}
+
 func (inst SetAuthority) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
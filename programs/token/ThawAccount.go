@@ -91,13 +91,13 @@ func (inst *ThawAccount) GetMintAccount() *ag_solanago.AccountMeta {
 // SetAuthorityAccount sets the "authority" account.
 // The mint freeze authority.
 func (inst *ThawAccount) SetAuthorityAccount(authority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *ThawAccount {
-	inst.Accounts[2] = ag_solanago.Meta(authority)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetAuthority(NewAuthority(authority, multisigSigners...))
+}
+
+// SetAuthority sets the "authority" account and, if it is a multisig, its signers.
+// The mint freeze authority.
+func (inst *ThawAccount) SetAuthority(authority Authority) *ThawAccount {
+	inst.Accounts[2], inst.Signers = authority.accountMetas()
 	return inst
 }
 
@@ -107,6 +107,11 @@ func (inst *ThawAccount) GetAuthorityAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetAuthority gets the "authority" account and its multisig signers.
+func (inst *ThawAccount) GetAuthority() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst ThawAccount) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -103,13 +103,13 @@ func (inst *Transfer) GetDestinationAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The source account owner/delegate.
 func (inst *Transfer) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *Transfer {
-	inst.Accounts[2] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[2].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The source account owner/delegate.
+func (inst *Transfer) SetOwner(owner Authority) *Transfer {
+	inst.Accounts[2], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -119,6 +119,11 @@ func (inst *Transfer) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[2]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *Transfer) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[2], inst.Signers)
+}
+
 func (inst Transfer) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
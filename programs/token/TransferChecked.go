@@ -133,13 +133,13 @@ func (inst *TransferChecked) GetDestinationAccount() *ag_solanago.AccountMeta {
 // SetOwnerAccount sets the "owner" account.
 // The source account's owner/delegate.
 func (inst *TransferChecked) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *TransferChecked {
-	inst.Accounts[3] = ag_solanago.Meta(owner)
-	if len(multisigSigners) == 0 {
-		inst.Accounts[3].SIGNER()
-	}
-	for _, signer := range multisigSigners {
-		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
-	}
+	return inst.SetOwner(NewAuthority(owner, multisigSigners...))
+}
+
+// SetOwner sets the "owner" account and, if it is a multisig, its signers.
+// The source account's owner/delegate.
+func (inst *TransferChecked) SetOwner(owner Authority) *TransferChecked {
+	inst.Accounts[3], inst.Signers = owner.accountMetas()
 	return inst
 }
 
@@ -149,6 +149,11 @@ func (inst *TransferChecked) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[3]
 }
 
+// GetOwner gets the "owner" account and its multisig signers.
+func (inst *TransferChecked) GetOwner() Authority {
+	return authorityFromAccountMetas(inst.Accounts[3], inst.Signers)
+}
+
 func (inst TransferChecked) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -0,0 +1,110 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// ACCOUNT_SIZE is the size of a token Account.
+const ACCOUNT_SIZE = 165
+
+// Token-2022 accounts with extensions are longer than an Account, and have
+// their account type right after the Account layout; mints are padded
+// to the size of an Account.
+const (
+	token2022AccountTypeMint    = 1
+	token2022AccountTypeAccount = 2
+)
+
+func init() {
+	registerAccountDecoders(ProgramID)
+	registerToken2022AccountDecoders(solana.Token2022ProgramID)
+}
+
+func registerAccountDecoders(programID solana.PublicKey) {
+	if programID.IsZero() {
+		return
+	}
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:   "Mint",
+		Size:   MINT_SIZE,
+		Decode: decodeMintAccount,
+	})
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:   "Account",
+		Size:   ACCOUNT_SIZE,
+		Decode: decodeTokenAccount,
+	})
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:   "Multisig",
+		Size:   MULTISIG_SIZE,
+		Decode: decodeMultisigAccount,
+	})
+}
+
+// registerToken2022AccountDecoders registers the base layouts, which are
+// shared with the Token program, and the extended ones. Extensions are
+// not decoded.
+func registerToken2022AccountDecoders(programID solana.PublicKey) {
+	registerAccountDecoders(programID)
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:                "MintWithExtensions",
+		Discriminator:       []byte{token2022AccountTypeMint},
+		DiscriminatorOffset: ACCOUNT_SIZE,
+		Decode:              decodeExtendedMintAccount,
+	})
+	solana.RegisterAccountDecoder(programID, solana.AccountLayout{
+		Name:                "AccountWithExtensions",
+		Discriminator:       []byte{token2022AccountTypeAccount},
+		DiscriminatorOffset: ACCOUNT_SIZE,
+		Decode:              decodeExtendedTokenAccount,
+	})
+}
+
+func decodeMintAccount(data []byte) (interface{}, error) {
+	var mint Mint
+	if err := bin.NewBinDecoder(data).Decode(&mint); err != nil {
+		return nil, fmt.Errorf("unable to decode mint: %w", err)
+	}
+	return &mint, nil
+}
+
+func decodeTokenAccount(data []byte) (interface{}, error) {
+	var account Account
+	if err := bin.NewBinDecoder(data).Decode(&account); err != nil {
+		return nil, fmt.Errorf("unable to decode token account: %w", err)
+	}
+	return &account, nil
+}
+
+func decodeMultisigAccount(data []byte) (interface{}, error) {
+	return DecodeMultisig(data)
+}
+
+func decodeExtendedMintAccount(data []byte) (interface{}, error) {
+	return decodeMintAccount(data[:MINT_SIZE])
+}
+
+func decodeExtendedTokenAccount(data []byte) (interface{}, error) {
+	return decodeTokenAccount(data[:ACCOUNT_SIZE])
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"bytes"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestDecodeAccount_Registered(t *testing.T) {
+	mintAuthority := solana.NewWallet().PublicKey()
+	mint := Mint{
+		MintAuthority: &mintAuthority,
+		Supply:        1000,
+		Decimals:      6,
+		IsInitialized: true,
+	}
+	account := Account{
+		Mint:   solana.NewWallet().PublicKey(),
+		Owner:  solana.NewWallet().PublicKey(),
+		Amount: 42,
+		State:  Initialized,
+	}
+
+	encode := func(v interface{}) []byte {
+		buf := new(bytes.Buffer)
+		require.NoError(t, bin.NewBinEncoder(buf).Encode(v))
+		return buf.Bytes()
+	}
+	mintData := encode(mint)
+	require.Len(t, mintData, MINT_SIZE)
+	accountData := encode(account)
+	require.Len(t, accountData, ACCOUNT_SIZE)
+
+	for _, programID := range []solana.PublicKey{solana.TokenProgramID, solana.Token2022ProgramID} {
+		obj, err := solana.DecodeAccount(programID, mintData)
+		require.NoError(t, err)
+		assert.Equal(t, &mint, obj)
+
+		obj, err = solana.DecodeAccount(programID, accountData)
+		require.NoError(t, err)
+		assert.Equal(t, &account, obj)
+	}
+
+	// Token-2022 mints with extensions are padded to the size of an
+	// Account, followed by the account type and the extensions.
+	extendedMint := make([]byte, ACCOUNT_SIZE+1, ACCOUNT_SIZE+8)
+	copy(extendedMint, mintData)
+	extendedMint[ACCOUNT_SIZE] = token2022AccountTypeMint
+	extendedMint = append(extendedMint, 1, 0, 2, 0, 0, 0, 0)
+
+	obj, err := solana.DecodeAccount(solana.Token2022ProgramID, extendedMint)
+	require.NoError(t, err)
+	assert.Equal(t, &mint, obj)
+
+	_, err = solana.DecodeAccount(solana.TokenProgramID, extendedMint)
+	assert.Equal(t, solana.ErrAccountDecoderNotFound, err)
+
+	extendedAccount := append(append([]byte{}, accountData...), token2022AccountTypeAccount, 7, 0, 0, 0)
+	obj, err = solana.DecodeAccount(solana.Token2022ProgramID, extendedAccount)
+	require.NoError(t, err)
+	assert.Equal(t, &account, obj)
+}
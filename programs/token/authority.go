@@ -0,0 +1,103 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+)
+
+// Authority is the owner, delegate or authority of a token instruction:
+// either a single signer, or a multisig account and the M of its
+// signers that sign the transaction.
+type Authority struct {
+	// Address is the authority account: the signer itself, or the multisig account.
+	Address ag_solanago.PublicKey
+	// MultisigSigners are the signing members of the multisig; empty for a single signer.
+	MultisigSigners []ag_solanago.PublicKey
+}
+
+// SingleSignerAuthority returns an authority that signs itself.
+func SingleSignerAuthority(signer ag_solanago.PublicKey) Authority {
+	return Authority{Address: signer}
+}
+
+// MultisigAuthority returns the authority of the `multisig` account,
+// signed by `signers`.
+func MultisigAuthority(multisig ag_solanago.PublicKey, signers ...ag_solanago.PublicKey) Authority {
+	return Authority{Address: multisig, MultisigSigners: signers}
+}
+
+// NewAuthority returns a single signer authority if `multisigSigners`
+// is empty, and a multisig authority otherwise.
+func NewAuthority(address ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) Authority {
+	if len(multisigSigners) == 0 {
+		return SingleSignerAuthority(address)
+	}
+	return MultisigAuthority(address, multisigSigners...)
+}
+
+// IsMultisig returns true if the authority is a multisig account.
+func (a Authority) IsMultisig() bool {
+	return len(a.MultisigSigners) != 0
+}
+
+// Validate checks the authority is usable, independently of the on-chain
+// multisig; use Multisig.CheckSigners to check the signers meet its threshold.
+func (a Authority) Validate() error {
+	if a.Address.IsZero() {
+		return fmt.Errorf("authority address is not set")
+	}
+	if len(a.MultisigSigners) > MAX_SIGNERS {
+		return fmt.Errorf("too many signers; got %v, but max is %v", len(a.MultisigSigners), MAX_SIGNERS)
+	}
+	seen := map[ag_solanago.PublicKey]bool{}
+	for _, signer := range a.MultisigSigners {
+		if seen[signer] {
+			return fmt.Errorf("duplicate multisig signer %s", signer)
+		}
+		seen[signer] = true
+	}
+	return nil
+}
+
+// accountMetas returns the meta of the authority account and of its signers.
+func (a Authority) accountMetas() (*ag_solanago.AccountMeta, ag_solanago.AccountMetaSlice) {
+	authority := ag_solanago.Meta(a.Address)
+	if !a.IsMultisig() {
+		return authority.SIGNER(), ag_solanago.AccountMetaSlice{}
+	}
+	signers := make(ag_solanago.AccountMetaSlice, len(a.MultisigSigners))
+	for i, signer := range a.MultisigSigners {
+		signers[i] = ag_solanago.Meta(signer).SIGNER()
+	}
+	return authority, signers
+}
+
+// authorityFromAccountMetas is the reverse of Authority.accountMetas.
+func authorityFromAccountMetas(authority *ag_solanago.AccountMeta, signers ag_solanago.AccountMetaSlice) Authority {
+	out := Authority{}
+	if authority != nil {
+		out.Address = authority.PublicKey
+	}
+	for _, signer := range signers {
+		out.MultisigSigners = append(out.MultisigSigners, signer.PublicKey)
+	}
+	return out
+}
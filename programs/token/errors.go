@@ -0,0 +1,82 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package token
+
+import (
+	"github.com/xmcontinue/solana-go"
+)
+
+// Errors are the custom errors of the Token program, which are shared by the
+// Token-2022 program.
+var Errors = []solana.ProgramError{
+	{Code: 0, Name: "NotRentExempt", Message: "Lamport balance below rent-exempt threshold"},
+	{Code: 1, Name: "InsufficientFunds", Message: "Insufficient funds"},
+	{Code: 2, Name: "InvalidMint", Message: "Invalid Mint"},
+	{Code: 3, Name: "MintMismatch", Message: "Account not associated with this Mint"},
+	{Code: 4, Name: "OwnerMismatch", Message: "Owner does not match"},
+	{Code: 5, Name: "FixedSupply", Message: "Fixed supply"},
+	{Code: 6, Name: "AlreadyInUse", Message: "Already in use"},
+	{Code: 7, Name: "InvalidNumberOfProvidedSigners", Message: "Invalid number of provided signers"},
+	{Code: 8, Name: "InvalidNumberOfRequiredSigners", Message: "Invalid number of required signers"},
+	{Code: 9, Name: "UninitializedState", Message: "State is unititialized"},
+	{Code: 10, Name: "NativeNotSupported", Message: "Instruction does not support native tokens"},
+	{Code: 11, Name: "NonNativeHasBalance", Message: "Non-native account can only be closed if its balance is zero"},
+	{Code: 12, Name: "InvalidInstruction", Message: "Invalid instruction"},
+	{Code: 13, Name: "InvalidState", Message: "State is invalid for requested operation"},
+	{Code: 14, Name: "Overflow", Message: "Operation overflowed"},
+	{Code: 15, Name: "AuthorityTypeNotSupported", Message: "Account does not support specified authority type"},
+	{Code: 16, Name: "MintCannotFreeze", Message: "This token mint cannot freeze accounts"},
+	{Code: 17, Name: "AccountFrozen", Message: "Account is frozen"},
+	{Code: 18, Name: "MintDecimalsMismatch", Message: "The provided decimals value different from the Mint decimals"},
+	{Code: 19, Name: "NonNativeNotSupported", Message: "Instruction does not support non-native tokens"},
+}
+
+// The custom errors of the Token program, to match the errors of failed
+// transactions with errors.Is.
+var (
+	ErrNotRentExempt                  = &Errors[0]
+	ErrInsufficientFunds              = &Errors[1]
+	ErrInvalidMint                    = &Errors[2]
+	ErrMintMismatch                   = &Errors[3]
+	ErrOwnerMismatch                  = &Errors[4]
+	ErrFixedSupply                    = &Errors[5]
+	ErrAlreadyInUse                   = &Errors[6]
+	ErrInvalidNumberOfProvidedSigners = &Errors[7]
+	ErrInvalidNumberOfRequiredSigners = &Errors[8]
+	ErrUninitializedState             = &Errors[9]
+	ErrNativeNotSupported             = &Errors[10]
+	ErrNonNativeHasBalance            = &Errors[11]
+	ErrInvalidInstruction             = &Errors[12]
+	ErrInvalidState                   = &Errors[13]
+	ErrOverflow                       = &Errors[14]
+	ErrAuthorityTypeNotSupported      = &Errors[15]
+	ErrMintCannotFreeze               = &Errors[16]
+	ErrAccountFrozen                  = &Errors[17]
+	ErrMintDecimalsMismatch           = &Errors[18]
+	ErrNonNativeNotSupported          = &Errors[19]
+)
+
+func init() {
+	registerErrors(ProgramID)
+	registerErrors(solana.Token2022ProgramID)
+}
+
+func registerErrors(programID solana.PublicKey) {
+	if programID.IsZero() {
+		return
+	}
+	solana.RegisterProgramErrors(programID, Errors...)
+}
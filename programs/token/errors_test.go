@@ -0,0 +1,59 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func TestErrors_TransactionError(t *testing.T) {
+	alice := ag_solanago.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	aliceUSDC := ag_solanago.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	bobUSDC := ag_solanago.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+
+	tx, err := ag_solanago.NewTransaction([]ag_solanago.Instruction{
+		NewTransferInstruction(1500000, aliceUSDC, bobUSDC, alice, nil).Build(),
+	}, ag_solanago.Hash{}, ag_solanago.TransactionPayer(alice))
+	require.NoError(t, err)
+
+	meta := rpc.TransactionMeta{
+		Err: map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}},
+		LogMessages: []string{
+			"Program " + ProgramID.String() + " invoke [1]",
+			"Program log: Instruction: Transfer",
+			"Program log: Error: insufficient funds",
+			"Program " + ProgramID.String() + " consumed 4000 of 200000 compute units",
+			"Program " + ProgramID.String() + " failed: custom program error: 0x1",
+		},
+	}
+
+	txErr, err := meta.TransactionError(tx)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(txErr, ErrInsufficientFunds))
+	assert.True(t, errors.Is(txErr, rpc.ErrInstructionCustom))
+	assert.False(t, errors.Is(txErr, ErrInvalidMint))
+
+	// Without the program, the code alone doesn't match.
+	txErr, err = (&rpc.TransactionMeta{Err: meta.Err}).TransactionError(nil)
+	require.NoError(t, err)
+	assert.False(t, errors.Is(txErr, ErrInsufficientFunds))
+}
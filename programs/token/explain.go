@@ -0,0 +1,117 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+	"math/big"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+)
+
+func explainInstruction(ctx *explain.Context, inst *explain.Instruction) []string {
+	decoded, ok := inst.Decoded.(*Instruction)
+	if !ok {
+		return nil
+	}
+
+	var statement string
+	switch impl := decoded.Impl.(type) {
+	case *Transfer:
+		if impl.Amount == nil || impl.GetSourceAccount() == nil || impl.GetDestinationAccount() == nil || impl.GetOwnerAccount() == nil {
+			return nil
+		}
+		source, found := ctx.TokenAccount(impl.GetSourceAccount().PublicKey)
+		statement = fmt.Sprintf("%s transferred %s to %s",
+			ctx.Label(impl.GetOwnerAccount().PublicKey),
+			formatTokens(ctx, *impl.Amount, source, found),
+			tokenAccountHolder(ctx, impl.GetDestinationAccount().PublicKey),
+		)
+	case *TransferChecked:
+		if impl.Amount == nil || impl.Decimals == nil || impl.GetMintAccount() == nil || impl.GetDestinationAccount() == nil || impl.GetOwnerAccount() == nil {
+			return nil
+		}
+		statement = fmt.Sprintf("%s transferred %s to %s",
+			ctx.Label(impl.GetOwnerAccount().PublicKey),
+			ctx.FormatToken(new(big.Int).SetUint64(*impl.Amount), *impl.Decimals, impl.GetMintAccount().PublicKey),
+			tokenAccountHolder(ctx, impl.GetDestinationAccount().PublicKey),
+		)
+	case *MintTo:
+		if impl.Amount == nil || impl.GetMintAccount() == nil || impl.GetDestinationAccount() == nil || impl.GetAuthorityAccount() == nil {
+			return nil
+		}
+		destination, found := ctx.TokenAccount(impl.GetDestinationAccount().PublicKey)
+		statement = fmt.Sprintf("%s minted %s to %s",
+			ctx.Label(impl.GetAuthorityAccount().PublicKey),
+			formatTokens(ctx, *impl.Amount, destination, found),
+			tokenAccountHolder(ctx, impl.GetDestinationAccount().PublicKey),
+		)
+	case *MintToChecked:
+		if impl.Amount == nil || impl.Decimals == nil || impl.GetMintAccount() == nil || impl.GetDestinationAccount() == nil || impl.GetAuthorityAccount() == nil {
+			return nil
+		}
+		statement = fmt.Sprintf("%s minted %s to %s",
+			ctx.Label(impl.GetAuthorityAccount().PublicKey),
+			ctx.FormatToken(new(big.Int).SetUint64(*impl.Amount), *impl.Decimals, impl.GetMintAccount().PublicKey),
+			tokenAccountHolder(ctx, impl.GetDestinationAccount().PublicKey),
+		)
+	case *Burn:
+		if impl.Amount == nil || impl.GetSourceAccount() == nil || impl.GetMintAccount() == nil || impl.GetOwnerAccount() == nil {
+			return nil
+		}
+		source, found := ctx.TokenAccount(impl.GetSourceAccount().PublicKey)
+		statement = fmt.Sprintf("%s burned %s", ctx.Label(impl.GetOwnerAccount().PublicKey), formatTokens(ctx, *impl.Amount, source, found))
+	case *BurnChecked:
+		if impl.Amount == nil || impl.Decimals == nil || impl.GetMintAccount() == nil || impl.GetOwnerAccount() == nil {
+			return nil
+		}
+		statement = fmt.Sprintf("%s burned %s",
+			ctx.Label(impl.GetOwnerAccount().PublicKey),
+			ctx.FormatToken(new(big.Int).SetUint64(*impl.Amount), *impl.Decimals, impl.GetMintAccount().PublicKey),
+		)
+	case *CloseAccount:
+		if impl.GetAccount() == nil || impl.GetDestinationAccount() == nil || impl.GetOwnerAccount() == nil {
+			return nil
+		}
+		statement = fmt.Sprintf("%s closed token account %s, its rent going to %s",
+			ctx.Label(impl.GetOwnerAccount().PublicKey),
+			ctx.Label(impl.GetAccount().PublicKey),
+			ctx.Label(impl.GetDestinationAccount().PublicKey),
+		)
+	default:
+		return nil
+	}
+
+	return []string{statement}
+}
+
+// formatTokens formats `amount` with the decimals and mint of `account`,
+// or as a raw amount when the transaction metadata doesn't describe it.
+func formatTokens(ctx *explain.Context, amount uint64, account explain.TokenAccount, found bool) string {
+	if !found {
+		return fmt.Sprintf("%d tokens", amount)
+	}
+	return ctx.FormatToken(new(big.Int).SetUint64(amount), account.Decimals, account.Mint)
+}
+
+// tokenAccountHolder returns the label of the owner of the token account, or
+// of the token account itself when its owner is unknown.
+func tokenAccountHolder(ctx *explain.Context, address ag_solanago.PublicKey) string {
+	if account, ok := ctx.TokenAccount(address); ok && !account.Owner.IsZero() {
+		return ctx.Label(account.Owner)
+	}
+	return ctx.Label(address)
+}
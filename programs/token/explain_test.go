@@ -0,0 +1,78 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func TestExplainInstruction(t *testing.T) {
+	alice := ag_solanago.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	bob := ag_solanago.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	aliceUSDC := ag_solanago.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	bobUSDC := ag_solanago.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+	usdc := ag_solanago.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	tx, err := ag_solanago.NewTransaction([]ag_solanago.Instruction{
+		NewTransferInstruction(1500000, aliceUSDC, bobUSDC, alice, nil).Build(),
+		NewTransferCheckedInstruction(2000000, 6, aliceUSDC, usdc, bobUSDC, alice, nil).Build(),
+		NewBurnInstruction(500000, aliceUSDC, usdc, alice, nil).Build(),
+		NewCloseAccountInstruction(aliceUSDC, alice, alice, nil).Build(),
+	}, ag_solanago.Hash{}, ag_solanago.TransactionPayer(alice))
+	require.NoError(t, err)
+	labels := &explain.Options{Labels: map[ag_solanago.PublicKey]string{alice: "alice", bob: "bob", usdc: "USDC"}}
+
+	index := func(pubkey ag_solanago.PublicKey) uint16 {
+		for i, key := range tx.Message.AccountKeys {
+			if key.Equals(pubkey) {
+				return uint16(i)
+			}
+		}
+		t.Fatalf("account %s not found", pubkey)
+		return 0
+	}
+	balances := make([]uint64, len(tx.Message.AccountKeys))
+	meta := &rpc.TransactionMeta{
+		PreBalances:  balances,
+		PostBalances: balances,
+		PreTokenBalances: []rpc.TokenBalance{
+			{AccountIndex: index(aliceUSDC), Owner: &alice, Mint: usdc, UiTokenAmount: &rpc.UiTokenAmount{Amount: "4000000", Decimals: 6}},
+			{AccountIndex: index(bobUSDC), Owner: &bob, Mint: usdc, UiTokenAmount: &rpc.UiTokenAmount{Amount: "0", Decimals: 6}},
+		},
+	}
+
+	statements, err := explain.Explain(tx, meta, labels)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alice transferred 1.5 USDC to bob",
+		"alice transferred 2 USDC to bob",
+		"alice burned 0.5 USDC",
+		"alice closed token account " + aliceUSDC.String() + ", its rent going to alice",
+		"alice paid 0 SOL in fees",
+	}, statements)
+
+	// Without metadata, the mint and decimals of Transfer are unknown.
+	statements, err = explain.Explain(tx, nil, labels)
+	require.NoError(t, err)
+	assert.Equal(t, "alice transferred 1500000 tokens to "+bobUSDC.String(), statements[0])
+	assert.Equal(t, "alice transferred 2 USDC to "+bobUSDC.String(), statements[1])
+}
@@ -25,6 +25,7 @@ import (
 	ag_binary "github.com/gagliardetto/binary"
 	ag_treeout "github.com/gagliardetto/treeout"
 	ag_solanago "github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/explain"
 	ag_text "github.com/xmcontinue/solana-go/text"
 )
 
@@ -36,6 +37,9 @@ var ProgramID ag_solanago.PublicKey = ag_solanago.TokenProgramID
 func SetProgramID(pubkey ag_solanago.PublicKey) {
 	ProgramID = pubkey
 	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	registerAccountDecoders(ProgramID)
+	registerErrors(ProgramID)
+	explain.RegisterExplainer(ProgramID, explainInstruction)
 }
 
 const ProgramName = "Token"
@@ -43,6 +47,7 @@ const ProgramName = "Token"
 func init() {
 	if !ProgramID.IsZero() {
 		ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+		explain.RegisterExplainer(ProgramID, explainInstruction)
 	}
 }
 
@@ -0,0 +1,114 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+// MULTISIG_SIZE is the size of a Multisig account.
+const MULTISIG_SIZE = 355
+
+// DecodeMultisig decodes the data of a Multisig account.
+func DecodeMultisig(data []byte) (*Multisig, error) {
+	if len(data) != MULTISIG_SIZE {
+		return nil, fmt.Errorf("invalid multisig account size: expected %d bytes, got %d", MULTISIG_SIZE, len(data))
+	}
+	out := new(Multisig)
+	if err := bin.NewBinDecoder(data).Decode(out); err != nil {
+		return nil, fmt.Errorf("unable to decode multisig: %w", err)
+	}
+	return out, nil
+}
+
+// FetchMultisig fetches and decodes the Multisig account at `address`.
+func FetchMultisig(ctx context.Context, rpcCli *rpc.Client, address solana.PublicKey) (*Multisig, error) {
+	resp, err := rpcCli.GetAccountInfo(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get multisig account %s: %w", address, err)
+	}
+	if resp.Value.Owner != ProgramID {
+		return nil, fmt.Errorf("account %s is not owned by the token program, but by %s", address, resp.Value.Owner)
+	}
+	return DecodeMultisig(resp.Value.Data.GetBinary())
+}
+
+// SignerKeys returns the N signers of the multisig.
+func (m *Multisig) SignerKeys() []solana.PublicKey {
+	n := int(m.N)
+	if n > MAX_SIGNERS {
+		n = MAX_SIGNERS
+	}
+	return append([]solana.PublicKey{}, m.Signers[:n]...)
+}
+
+// IsSigner returns true if `signer` is one of the N signers of the multisig.
+func (m *Multisig) IsSigner(signer solana.PublicKey) bool {
+	for _, key := range m.SignerKeys() {
+		if key.Equals(signer) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSigners returns an error if `signers` are not enough distinct
+// signers of the multisig to meet its M threshold.
+func (m *Multisig) CheckSigners(signers []solana.PublicKey) error {
+	if !m.IsInitialized {
+		return fmt.Errorf("multisig is not initialized")
+	}
+	seen := map[solana.PublicKey]bool{}
+	for _, signer := range signers {
+		if !m.IsSigner(signer) {
+			return fmt.Errorf("%s is not a signer of the multisig", signer)
+		}
+		if seen[signer] {
+			return fmt.Errorf("duplicate multisig signer %s", signer)
+		}
+		seen[signer] = true
+	}
+	if len(seen) < int(m.M) {
+		return fmt.Errorf("not enough multisig signers: got %d, but %d of %d are required", len(seen), m.M, m.N)
+	}
+	return nil
+}
+
+// Authority returns the authority of the multisig account at `address`,
+// signed by `signers`, after checking they meet the multisig threshold.
+func (m *Multisig) Authority(address solana.PublicKey, signers ...solana.PublicKey) (Authority, error) {
+	if err := m.CheckSigners(signers); err != nil {
+		return Authority{}, err
+	}
+	return MultisigAuthority(address, signers...), nil
+}
+
+// FetchMultisigAuthority fetches the multisig account at `address` and
+// returns its authority, signed by `signers`, after checking they meet its threshold.
+func FetchMultisigAuthority(ctx context.Context, rpcCli *rpc.Client, address solana.PublicKey, signers ...solana.PublicKey) (Authority, error) {
+	multisig, err := FetchMultisig(ctx, rpcCli, address)
+	if err != nil {
+		return Authority{}, err
+	}
+	return multisig.Authority(address, signers...)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"bytes"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestAuthority_Builders(t *testing.T) {
+	multisig := solana.NewWallet().PublicKey()
+	signerA := solana.NewWallet().PublicKey()
+	signerB := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+
+	inst := NewTransferInstructionBuilder().
+		SetAmount(1).
+		SetSourceAccount(solana.NewWallet().PublicKey()).
+		SetDestinationAccount(solana.NewWallet().PublicKey()).
+		SetOwner(MultisigAuthority(multisig, signerA, signerB))
+	require.NoError(t, inst.Validate())
+
+	accounts := inst.GetAccounts()
+	require.Len(t, accounts, 5)
+	assert.Equal(t, multisig, accounts[2].PublicKey)
+	assert.False(t, accounts[2].IsSigner)
+	assert.True(t, accounts[3].IsSigner)
+	assert.True(t, accounts[4].IsSigner)
+	assert.Equal(t, MultisigAuthority(multisig, signerA, signerB), inst.GetOwner())
+
+	// Setting the owner again replaces the signers.
+	inst.SetOwner(SingleSignerAuthority(owner))
+	accounts = inst.GetAccounts()
+	require.Len(t, accounts, 3)
+	assert.True(t, accounts[2].IsSigner)
+	assert.False(t, inst.GetOwner().IsMultisig())
+
+	mintTo := NewMintToInstructionBuilder().
+		SetAmount(1).
+		SetMintAccount(solana.NewWallet().PublicKey()).
+		SetDestinationAccount(solana.NewWallet().PublicKey()).
+		SetAuthority(MultisigAuthority(multisig, signerA))
+	require.NoError(t, mintTo.Validate())
+	assert.Equal(t, MultisigAuthority(multisig, signerA), mintTo.GetAuthority())
+}
+
+func TestAuthority_Validate(t *testing.T) {
+	signer := solana.NewWallet().PublicKey()
+
+	require.NoError(t, SingleSignerAuthority(signer).Validate())
+	require.Error(t, Authority{}.Validate())
+	require.Error(t, MultisigAuthority(solana.NewWallet().PublicKey(), signer, signer).Validate())
+
+	signers := make([]solana.PublicKey, MAX_SIGNERS+1)
+	for i := range signers {
+		signers[i] = solana.NewWallet().PublicKey()
+	}
+	require.Error(t, MultisigAuthority(solana.NewWallet().PublicKey(), signers...).Validate())
+}
+
+func TestMultisig_CheckSigners(t *testing.T) {
+	signerA := solana.NewWallet().PublicKey()
+	signerB := solana.NewWallet().PublicKey()
+	signerC := solana.NewWallet().PublicKey()
+	address := solana.NewWallet().PublicKey()
+
+	ms := Multisig{M: 2, N: 3, IsInitialized: true}
+	copy(ms.Signers[:], []solana.PublicKey{signerA, signerB, signerC})
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(ms))
+	require.Equal(t, MULTISIG_SIZE, buf.Len())
+
+	multisig, err := DecodeMultisig(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []solana.PublicKey{signerA, signerB, signerC}, multisig.SignerKeys())
+
+	authority, err := multisig.Authority(address, signerA, signerC)
+	require.NoError(t, err)
+	assert.Equal(t, MultisigAuthority(address, signerA, signerC), authority)
+
+	require.Error(t, multisig.CheckSigners([]solana.PublicKey{signerA}))
+	require.Error(t, multisig.CheckSigners([]solana.PublicKey{signerA, signerA}))
+	require.Error(t, multisig.CheckSigners([]solana.PublicKey{signerA, solana.NewWallet().PublicKey()}))
+
+	_, err = DecodeMultisig(buf.Bytes()[:100])
+	require.Error(t, err)
+}
@@ -0,0 +1,289 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// BalanceChanges are the changes of the SOL and token balances caused by a
+// transaction, as recorded in its metadata.
+type BalanceChanges struct {
+	// FeePayer is the account that paid Fee, the first signer of the transaction.
+	FeePayer solana.PublicKey
+	Fee      uint64
+
+	// Accounts are the accounts whose lamports changed, in the order of
+	// the account keys of the transaction. The fee is not part of the
+	// change of the fee payer.
+	Accounts []AccountBalanceChange
+
+	// Tokens are the changes of the tokens held per (owner, mint), in the
+	// order in which they appear in the token balances of the metadata.
+	Tokens []TokenBalanceChange
+}
+
+type AccountBalanceChange struct {
+	Account solana.PublicKey
+	Pre     uint64
+	Post    uint64
+
+	// Delta is Post - Pre, without the fee for the fee payer.
+	Delta int64
+}
+
+type TokenBalanceChange struct {
+	// Owner of the token accounts, zero for the transactions whose metadata
+	// predates the recording of owners, in which case there is one change per
+	// token account.
+	Owner    solana.PublicKey
+	Mint     solana.PublicKey
+	Decimals uint8
+
+	// TokenAccounts are the token accounts of Owner for Mint.
+	TokenAccounts solana.PublicKeySlice
+
+	// Pre, Post and Delta are raw amounts, ignoring decimals.
+	Pre   *big.Int
+	Post  *big.Int
+	Delta *big.Int
+
+	// UiDelta is Delta with decimals applied, e.g. "-10.5".
+	UiDelta string
+}
+
+// AccountDelta returns the change of the lamports of `account`, without the fee.
+func (c *BalanceChanges) AccountDelta(account solana.PublicKey) int64 {
+	for _, change := range c.Accounts {
+		if change.Account.Equals(account) {
+			return change.Delta
+		}
+	}
+	return 0
+}
+
+// TokensOf returns the changes of the tokens held by `owner`.
+func (c *BalanceChanges) TokensOf(owner solana.PublicKey) (out []TokenBalanceChange) {
+	for _, change := range c.Tokens {
+		if change.Owner.Equals(owner) {
+			out = append(out, change)
+		}
+	}
+	return
+}
+
+// TokenDelta returns the change of the tokens of `mint` held by `owner`, as a raw amount.
+func (c *BalanceChanges) TokenDelta(owner, mint solana.PublicKey) *big.Int {
+	for _, change := range c.Tokens {
+		if change.Owner.Equals(owner) && change.Mint.Equals(mint) {
+			return new(big.Int).Set(change.Delta)
+		}
+	}
+	return new(big.Int)
+}
+
+// AccountKeys returns the account keys of `tx` to which the balances of the
+// metadata refer: the static keys of the message, followed by the writable
+// and the readonly addresses loaded from address lookup tables.
+func (m *TransactionMeta) AccountKeys(tx *solana.Transaction) solana.PublicKeySlice {
+	keys := solana.PublicKeySlice(tx.Message.AccountKeys)
+	loaded := append(append(solana.PublicKeySlice{}, m.LoadedAddresses.Writable...), m.LoadedAddresses.ReadOnly...)
+	if len(loaded) == 0 {
+		return keys
+	}
+
+	// The message of which the lookups are resolved already holds the loaded addresses.
+	if len(keys) >= len(loaded) && keysEqual(keys[len(keys)-len(loaded):], loaded) {
+		return keys
+	}
+
+	return append(append(solana.PublicKeySlice{}, keys...), loaded...)
+}
+
+// AccountMetas returns the accounts of `AccountKeys`, with their signer
+// and writable flags.
+func (m *TransactionMeta) AccountMetas(tx *solana.Transaction) []*solana.AccountMeta {
+	keys := m.AccountKeys(tx)
+	numStatic := len(keys) - len(m.LoadedAddresses.Writable) - len(m.LoadedAddresses.ReadOnly)
+
+	header := tx.Message.Header
+	numWritableSigners := int(header.NumRequiredSignatures - header.NumReadonlySignedAccounts)
+	numWritableStatic := numStatic - int(header.NumReadonlyUnsignedAccounts)
+
+	out := make([]*solana.AccountMeta, 0, len(keys))
+	for i, key := range keys {
+		meta := &solana.AccountMeta{PublicKey: key}
+		if i < numStatic {
+			meta.IsSigner = i < int(header.NumRequiredSignatures)
+			meta.IsWritable = i < numWritableSigners || (!meta.IsSigner && i < numWritableStatic)
+		} else {
+			meta.IsWritable = i-numStatic < len(m.LoadedAddresses.Writable)
+		}
+		out = append(out, meta)
+	}
+
+	return out
+}
+
+func keysEqual(a, b solana.PublicKeySlice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// BalanceChanges returns the balance changes of `tx` recorded in the metadata.
+func (m *TransactionMeta) BalanceChanges(tx *solana.Transaction) (*BalanceChanges, error) {
+	keys := m.AccountKeys(tx)
+	if len(m.PreBalances) != len(keys) || len(m.PostBalances) != len(keys) {
+		return nil, fmt.Errorf("transaction has %d accounts but its metadata has %d pre and %d post balances", len(keys), len(m.PreBalances), len(m.PostBalances))
+	}
+
+	out := &BalanceChanges{Fee: m.Fee}
+	if len(keys) > 0 {
+		out.FeePayer = keys[0]
+	}
+
+	for i, key := range keys {
+		change := AccountBalanceChange{
+			Account: key,
+			Pre:     m.PreBalances[i],
+			Post:    m.PostBalances[i],
+			Delta:   int64(m.PostBalances[i]) - int64(m.PreBalances[i]),
+		}
+		if i == 0 {
+			change.Delta += int64(m.Fee)
+		}
+		if change.Delta != 0 {
+			out.Accounts = append(out.Accounts, change)
+		}
+	}
+
+	type tokenKey struct {
+		owner, mint, account solana.PublicKey
+	}
+	var order []tokenKey
+	changes := map[tokenKey]*TokenBalanceChange{}
+
+	add := func(balances []TokenBalance, post bool) error {
+		for _, balance := range balances {
+			if int(balance.AccountIndex) >= len(keys) {
+				return fmt.Errorf("token balance account index %d out of range", balance.AccountIndex)
+			}
+			account := keys[balance.AccountIndex]
+
+			var amount *big.Int
+			var decimals uint8
+			if balance.UiTokenAmount != nil {
+				var ok bool
+				if amount, ok = new(big.Int).SetString(balance.UiTokenAmount.Amount, 10); !ok {
+					return fmt.Errorf("invalid token amount %q of account %s", balance.UiTokenAmount.Amount, account)
+				}
+				decimals = balance.UiTokenAmount.Decimals
+			} else {
+				amount = new(big.Int)
+			}
+
+			key := tokenKey{mint: balance.Mint}
+			if balance.Owner != nil {
+				key.owner = *balance.Owner
+			} else {
+				key.account = account
+			}
+
+			change, found := changes[key]
+			if !found {
+				change = &TokenBalanceChange{Owner: key.owner, Mint: key.mint, Decimals: decimals, Pre: new(big.Int), Post: new(big.Int)}
+				changes[key] = change
+				order = append(order, key)
+			}
+			if !change.TokenAccounts.Has(account) {
+				change.TokenAccounts = append(change.TokenAccounts, account)
+			}
+			if post {
+				change.Post.Add(change.Post, amount)
+			} else {
+				change.Pre.Add(change.Pre, amount)
+			}
+		}
+		return nil
+	}
+	if err := add(m.PreTokenBalances, false); err != nil {
+		return nil, err
+	}
+	if err := add(m.PostTokenBalances, true); err != nil {
+		return nil, err
+	}
+
+	for _, key := range order {
+		change := changes[key]
+		change.Delta = new(big.Int).Sub(change.Post, change.Pre)
+		if change.Delta.Sign() == 0 {
+			continue
+		}
+		change.UiDelta = solana.FormatUiAmount(change.Delta, change.Decimals)
+		out.Tokens = append(out.Tokens, *change)
+	}
+
+	return out, nil
+}
+
+// BalanceChanges returns the balance changes of the transaction, see `TransactionMeta.BalanceChanges`.
+func (twm TransactionWithMeta) BalanceChanges() (*BalanceChanges, error) {
+	if twm.Meta == nil {
+		return nil, fmt.Errorf("transaction has no metadata")
+	}
+	if twm.Transaction == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+
+	var tx *solana.Transaction
+	var err error
+	if twm.Transaction.rawDataEncoding == solana.EncodingJSONParsed {
+		tx, err = twm.GetParsedTransaction()
+	} else {
+		tx, err = twm.GetTransaction()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+
+	return twm.Meta.BalanceChanges(tx)
+}
+
+// BalanceChanges returns the balance changes of the transaction, see `TransactionMeta.BalanceChanges`.
+func (res *GetTransactionResult) BalanceChanges() (*BalanceChanges, error) {
+	if res.Meta == nil {
+		return nil, fmt.Errorf("transaction has no metadata")
+	}
+	if res.Transaction == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+
+	tx, err := res.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+
+	return res.Meta.BalanceChanges(tx)
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	stdjson "encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestTransactionMeta_BalanceChanges(t *testing.T) {
+	payer := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	recipient := solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+	usdcAccount := solana.MustPublicKeyFromBase58("7y6V4YxKvM3R4ApgGMxrkcQpPuKaSb7N1pyV6WG2wV2F")
+	programID := solana.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+	usdc := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	table := solana.MustPublicKeyFromBase58("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+
+	// v0 transaction: `recipient` and `usdcAccount` are loaded from a lookup table.
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(programID, solana.AccountMetaSlice{
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(recipient).WRITE(),
+			solana.Meta(usdcAccount).WRITE(),
+		}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer), solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{
+		table: {recipient, usdcAccount},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []solana.PublicKey{payer, programID}, tx.Message.AccountKeys)
+
+	meta := &TransactionMeta{
+		Fee:             5000,
+		PreBalances:     []uint64{2000005000, 1, 0, 2039280},
+		PostBalances:    []uint64{1000000000, 1, 1000000000, 2039280},
+		LoadedAddresses: LoadedAddresses{Writable: solana.PublicKeySlice{recipient, usdcAccount}},
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 3, Owner: &payer, Mint: usdc, UiTokenAmount: &UiTokenAmount{Amount: "25000000", Decimals: 6}},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 3, Owner: &payer, Mint: usdc, UiTokenAmount: &UiTokenAmount{Amount: "14500000", Decimals: 6}},
+		},
+	}
+	require.Equal(t, solana.PublicKeySlice{payer, programID, recipient, usdcAccount}, meta.AccountKeys(tx))
+
+	changes, err := meta.BalanceChanges(tx)
+	require.NoError(t, err)
+	assert.Equal(t, &BalanceChanges{
+		FeePayer: payer,
+		Fee:      5000,
+		Accounts: []AccountBalanceChange{
+			{Account: payer, Pre: 2000005000, Post: 1000000000, Delta: -1000000000},
+			{Account: recipient, Pre: 0, Post: 1000000000, Delta: 1000000000},
+		},
+		Tokens: []TokenBalanceChange{
+			{
+				Owner:         payer,
+				Mint:          usdc,
+				Decimals:      6,
+				TokenAccounts: solana.PublicKeySlice{usdcAccount},
+				Pre:           big.NewInt(25000000),
+				Post:          big.NewInt(14500000),
+				Delta:         big.NewInt(-10500000),
+				UiDelta:       "-10.5",
+			},
+		},
+	}, changes)
+	assert.Equal(t, int64(1000000000), changes.AccountDelta(recipient))
+	assert.Equal(t, int64(0), changes.AccountDelta(programID))
+	assert.Equal(t, big.NewInt(-10500000), changes.TokenDelta(payer, usdc))
+	assert.Equal(t, big.NewInt(0), changes.TokenDelta(recipient, usdc))
+	assert.Len(t, changes.TokensOf(payer), 1)
+
+	// Same result once the lookups of the message are resolved.
+	require.NoError(t, tx.Message.ResolveLookups())
+	resolved, err := meta.BalanceChanges(tx)
+	require.NoError(t, err)
+	assert.Equal(t, changes, resolved)
+
+	_, err = (&TransactionMeta{PreBalances: []uint64{1}, PostBalances: []uint64{1}}).BalanceChanges(tx)
+	require.Error(t, err)
+}
+
+func TestTransactionWithMeta_BalanceChanges(t *testing.T) {
+	payer := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	recipient := solana.MustPublicKeyFromBase58("77K8mr457qxUSSNSfi4sSj5euP8DyuJJWHAUQVW8QCp3")
+
+	in := `{
+		"slot": 1,
+		"transaction": {
+			"signatures": ["` + solana.Signature{}.String() + `"],
+			"message": {
+				"header": {"numRequiredSignatures": 1, "numReadonlySignedAccounts": 0, "numReadonlyUnsignedAccounts": 1},
+				"accountKeys": ["` + payer.String() + `", "` + recipient.String() + `", "11111111111111111111111111111111"],
+				"recentBlockhash": "11111111111111111111111111111111",
+				"instructions": [{"programIdIndex": 2, "accounts": [0, 1], "data": "3Bxs4Bc3VYuGVB19"}]
+			}
+		},
+		"meta": {"fee": 5000, "preBalances": [100005000, 0, 1], "postBalances": [50000000, 50000000, 1]}
+	}`
+
+	var twm TransactionWithMeta
+	require.NoError(t, stdjson.Unmarshal([]byte(in), &twm))
+
+	changes, err := twm.BalanceChanges()
+	require.NoError(t, err)
+	assert.Equal(t, payer, changes.FeePayer)
+	assert.Equal(t, []AccountBalanceChange{
+		{Account: payer, Pre: 100005000, Post: 50000000, Delta: -50000000},
+		{Account: recipient, Pre: 0, Post: 50000000, Delta: 50000000},
+	}, changes.Accounts)
+	assert.Empty(t, changes.Tokens)
+}
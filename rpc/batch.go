@@ -0,0 +1,380 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc/jsonrpc"
+)
+
+var (
+	ErrBatchNotExecuted     = errors.New("batch not executed")
+	ErrBatchAlreadyExecuted = errors.New("batch already executed")
+)
+
+const (
+	// DefaultBatchSize is the number of requests sent in a single HTTP
+	// request, most providers accept batches of at least this size.
+	DefaultBatchSize = 100
+
+	// DefaultBatchConcurrency is the number of HTTP requests of a batch sent
+	// at the same time.
+	DefaultBatchConcurrency = 4
+)
+
+// Batch collects requests and sends them with JSON-RPC batch calls. Each
+// request returns a future holding its result once the batch was executed
+// with `Do`.
+//
+// The requests are prepared by the methods of `Client`, so they are
+// validated and their results are processed the same way as with single
+// calls, e.g. `GetAccountInfo` resolving to `ErrNotFound`.
+type Batch struct {
+	client   *Client
+	requests []*batchRequest
+	executed bool
+}
+
+// BatchOpts are the options of `Batch.DoWithOpts`.
+type BatchOpts struct {
+	// MaxBatchSize is the maximum number of requests of a JSON-RPC batch
+	// call, `DefaultBatchSize` if zero.
+	MaxBatchSize int
+
+	// MaxConcurrency is the maximum number of batch calls in flight,
+	// `DefaultBatchConcurrency` if zero.
+	MaxConcurrency int
+}
+
+// NewBatch returns an empty batch of requests sent through the client.
+func (cl *Client) NewBatch() *Batch {
+	return &Batch{client: cl}
+}
+
+type batchRequest struct {
+	// call performs the request with the provided client, it is called once
+	// to record the request, and once to process its response.
+	call func(ctx context.Context, cl *Client) error
+
+	method string
+	params []interface{}
+
+	done bool
+	err  error
+}
+
+func (req *batchRequest) result() error {
+	if !req.done {
+		return ErrBatchNotExecuted
+	}
+	return req.err
+}
+
+func (b *Batch) add(call func(ctx context.Context, cl *Client) error) *batchRequest {
+	req := &batchRequest{call: call}
+	b.requests = append(b.requests, req)
+	return req
+}
+
+// Len returns the number of requests of the batch.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Do executes the batch with the default options.
+func (b *Batch) Do(ctx context.Context) error {
+	return b.DoWithOpts(ctx, nil)
+}
+
+// DoWithOpts sends the requests of the batch, split into JSON-RPC batch calls
+// of at most `opts.MaxBatchSize` requests, `opts.MaxConcurrency` of them at
+// the same time. The results and the errors of the requests are set in their
+// futures, the returned error is the first batch call that failed, in which
+// case the futures of its requests hold that error too.
+func (b *Batch) DoWithOpts(ctx context.Context, opts *BatchOpts) error {
+	if b.executed {
+		return ErrBatchAlreadyExecuted
+	}
+	b.executed = true
+
+	maxBatchSize, maxConcurrency := DefaultBatchSize, DefaultBatchConcurrency
+	if opts != nil {
+		if opts.MaxBatchSize > 0 {
+			maxBatchSize = opts.MaxBatchSize
+		}
+		if opts.MaxConcurrency > 0 {
+			maxConcurrency = opts.MaxConcurrency
+		}
+	}
+
+	// Record the requests, the ones failing before being sent are done.
+	var pending []*batchRequest
+	for _, req := range b.requests {
+		recorder := &batchRecorder{}
+		err := req.call(ctx, NewWithCustomRPCClient(recorder))
+		if !recorder.recorded {
+			req.done, req.err = true, err
+			continue
+		}
+		req.method, req.params = recorder.method, recorder.params
+		pending = append(pending, req)
+	}
+
+	var chunks [][]*batchRequest
+	for len(pending) > maxBatchSize {
+		chunks = append(chunks, pending[:maxBatchSize])
+		pending = pending[maxBatchSize:]
+	}
+	if len(pending) > 0 {
+		chunks = append(chunks, pending)
+	}
+
+	errs := make([]error, len(chunks))
+	semaphore := make(chan struct{}, maxConcurrency)
+	wg := sync.WaitGroup{}
+	for i, chunk := range chunks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, chunk []*batchRequest) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs[i] = b.send(ctx, chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("batch call %d of %d: %w", i+1, len(chunks), err)
+		}
+	}
+	return nil
+}
+
+func (b *Batch) send(ctx context.Context, chunk []*batchRequest) error {
+	requests := make(jsonrpc.RPCRequests, len(chunk))
+	for i, req := range chunk {
+		requests[i] = jsonrpc.NewRequest(req.method)
+		requests[i].Params = req.params
+	}
+
+	responses, err := b.client.rpcClient.CallBatch(ctx, requests)
+	if err != nil {
+		for _, req := range chunk {
+			req.done, req.err = true, err
+		}
+		return err
+	}
+
+	byID := responses.AsMap()
+	for i, req := range chunk {
+		// `CallBatch` sets the ID of the requests to their position.
+		response, ok := byID[i]
+		if !ok {
+			req.done, req.err = true, fmt.Errorf("no response for request %d (%s)", i, req.method)
+			continue
+		}
+		req.done, req.err = true, req.call(ctx, NewWithCustomRPCClient(&batchReplayer{response: response}))
+	}
+	return nil
+}
+
+var errBatchRecorded = errors.New("request recorded for batch")
+
+// batchRecorder records the request of a `Client` method.
+type batchRecorder struct {
+	recorded bool
+	method   string
+	params   []interface{}
+}
+
+func (rec *batchRecorder) CallForInto(ctx context.Context, out interface{}, method string, params []interface{}) error {
+	if rec.recorded {
+		return fmt.Errorf("method %s makes more than one request, it can't be batched", method)
+	}
+	rec.recorded, rec.method, rec.params = true, method, params
+	return errBatchRecorded
+}
+
+func (rec *batchRecorder) CallWithCallback(ctx context.Context, method string, params []interface{}, callback func(*http.Request, *http.Response) error) error {
+	return fmt.Errorf("method %s can't be batched", method)
+}
+
+func (rec *batchRecorder) CallBatch(ctx context.Context, requests jsonrpc.RPCRequests) (jsonrpc.RPCResponses, error) {
+	return nil, errors.New("batch calls can't be batched")
+}
+
+// batchReplayer answers the request of a `Client` method with its response
+// from the batch call.
+type batchReplayer struct {
+	response *jsonrpc.RPCResponse
+}
+
+func (rep *batchReplayer) CallForInto(ctx context.Context, out interface{}, method string, params []interface{}) error {
+	if rep.response.Error != nil {
+		return rep.response.Error
+	}
+	return rep.response.GetObject(out)
+}
+
+func (rep *batchReplayer) CallWithCallback(ctx context.Context, method string, params []interface{}, callback func(*http.Request, *http.Response) error) error {
+	return fmt.Errorf("method %s can't be batched", method)
+}
+
+func (rep *batchReplayer) CallBatch(ctx context.Context, requests jsonrpc.RPCRequests) (jsonrpc.RPCResponses, error) {
+	return nil, errors.New("batch calls can't be batched")
+}
+
+// BatchFuture is the result of a request of a batch, available once the
+// batch was executed.
+type BatchFuture struct {
+	req *batchRequest
+}
+
+// Err returns the error of the request, `ErrBatchNotExecuted` before the
+// batch was executed.
+func (f *BatchFuture) Err() error {
+	return f.req.result()
+}
+
+// Call adds the request `method` with `params`, its result being decoded into `out`.
+func (b *Batch) Call(out interface{}, method string, params ...interface{}) *BatchFuture {
+	return &BatchFuture{req: b.add(func(ctx context.Context, cl *Client) error {
+		return cl.RPCCallForInto(ctx, out, method, params)
+	})}
+}
+
+type GetAccountInfoFuture struct {
+	req    *batchRequest
+	result *GetAccountInfoResult
+}
+
+func (f *GetAccountInfoFuture) Result() (*GetAccountInfoResult, error) {
+	if err := f.req.result(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// GetAccountInfo adds a `Client.GetAccountInfo` request.
+func (b *Batch) GetAccountInfo(account solana.PublicKey) *GetAccountInfoFuture {
+	return b.GetAccountInfoWithOpts(account, &GetAccountInfoOpts{})
+}
+
+// GetAccountInfoWithOpts adds a `Client.GetAccountInfoWithOpts` request.
+func (b *Batch) GetAccountInfoWithOpts(account solana.PublicKey, opts *GetAccountInfoOpts) *GetAccountInfoFuture {
+	f := &GetAccountInfoFuture{}
+	f.req = b.add(func(ctx context.Context, cl *Client) (err error) {
+		f.result, err = cl.GetAccountInfoWithOpts(ctx, account, opts)
+		return err
+	})
+	return f
+}
+
+type GetBalanceFuture struct {
+	req    *batchRequest
+	result *GetBalanceResult
+}
+
+func (f *GetBalanceFuture) Result() (*GetBalanceResult, error) {
+	if err := f.req.result(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// GetBalance adds a `Client.GetBalance` request.
+func (b *Batch) GetBalance(publicKey solana.PublicKey, commitment CommitmentType) *GetBalanceFuture {
+	f := &GetBalanceFuture{}
+	f.req = b.add(func(ctx context.Context, cl *Client) (err error) {
+		f.result, err = cl.GetBalance(ctx, publicKey, commitment)
+		return err
+	})
+	return f
+}
+
+type GetSignatureStatusesFuture struct {
+	req    *batchRequest
+	result *GetSignatureStatusesResult
+}
+
+func (f *GetSignatureStatusesFuture) Result() (*GetSignatureStatusesResult, error) {
+	if err := f.req.result(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// GetSignatureStatuses adds a `Client.GetSignatureStatuses` request.
+func (b *Batch) GetSignatureStatuses(searchTransactionHistory bool, transactionSignatures ...solana.Signature) *GetSignatureStatusesFuture {
+	f := &GetSignatureStatusesFuture{}
+	f.req = b.add(func(ctx context.Context, cl *Client) (err error) {
+		f.result, err = cl.GetSignatureStatuses(ctx, searchTransactionHistory, transactionSignatures...)
+		return err
+	})
+	return f
+}
+
+type GetTransactionFuture struct {
+	req    *batchRequest
+	result *GetTransactionResult
+}
+
+func (f *GetTransactionFuture) Result() (*GetTransactionResult, error) {
+	if err := f.req.result(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// GetTransaction adds a `Client.GetTransaction` request.
+func (b *Batch) GetTransaction(txSig solana.Signature, opts *GetTransactionOpts) *GetTransactionFuture {
+	f := &GetTransactionFuture{}
+	f.req = b.add(func(ctx context.Context, cl *Client) (err error) {
+		f.result, err = cl.GetTransaction(ctx, txSig, opts)
+		return err
+	})
+	return f
+}
+
+type GetMultipleAccountsFuture struct {
+	req    *batchRequest
+	result *GetMultipleAccountsResult
+}
+
+func (f *GetMultipleAccountsFuture) Result() (*GetMultipleAccountsResult, error) {
+	if err := f.req.result(); err != nil {
+		return nil, err
+	}
+	return f.result, nil
+}
+
+// GetMultipleAccountsWithOpts adds a `Client.GetMultipleAccountsWithOpts` request.
+func (b *Batch) GetMultipleAccountsWithOpts(accounts []solana.PublicKey, opts *GetMultipleAccountsOpts) *GetMultipleAccountsFuture {
+	f := &GetMultipleAccountsFuture{}
+	f.req = b.add(func(ctx context.Context, cl *Client) (err error) {
+		f.result, err = cl.GetMultipleAccountsWithOpts(ctx, accounts, opts)
+		return err
+	})
+	return f
+}
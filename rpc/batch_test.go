@@ -0,0 +1,151 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/rpc/jsonrpc"
+)
+
+type batchServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	calls       int
+	sizes       []int
+	inFlight    int
+	maxInFlight int
+}
+
+func newBatchServer(t *testing.T, balances map[string]uint64) *batchServer {
+	srv := &batchServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var requests []struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+			ID     int           `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&requests))
+
+		srv.mu.Lock()
+		srv.calls++
+		srv.sizes = append(srv.sizes, len(requests))
+		srv.inFlight++
+		if srv.inFlight > srv.maxInFlight {
+			srv.maxInFlight = srv.inFlight
+		}
+		srv.mu.Unlock()
+		defer func() {
+			srv.mu.Lock()
+			srv.inFlight--
+			srv.mu.Unlock()
+		}()
+
+		var responses []interface{}
+		for _, request := range requests {
+			response := M{"jsonrpc": "2.0", "id": request.ID}
+			switch request.Method {
+			case "getBalance":
+				response["result"] = M{"context": M{"slot": 1}, "value": balances[request.Params[0].(string)]}
+			case "getAccountInfo":
+				response["result"] = M{"context": M{"slot": 1}, "value": nil}
+			default:
+				response["error"] = M{"code": -32601, "message": "Method not found"}
+			}
+			responses = append(responses, response)
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(responses))
+	}))
+	return srv
+}
+
+func TestBatch(t *testing.T) {
+	balances := map[string]uint64{}
+	var keys []solana.PublicKey
+	for i := 0; i < 7; i++ {
+		key := solana.NewWallet().PublicKey()
+		keys = append(keys, key)
+		balances[key.String()] = uint64(i * 1000)
+	}
+
+	srv := newBatchServer(t, balances)
+	defer srv.Close()
+
+	batch := New(srv.URL).NewBatch()
+	var balanceFutures []*GetBalanceFuture
+	for _, key := range keys {
+		balanceFutures = append(balanceFutures, batch.GetBalance(key, CommitmentFinalized))
+	}
+	accountFuture := batch.GetAccountInfo(keys[0])
+	invalidFuture := batch.GetAccountInfoWithOpts(keys[0], &GetAccountInfoOpts{
+		Encoding:  solana.EncodingJSONParsed,
+		DataSlice: &DataSlice{},
+	})
+	var statuses GetSignatureStatusesResult
+	statusesFuture := batch.Call(&statuses, "getSignatureStatuses", []solana.Signature{{}})
+	require.Equal(t, 10, batch.Len())
+
+	_, err := accountFuture.Result()
+	require.Equal(t, ErrBatchNotExecuted, err)
+
+	require.NoError(t, batch.DoWithOpts(context.Background(), &BatchOpts{MaxBatchSize: 3, MaxConcurrency: 2}))
+	require.Equal(t, ErrBatchAlreadyExecuted, batch.Do(context.Background()))
+
+	assert.Equal(t, 3, srv.calls)
+	assert.ElementsMatch(t, []int{3, 3, 3}, srv.sizes)
+	assert.LessOrEqual(t, srv.maxInFlight, 2)
+
+	for i, future := range balanceFutures {
+		result, err := future.Result()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i*1000), result.Value)
+	}
+
+	_, err = accountFuture.Result()
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = invalidFuture.Result()
+	assert.EqualError(t, err, "cannot use dataSlice with EncodingJSONParsed")
+
+	var rpcErr *jsonrpc.RPCError
+	require.True(t, errors.As(statusesFuture.Err(), &rpcErr))
+	assert.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestBatch_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	batch := New(srv.URL).NewBatch()
+	future := batch.GetBalance(solana.SystemProgramID, "")
+	err := batch.Do(context.Background())
+	require.Error(t, err)
+
+	_, futureErr := future.Result()
+	assert.Error(t, futureErr)
+	assert.True(t, errors.Is(err, futureErr))
+}
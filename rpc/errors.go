@@ -14,6 +14,16 @@
 
 package rpc
 
+import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/xmcontinue/solana-go"
+)
+
 // rpc error:
 // - https://github.com/solana-labs/solana/blob/d5961e9d9f005966f409fbddd40c3651591b27fb/client/src/rpc_custom_error.rs
 
@@ -23,3 +33,540 @@ package rpc
 
 // instruction error
 // - https://github.com/solana-labs/solana/blob/f6371cce176d481b4132e5061262ca015db0f8b1/sdk/program/src/instruction.rs
+
+// Sentinels of the variants of TransactionError, for `errors.Is`.
+var (
+	ErrTransactionAccountInUse                          = errors.New("AccountInUse")
+	ErrTransactionAccountLoadedTwice                    = errors.New("AccountLoadedTwice")
+	ErrTransactionAccountNotFound                       = errors.New("AccountNotFound")
+	ErrTransactionProgramAccountNotFound                = errors.New("ProgramAccountNotFound")
+	ErrTransactionInsufficientFundsForFee               = errors.New("InsufficientFundsForFee")
+	ErrTransactionInvalidAccountForFee                  = errors.New("InvalidAccountForFee")
+	ErrTransactionAlreadyProcessed                      = errors.New("AlreadyProcessed")
+	ErrTransactionBlockhashNotFound                     = errors.New("BlockhashNotFound")
+	ErrTransactionInstructionError                      = errors.New("InstructionError")
+	ErrTransactionCallChainTooDeep                      = errors.New("CallChainTooDeep")
+	ErrTransactionMissingSignatureForFee                = errors.New("MissingSignatureForFee")
+	ErrTransactionInvalidAccountIndex                   = errors.New("InvalidAccountIndex")
+	ErrTransactionSignatureFailure                      = errors.New("SignatureFailure")
+	ErrTransactionInvalidProgramForExecution            = errors.New("InvalidProgramForExecution")
+	ErrTransactionSanitizeFailure                       = errors.New("SanitizeFailure")
+	ErrTransactionClusterMaintenance                    = errors.New("ClusterMaintenance")
+	ErrTransactionAccountBorrowOutstanding              = errors.New("AccountBorrowOutstanding")
+	ErrTransactionWouldExceedMaxBlockCostLimit          = errors.New("WouldExceedMaxBlockCostLimit")
+	ErrTransactionUnsupportedVersion                    = errors.New("UnsupportedVersion")
+	ErrTransactionInvalidWritableAccount                = errors.New("InvalidWritableAccount")
+	ErrTransactionWouldExceedMaxAccountCostLimit        = errors.New("WouldExceedMaxAccountCostLimit")
+	ErrTransactionWouldExceedAccountDataBlockLimit      = errors.New("WouldExceedAccountDataBlockLimit")
+	ErrTransactionTooManyAccountLocks                   = errors.New("TooManyAccountLocks")
+	ErrTransactionAddressLookupTableNotFound            = errors.New("AddressLookupTableNotFound")
+	ErrTransactionInvalidAddressLookupTableOwner        = errors.New("InvalidAddressLookupTableOwner")
+	ErrTransactionInvalidAddressLookupTableData         = errors.New("InvalidAddressLookupTableData")
+	ErrTransactionInvalidAddressLookupTableIndex        = errors.New("InvalidAddressLookupTableIndex")
+	ErrTransactionInvalidRentPayingAccount              = errors.New("InvalidRentPayingAccount")
+	ErrTransactionWouldExceedMaxVoteCostLimit           = errors.New("WouldExceedMaxVoteCostLimit")
+	ErrTransactionWouldExceedAccountDataTotalLimit      = errors.New("WouldExceedAccountDataTotalLimit")
+	ErrTransactionDuplicateInstruction                  = errors.New("DuplicateInstruction")
+	ErrTransactionInsufficientFundsForRent              = errors.New("InsufficientFundsForRent")
+	ErrTransactionMaxLoadedAccountsDataSizeExceeded     = errors.New("MaxLoadedAccountsDataSizeExceeded")
+	ErrTransactionInvalidLoadedAccountsDataSizeLimit    = errors.New("InvalidLoadedAccountsDataSizeLimit")
+	ErrTransactionResanitizationNeeded                  = errors.New("ResanitizationNeeded")
+	ErrTransactionProgramExecutionTemporarilyRestricted = errors.New("ProgramExecutionTemporarilyRestricted")
+	ErrTransactionUnbalancedTransaction                 = errors.New("UnbalancedTransaction")
+	ErrTransactionProgramCacheHitMaxLimit               = errors.New("ProgramCacheHitMaxLimit")
+	ErrTransactionCommitCancelled                       = errors.New("CommitCancelled")
+)
+
+var transactionErrorSentinels = map[string]error{
+	"AccountInUse":                          ErrTransactionAccountInUse,
+	"AccountLoadedTwice":                    ErrTransactionAccountLoadedTwice,
+	"AccountNotFound":                       ErrTransactionAccountNotFound,
+	"ProgramAccountNotFound":                ErrTransactionProgramAccountNotFound,
+	"InsufficientFundsForFee":               ErrTransactionInsufficientFundsForFee,
+	"InvalidAccountForFee":                  ErrTransactionInvalidAccountForFee,
+	"AlreadyProcessed":                      ErrTransactionAlreadyProcessed,
+	"BlockhashNotFound":                     ErrTransactionBlockhashNotFound,
+	"InstructionError":                      ErrTransactionInstructionError,
+	"CallChainTooDeep":                      ErrTransactionCallChainTooDeep,
+	"MissingSignatureForFee":                ErrTransactionMissingSignatureForFee,
+	"InvalidAccountIndex":                   ErrTransactionInvalidAccountIndex,
+	"SignatureFailure":                      ErrTransactionSignatureFailure,
+	"InvalidProgramForExecution":            ErrTransactionInvalidProgramForExecution,
+	"SanitizeFailure":                       ErrTransactionSanitizeFailure,
+	"ClusterMaintenance":                    ErrTransactionClusterMaintenance,
+	"AccountBorrowOutstanding":              ErrTransactionAccountBorrowOutstanding,
+	"WouldExceedMaxBlockCostLimit":          ErrTransactionWouldExceedMaxBlockCostLimit,
+	"UnsupportedVersion":                    ErrTransactionUnsupportedVersion,
+	"InvalidWritableAccount":                ErrTransactionInvalidWritableAccount,
+	"WouldExceedMaxAccountCostLimit":        ErrTransactionWouldExceedMaxAccountCostLimit,
+	"WouldExceedAccountDataBlockLimit":      ErrTransactionWouldExceedAccountDataBlockLimit,
+	"TooManyAccountLocks":                   ErrTransactionTooManyAccountLocks,
+	"AddressLookupTableNotFound":            ErrTransactionAddressLookupTableNotFound,
+	"InvalidAddressLookupTableOwner":        ErrTransactionInvalidAddressLookupTableOwner,
+	"InvalidAddressLookupTableData":         ErrTransactionInvalidAddressLookupTableData,
+	"InvalidAddressLookupTableIndex":        ErrTransactionInvalidAddressLookupTableIndex,
+	"InvalidRentPayingAccount":              ErrTransactionInvalidRentPayingAccount,
+	"WouldExceedMaxVoteCostLimit":           ErrTransactionWouldExceedMaxVoteCostLimit,
+	"WouldExceedAccountDataTotalLimit":      ErrTransactionWouldExceedAccountDataTotalLimit,
+	"DuplicateInstruction":                  ErrTransactionDuplicateInstruction,
+	"InsufficientFundsForRent":              ErrTransactionInsufficientFundsForRent,
+	"MaxLoadedAccountsDataSizeExceeded":     ErrTransactionMaxLoadedAccountsDataSizeExceeded,
+	"InvalidLoadedAccountsDataSizeLimit":    ErrTransactionInvalidLoadedAccountsDataSizeLimit,
+	"ResanitizationNeeded":                  ErrTransactionResanitizationNeeded,
+	"ProgramExecutionTemporarilyRestricted": ErrTransactionProgramExecutionTemporarilyRestricted,
+	"UnbalancedTransaction":                 ErrTransactionUnbalancedTransaction,
+	"ProgramCacheHitMaxLimit":               ErrTransactionProgramCacheHitMaxLimit,
+	"CommitCancelled":                       ErrTransactionCommitCancelled,
+}
+
+// Sentinels of the variants of InstructionError, for `errors.Is`.
+var (
+	ErrInstructionGenericError                           = errors.New("GenericError")
+	ErrInstructionInvalidArgument                        = errors.New("InvalidArgument")
+	ErrInstructionInvalidInstructionData                 = errors.New("InvalidInstructionData")
+	ErrInstructionInvalidAccountData                     = errors.New("InvalidAccountData")
+	ErrInstructionAccountDataTooSmall                    = errors.New("AccountDataTooSmall")
+	ErrInstructionInsufficientFunds                      = errors.New("InsufficientFunds")
+	ErrInstructionIncorrectProgramId                     = errors.New("IncorrectProgramId")
+	ErrInstructionMissingRequiredSignature               = errors.New("MissingRequiredSignature")
+	ErrInstructionAccountAlreadyInitialized              = errors.New("AccountAlreadyInitialized")
+	ErrInstructionUninitializedAccount                   = errors.New("UninitializedAccount")
+	ErrInstructionUnbalancedInstruction                  = errors.New("UnbalancedInstruction")
+	ErrInstructionModifiedProgramId                      = errors.New("ModifiedProgramId")
+	ErrInstructionExternalAccountLamportSpend            = errors.New("ExternalAccountLamportSpend")
+	ErrInstructionExternalAccountDataModified            = errors.New("ExternalAccountDataModified")
+	ErrInstructionReadonlyLamportChange                  = errors.New("ReadonlyLamportChange")
+	ErrInstructionReadonlyDataModified                   = errors.New("ReadonlyDataModified")
+	ErrInstructionDuplicateAccountIndex                  = errors.New("DuplicateAccountIndex")
+	ErrInstructionExecutableModified                     = errors.New("ExecutableModified")
+	ErrInstructionRentEpochModified                      = errors.New("RentEpochModified")
+	ErrInstructionNotEnoughAccountKeys                   = errors.New("NotEnoughAccountKeys")
+	ErrInstructionAccountDataSizeChanged                 = errors.New("AccountDataSizeChanged")
+	ErrInstructionAccountNotExecutable                   = errors.New("AccountNotExecutable")
+	ErrInstructionAccountBorrowFailed                    = errors.New("AccountBorrowFailed")
+	ErrInstructionAccountBorrowOutstanding               = errors.New("AccountBorrowOutstanding")
+	ErrInstructionDuplicateAccountOutOfSync              = errors.New("DuplicateAccountOutOfSync")
+	ErrInstructionCustom                                 = errors.New("Custom")
+	ErrInstructionInvalidError                           = errors.New("InvalidError")
+	ErrInstructionExecutableDataModified                 = errors.New("ExecutableDataModified")
+	ErrInstructionExecutableLamportChange                = errors.New("ExecutableLamportChange")
+	ErrInstructionExecutableAccountNotRentExempt         = errors.New("ExecutableAccountNotRentExempt")
+	ErrInstructionUnsupportedProgramId                   = errors.New("UnsupportedProgramId")
+	ErrInstructionCallDepth                              = errors.New("CallDepth")
+	ErrInstructionMissingAccount                         = errors.New("MissingAccount")
+	ErrInstructionReentrancyNotAllowed                   = errors.New("ReentrancyNotAllowed")
+	ErrInstructionMaxSeedLengthExceeded                  = errors.New("MaxSeedLengthExceeded")
+	ErrInstructionInvalidSeeds                           = errors.New("InvalidSeeds")
+	ErrInstructionInvalidRealloc                         = errors.New("InvalidRealloc")
+	ErrInstructionComputationalBudgetExceeded            = errors.New("ComputationalBudgetExceeded")
+	ErrInstructionPrivilegeEscalation                    = errors.New("PrivilegeEscalation")
+	ErrInstructionProgramEnvironmentSetupFailure         = errors.New("ProgramEnvironmentSetupFailure")
+	ErrInstructionProgramFailedToComplete                = errors.New("ProgramFailedToComplete")
+	ErrInstructionProgramFailedToCompile                 = errors.New("ProgramFailedToCompile")
+	ErrInstructionImmutable                              = errors.New("Immutable")
+	ErrInstructionIncorrectAuthority                     = errors.New("IncorrectAuthority")
+	ErrInstructionBorshIoError                           = errors.New("BorshIoError")
+	ErrInstructionAccountNotRentExempt                   = errors.New("AccountNotRentExempt")
+	ErrInstructionInvalidAccountOwner                    = errors.New("InvalidAccountOwner")
+	ErrInstructionArithmeticOverflow                     = errors.New("ArithmeticOverflow")
+	ErrInstructionUnsupportedSysvar                      = errors.New("UnsupportedSysvar")
+	ErrInstructionIllegalOwner                           = errors.New("IllegalOwner")
+	ErrInstructionMaxAccountsDataAllocationsExceeded     = errors.New("MaxAccountsDataAllocationsExceeded")
+	ErrInstructionMaxAccountsExceeded                    = errors.New("MaxAccountsExceeded")
+	ErrInstructionMaxInstructionTraceLengthExceeded      = errors.New("MaxInstructionTraceLengthExceeded")
+	ErrInstructionBuiltinProgramsMustConsumeComputeUnits = errors.New("BuiltinProgramsMustConsumeComputeUnits")
+)
+
+var instructionErrorSentinels = map[string]error{
+	"GenericError":                           ErrInstructionGenericError,
+	"InvalidArgument":                        ErrInstructionInvalidArgument,
+	"InvalidInstructionData":                 ErrInstructionInvalidInstructionData,
+	"InvalidAccountData":                     ErrInstructionInvalidAccountData,
+	"AccountDataTooSmall":                    ErrInstructionAccountDataTooSmall,
+	"InsufficientFunds":                      ErrInstructionInsufficientFunds,
+	"IncorrectProgramId":                     ErrInstructionIncorrectProgramId,
+	"MissingRequiredSignature":               ErrInstructionMissingRequiredSignature,
+	"AccountAlreadyInitialized":              ErrInstructionAccountAlreadyInitialized,
+	"UninitializedAccount":                   ErrInstructionUninitializedAccount,
+	"UnbalancedInstruction":                  ErrInstructionUnbalancedInstruction,
+	"ModifiedProgramId":                      ErrInstructionModifiedProgramId,
+	"ExternalAccountLamportSpend":            ErrInstructionExternalAccountLamportSpend,
+	"ExternalAccountDataModified":            ErrInstructionExternalAccountDataModified,
+	"ReadonlyLamportChange":                  ErrInstructionReadonlyLamportChange,
+	"ReadonlyDataModified":                   ErrInstructionReadonlyDataModified,
+	"DuplicateAccountIndex":                  ErrInstructionDuplicateAccountIndex,
+	"ExecutableModified":                     ErrInstructionExecutableModified,
+	"RentEpochModified":                      ErrInstructionRentEpochModified,
+	"NotEnoughAccountKeys":                   ErrInstructionNotEnoughAccountKeys,
+	"AccountDataSizeChanged":                 ErrInstructionAccountDataSizeChanged,
+	"AccountNotExecutable":                   ErrInstructionAccountNotExecutable,
+	"AccountBorrowFailed":                    ErrInstructionAccountBorrowFailed,
+	"AccountBorrowOutstanding":               ErrInstructionAccountBorrowOutstanding,
+	"DuplicateAccountOutOfSync":              ErrInstructionDuplicateAccountOutOfSync,
+	"Custom":                                 ErrInstructionCustom,
+	"InvalidError":                           ErrInstructionInvalidError,
+	"ExecutableDataModified":                 ErrInstructionExecutableDataModified,
+	"ExecutableLamportChange":                ErrInstructionExecutableLamportChange,
+	"ExecutableAccountNotRentExempt":         ErrInstructionExecutableAccountNotRentExempt,
+	"UnsupportedProgramId":                   ErrInstructionUnsupportedProgramId,
+	"CallDepth":                              ErrInstructionCallDepth,
+	"MissingAccount":                         ErrInstructionMissingAccount,
+	"ReentrancyNotAllowed":                   ErrInstructionReentrancyNotAllowed,
+	"MaxSeedLengthExceeded":                  ErrInstructionMaxSeedLengthExceeded,
+	"InvalidSeeds":                           ErrInstructionInvalidSeeds,
+	"InvalidRealloc":                         ErrInstructionInvalidRealloc,
+	"ComputationalBudgetExceeded":            ErrInstructionComputationalBudgetExceeded,
+	"PrivilegeEscalation":                    ErrInstructionPrivilegeEscalation,
+	"ProgramEnvironmentSetupFailure":         ErrInstructionProgramEnvironmentSetupFailure,
+	"ProgramFailedToComplete":                ErrInstructionProgramFailedToComplete,
+	"ProgramFailedToCompile":                 ErrInstructionProgramFailedToCompile,
+	"Immutable":                              ErrInstructionImmutable,
+	"IncorrectAuthority":                     ErrInstructionIncorrectAuthority,
+	"BorshIoError":                           ErrInstructionBorshIoError,
+	"AccountNotRentExempt":                   ErrInstructionAccountNotRentExempt,
+	"InvalidAccountOwner":                    ErrInstructionInvalidAccountOwner,
+	"ArithmeticOverflow":                     ErrInstructionArithmeticOverflow,
+	"UnsupportedSysvar":                      ErrInstructionUnsupportedSysvar,
+	"IllegalOwner":                           ErrInstructionIllegalOwner,
+	"MaxAccountsDataAllocationsExceeded":     ErrInstructionMaxAccountsDataAllocationsExceeded,
+	"MaxAccountsExceeded":                    ErrInstructionMaxAccountsExceeded,
+	"MaxInstructionTraceLengthExceeded":      ErrInstructionMaxInstructionTraceLengthExceeded,
+	"BuiltinProgramsMustConsumeComputeUnits": ErrInstructionBuiltinProgramsMustConsumeComputeUnits,
+}
+
+// TransactionError is the error of a failed transaction, as found in the
+// `err` field of the transaction metadata, of the simulation results and
+// of the signature notifications.
+type TransactionError struct {
+	// Name of the variant, e.g. "BlockhashNotFound" or "InstructionError".
+	Name string
+
+	// InstructionIndex is the index of the instruction of the
+	// "InstructionError" and "DuplicateInstruction" variants.
+	InstructionIndex *uint8
+
+	// AccountIndex is the index of the account of the
+	// "InsufficientFundsForRent" and "ProgramExecutionTemporarilyRestricted" variants.
+	AccountIndex *uint8
+
+	// Instruction is the error of the failed instruction of the
+	// "InstructionError" variant.
+	Instruction *InstructionError
+}
+
+// InstructionError is the error of a failed instruction.
+type InstructionError struct {
+	// Index of the failed instruction in the transaction.
+	Index uint8
+
+	// Name of the variant, e.g. "InvalidAccountData" or "Custom".
+	Name string
+
+	// Code of the "Custom" variant.
+	Code *uint32
+
+	// Message of the "BorshIoError" variant.
+	Message string
+
+	// ProgramID is the program that raised the error, and ProgramError the
+	// registered error of its custom code; they are set by
+	// `TransactionError.ResolveProgram`. The program is the one of the
+	// instruction, or a program it invoked when the logs show it.
+	ProgramID    solana.PublicKey
+	ProgramError *solana.ProgramError
+}
+
+// ParseTransactionError parses the `err` field of the responses, as decoded
+// from JSON into an `interface{}`, or as a `stdjson.RawMessage`. It returns nil
+// if the transaction succeeded.
+func ParseTransactionError(raw interface{}) (*TransactionError, error) {
+	var data []byte
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case stdjson.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	default:
+		var err error
+		data, err = json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode transaction error: %w", err)
+		}
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	out := new(TransactionError)
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnmarshalJSON decodes the JSON representation of a TransactionError:
+// a string for the variants without fields, an object otherwise, e.g.
+// `{"InstructionError":[0,{"Custom":1}]}`.
+func (e *TransactionError) UnmarshalJSON(data []byte) error {
+	name, value, err := decodeErrorVariant(data)
+	if err != nil {
+		return fmt.Errorf("unable to decode transaction error: %w", err)
+	}
+	*e = TransactionError{Name: name}
+	if value == nil {
+		return nil
+	}
+
+	switch name {
+	case "InstructionError":
+		var tuple []stdjson.RawMessage
+		if err := json.Unmarshal(value, &tuple); err != nil {
+			return fmt.Errorf("unable to decode instruction error: %w", err)
+		}
+		if len(tuple) != 2 {
+			return fmt.Errorf("unable to decode instruction error: expected 2 elements, got %d", len(tuple))
+		}
+		var index uint8
+		if err := json.Unmarshal(tuple[0], &index); err != nil {
+			return fmt.Errorf("unable to decode instruction error index: %w", err)
+		}
+		e.InstructionIndex = &index
+		e.Instruction = &InstructionError{Index: index}
+		return e.Instruction.UnmarshalJSON(tuple[1])
+	case "DuplicateInstruction":
+		var index uint8
+		if err := json.Unmarshal(value, &index); err != nil {
+			return fmt.Errorf("unable to decode %s index: %w", name, err)
+		}
+		e.InstructionIndex = &index
+	case "InsufficientFundsForRent", "ProgramExecutionTemporarilyRestricted":
+		var fields struct {
+			AccountIndex uint8 `json:"account_index"`
+		}
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return fmt.Errorf("unable to decode %s account index: %w", name, err)
+		}
+		e.AccountIndex = &fields.AccountIndex
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the JSON representation of an InstructionError, the
+// index of the instruction being set by the enclosing TransactionError.
+func (e *InstructionError) UnmarshalJSON(data []byte) error {
+	name, value, err := decodeErrorVariant(data)
+	if err != nil {
+		return fmt.Errorf("unable to decode instruction error: %w", err)
+	}
+	e.Name = name
+	if value == nil {
+		return nil
+	}
+
+	switch name {
+	case "Custom":
+		var code uint32
+		if err := json.Unmarshal(value, &code); err != nil {
+			return fmt.Errorf("unable to decode custom program error code: %w", err)
+		}
+		e.Code = &code
+	case "BorshIoError":
+		if err := json.Unmarshal(value, &e.Message); err != nil {
+			return fmt.Errorf("unable to decode %s message: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// decodeErrorVariant decodes a serde enum: the name of the variant as a
+// string, or an object with the name of the variant as single key.
+func decodeErrorVariant(data []byte) (name string, value stdjson.RawMessage, err error) {
+	if err := json.Unmarshal(data, &name); err == nil {
+		return name, nil, nil
+	}
+
+	var object map[string]stdjson.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", nil, err
+	}
+	if len(object) != 1 {
+		return "", nil, fmt.Errorf("expected a single variant, got %d", len(object))
+	}
+	for name, value = range object {
+	}
+	return name, value, nil
+}
+
+// ResolveProgram sets the program that raised the error of the failed
+// instruction, and the registered error of its custom code. The error is
+// often raised by a program the instruction invoked, e.g. the Token program
+// under a swap program: when the `logs` of the transaction are available,
+// the program is the deepest failed invocation of the instruction, and
+// otherwise the program of the instruction in `tx`. Both can be nil.
+func (e *TransactionError) ResolveProgram(tx *solana.Transaction, logs []string) error {
+	if e.Instruction == nil {
+		return nil
+	}
+	if programID, ok := e.Instruction.failedProgram(logs); ok {
+		e.Instruction.ProgramID = programID
+		if e.Instruction.Code != nil {
+			e.Instruction.ProgramError, _ = solana.LookupProgramError(programID, *e.Instruction.Code)
+		}
+		return nil
+	}
+	if tx == nil {
+		return nil
+	}
+	if int(e.Instruction.Index) >= len(tx.Message.Instructions) {
+		return fmt.Errorf("instruction %d out of range", e.Instruction.Index)
+	}
+	programID, err := tx.ResolveProgramIDIndex(tx.Message.Instructions[e.Instruction.Index].ProgramIDIndex)
+	if err != nil {
+		return err
+	}
+	e.Instruction.ProgramID = programID
+	if e.Instruction.Code != nil {
+		e.Instruction.ProgramError, _ = solana.LookupProgramError(programID, *e.Instruction.Code)
+	}
+	return nil
+}
+
+var (
+	invokeLogRegex   = regexp.MustCompile(`^Program (\w+) invoke \[(\d+)\]$`)
+	failedLogRegex   = regexp.MustCompile(`^Program (\w+) failed: (.*)$`)
+	customErrorRegex = regexp.MustCompile(`custom program error: 0x([0-9a-fA-F]+)$`)
+)
+
+// failedProgram returns the program that made the instruction fail in
+// `logs`: the first one to fail within the instruction, the failure then
+// propagating to the programs that invoked it. It returns false if the logs
+// don't show it, or if it failed with another custom code than the error.
+func (e *InstructionError) failedProgram(logs []string) (solana.PublicKey, bool) {
+	instruction := -1
+	for _, line := range logs {
+		if match := invokeLogRegex.FindStringSubmatch(line); match != nil {
+			if match[2] == "1" {
+				instruction++
+			}
+			continue
+		}
+		if instruction < int(e.Index) {
+			continue
+		}
+		if instruction > int(e.Index) {
+			break
+		}
+
+		match := failedLogRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		programID, err := solana.PublicKeyFromBase58(match[1])
+		if err != nil {
+			return solana.PublicKey{}, false
+		}
+		if e.Code != nil {
+			custom := customErrorRegex.FindStringSubmatch(match[2])
+			if custom == nil {
+				return solana.PublicKey{}, false
+			}
+			code, err := strconv.ParseUint(custom[1], 16, 32)
+			if err != nil || uint32(code) != *e.Code {
+				return solana.PublicKey{}, false
+			}
+		}
+		return programID, true
+	}
+	return solana.PublicKey{}, false
+}
+
+// CustomCode returns the custom code of the failed instruction.
+func (e *TransactionError) CustomCode() (uint32, bool) {
+	if e.Instruction == nil || e.Instruction.Code == nil {
+		return 0, false
+	}
+	return *e.Instruction.Code, true
+}
+
+func (e *TransactionError) Error() string {
+	switch {
+	case e.Instruction != nil:
+		return fmt.Sprintf("Error processing Instruction %d: %s", e.Instruction.Index, e.Instruction.description())
+	case e.InstructionIndex != nil:
+		return fmt.Sprintf("%s: instruction %d", e.Name, *e.InstructionIndex)
+	case e.AccountIndex != nil:
+		return fmt.Sprintf("%s: account %d", e.Name, *e.AccountIndex)
+	}
+	return e.Name
+}
+
+// Is reports whether `target` is the sentinel of the variant of the error.
+func (e *TransactionError) Is(target error) bool {
+	sentinel, ok := transactionErrorSentinels[e.Name]
+	return ok && target == sentinel
+}
+
+// Unwrap returns the error of the failed instruction.
+func (e *TransactionError) Unwrap() error {
+	if e.Instruction == nil {
+		return nil
+	}
+	return e.Instruction
+}
+
+func (e *InstructionError) description() string {
+	switch {
+	case e.ProgramError != nil:
+		return e.ProgramError.Error()
+	case e.Code != nil:
+		return fmt.Sprintf("custom program error: 0x%x", *e.Code)
+	case e.Message != "":
+		return fmt.Sprintf("%s: %s", e.Name, e.Message)
+	}
+	return e.Name
+}
+
+func (e *InstructionError) Error() string {
+	return fmt.Sprintf("instruction %d: %s", e.Index, e.description())
+}
+
+// Is reports whether `target` is the sentinel of the variant of the error.
+func (e *InstructionError) Is(target error) bool {
+	sentinel, ok := instructionErrorSentinels[e.Name]
+	return ok && target == sentinel
+}
+
+// Unwrap returns the registered error of the custom code, if resolved.
+func (e *InstructionError) Unwrap() error {
+	if e.ProgramError == nil {
+		return nil
+	}
+	return e.ProgramError
+}
+
+// TransactionError returns the error of the transaction, nil if it
+// succeeded. The program that raised the error is resolved from the log
+// messages, or from `tx`, which can be nil.
+func (m *TransactionMeta) TransactionError(tx *solana.Transaction) (*TransactionError, error) {
+	return parseAndResolveTransactionError(m.Err, tx, m.LogMessages)
+}
+
+// TransactionError returns the error of the simulated transaction, nil if
+// it succeeded. The program that raised the error is resolved from the
+// logs, or from `tx`, which can be nil.
+func (r *SimulateTransactionResult) TransactionError(tx *solana.Transaction) (*TransactionError, error) {
+	return parseAndResolveTransactionError(r.Err, tx, r.Logs)
+}
+
+func parseAndResolveTransactionError(raw interface{}, tx *solana.Transaction, logs []string) (*TransactionError, error) {
+	txErr, err := ParseTransactionError(raw)
+	if err != nil || txErr == nil {
+		return nil, err
+	}
+	if err := txErr.ResolveProgram(tx, logs); err != nil {
+		return nil, err
+	}
+	return txErr, nil
+}
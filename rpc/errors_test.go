@@ -0,0 +1,214 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestParseTransactionError(t *testing.T) {
+	tests := []struct {
+		raw      string
+		sentinel error
+		expected TransactionError
+		message  string
+	}{
+		{
+			raw:      `"BlockhashNotFound"`,
+			sentinel: ErrTransactionBlockhashNotFound,
+			expected: TransactionError{Name: "BlockhashNotFound"},
+			message:  "BlockhashNotFound",
+		},
+		{
+			raw:      `{"DuplicateInstruction":2}`,
+			sentinel: ErrTransactionDuplicateInstruction,
+			expected: TransactionError{Name: "DuplicateInstruction", InstructionIndex: uint8Ptr(2)},
+			message:  "DuplicateInstruction: instruction 2",
+		},
+		{
+			raw:      `{"InsufficientFundsForRent":{"account_index":3}}`,
+			sentinel: ErrTransactionInsufficientFundsForRent,
+			expected: TransactionError{Name: "InsufficientFundsForRent", AccountIndex: uint8Ptr(3)},
+			message:  "InsufficientFundsForRent: account 3",
+		},
+		{
+			raw:      `{"InstructionError":[1,"InvalidAccountData"]}`,
+			sentinel: ErrInstructionInvalidAccountData,
+			expected: TransactionError{
+				Name:             "InstructionError",
+				InstructionIndex: uint8Ptr(1),
+				Instruction:      &InstructionError{Index: 1, Name: "InvalidAccountData"},
+			},
+			message: "Error processing Instruction 1: InvalidAccountData",
+		},
+		{
+			raw:      `{"InstructionError":[0,{"Custom":6001}]}`,
+			sentinel: ErrInstructionCustom,
+			expected: TransactionError{
+				Name:             "InstructionError",
+				InstructionIndex: uint8Ptr(0),
+				Instruction:      &InstructionError{Index: 0, Name: "Custom", Code: uint32Ptr(6001)},
+			},
+			message: "Error processing Instruction 0: custom program error: 0x1771",
+		},
+		{
+			raw:      `{"InstructionError":[0,{"BorshIoError":"Unexpected length of input"}]}`,
+			sentinel: ErrInstructionBorshIoError,
+			expected: TransactionError{
+				Name:             "InstructionError",
+				InstructionIndex: uint8Ptr(0),
+				Instruction:      &InstructionError{Index: 0, Name: "BorshIoError", Message: "Unexpected length of input"},
+			},
+			message: "Error processing Instruction 0: BorshIoError: Unexpected length of input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			var raw interface{}
+			require.NoError(t, stdjson.Unmarshal([]byte(test.raw), &raw))
+
+			for _, in := range []interface{}{raw, stdjson.RawMessage(test.raw)} {
+				txErr, err := ParseTransactionError(in)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, *txErr)
+				assert.Equal(t, test.message, txErr.Error())
+				assert.True(t, errors.Is(txErr, test.sentinel))
+				assert.False(t, errors.Is(txErr, ErrTransactionAccountInUse))
+			}
+		})
+	}
+
+	txErr, err := ParseTransactionError(nil)
+	require.NoError(t, err)
+	assert.Nil(t, txErr)
+
+	txErr, err = ParseTransactionError("SomeFutureError")
+	require.NoError(t, err)
+	assert.Equal(t, "SomeFutureError", txErr.Error())
+
+	for _, invalid := range []string{`1`, `{"A":1,"B":2}`, `{"InstructionError":[0]}`, `{"InstructionError":[0,{"Custom":-1}]}`} {
+		_, err = ParseTransactionError(stdjson.RawMessage(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTransactionMeta_TransactionError(t *testing.T) {
+	payer := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	programID := solana.MustPublicKeyFromBase58("CMvYbQyX8dcEEZ8dKfL8mk4ZqApZB5KeYuxoDgTg4B1z")
+	solana.RegisterProgramErrors(programID, solana.ProgramError{Code: 6001, Name: "SlippageExceeded", Message: "Slippage tolerance exceeded"})
+
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(solana.ComputeBudget, nil, []byte{2}),
+		solana.NewInstruction(programID, solana.AccountMetaSlice{solana.Meta(payer).WRITE().SIGNER()}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	var meta TransactionMeta
+	require.NoError(t, stdjson.Unmarshal([]byte(`{"err":{"InstructionError":[1,{"Custom":6001}]}}`), &meta))
+
+	txErr, err := meta.TransactionError(tx)
+	require.NoError(t, err)
+	assert.Equal(t, programID, txErr.Instruction.ProgramID)
+	code, ok := txErr.CustomCode()
+	require.True(t, ok)
+	assert.Equal(t, uint32(6001), code)
+	assert.Equal(t, "Error processing Instruction 1: SlippageExceeded (0x1771): Slippage tolerance exceeded", txErr.Error())
+
+	var programErr *solana.ProgramError
+	require.True(t, errors.As(txErr, &programErr))
+	assert.Equal(t, "SlippageExceeded", programErr.Name)
+	assert.True(t, errors.Is(txErr, ErrInstructionCustom))
+
+	// Without the transaction, the code is not resolved.
+	txErr, err = meta.TransactionError(nil)
+	require.NoError(t, err)
+	assert.Nil(t, txErr.Instruction.ProgramError)
+	assert.False(t, errors.As(txErr, &programErr))
+
+	txErr, err = (&TransactionMeta{}).TransactionError(tx)
+	require.NoError(t, err)
+	assert.Nil(t, txErr)
+
+	_, err = (&SimulateTransactionResult{Err: map[string]interface{}{"InstructionError": []interface{}{5, "InvalidArgument"}}}).TransactionError(tx)
+	assert.Error(t, err)
+}
+
+func TestTransactionMeta_TransactionError_NestedInvocation(t *testing.T) {
+	payer := solana.MustPublicKeyFromBase58("9qpUERdcP5YpVVJEd95FskbkvvcfqZ13Hc3GTaZr5Jxv")
+	aggregatorID := solana.MustPublicKeyFromBase58("7xLk17EQQ5KLDLDe44wCmupJKJjTGd8hs3eSVVhCx932")
+	tokenID := solana.MustPublicKeyFromBase58("HWHvQhFmJB3NUcu1aihKmrKegfVxBEHzwVX6yZCKEsi1")
+	solana.RegisterProgramErrors(aggregatorID, solana.ProgramError{Code: 1, Name: "RouteNotFound", Message: "No route found"})
+	solana.RegisterProgramErrors(tokenID, solana.ProgramError{Code: 1, Name: "InsufficientFunds", Message: "Insufficient funds"})
+
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		solana.NewInstruction(solana.ComputeBudget, nil, []byte{2}),
+		solana.NewInstruction(aggregatorID, solana.AccountMetaSlice{solana.Meta(payer).WRITE().SIGNER()}, nil),
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	meta := TransactionMeta{
+		Err: map[string]interface{}{"InstructionError": []interface{}{1, map[string]interface{}{"Custom": 1}}},
+		LogMessages: []string{
+			"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+			"Program ComputeBudget111111111111111111111111111111 success",
+			"Program " + aggregatorID.String() + " invoke [1]",
+			"Program log: Instruction: Route",
+			"Program " + tokenID.String() + " invoke [2]",
+			"Program log: Instruction: Transfer",
+			"Program log: Error: insufficient funds",
+			"Program " + tokenID.String() + " consumed 4000 of 190000 compute units",
+			"Program " + tokenID.String() + " failed: custom program error: 0x1",
+			"Program " + aggregatorID.String() + " consumed 10000 of 200000 compute units",
+			"Program " + aggregatorID.String() + " failed: custom program error: 0x1",
+		},
+	}
+
+	// The code is resolved against the invoked program that raised it.
+	txErr, err := meta.TransactionError(tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), txErr.Instruction.Index)
+	assert.Equal(t, tokenID, txErr.Instruction.ProgramID)
+	require.NotNil(t, txErr.Instruction.ProgramError)
+	assert.Equal(t, "InsufficientFunds", txErr.Instruction.ProgramError.Name)
+
+	// Also without the transaction.
+	txErr, err = meta.TransactionError(nil)
+	require.NoError(t, err)
+	assert.Equal(t, tokenID, txErr.Instruction.ProgramID)
+	assert.Equal(t, "InsufficientFunds", txErr.Instruction.ProgramError.Name)
+
+	// Without the logs, only the program of the instruction is known.
+	meta.LogMessages = nil
+	txErr, err = meta.TransactionError(tx)
+	require.NoError(t, err)
+	assert.Equal(t, aggregatorID, txErr.Instruction.ProgramID)
+	assert.Equal(t, "RouteNotFound", txErr.Instruction.ProgramError.Name)
+}
+
+func uint8Ptr(v uint8) *uint8 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
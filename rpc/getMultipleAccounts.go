@@ -17,10 +17,18 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	bin "github.com/gagliardetto/binary"
 
 	"github.com/xmcontinue/solana-go"
 )
 
+// Maximum number of accounts that can be requested in a single getMultipleAccounts call.
+const MaxGetMultipleAccounts = 100
+
 type GetMultipleAccountsResult struct {
 	RPCContext
 	Value []*Account `json:"value"`
@@ -79,3 +87,187 @@ func (cl *Client) GetMultipleAccountsWithOpts(
 	}
 	return
 }
+
+type GetMultipleAccountsChunkedOpts struct {
+	GetMultipleAccountsOpts
+
+	// MaxConcurrency is the maximum number of calls in flight,
+	// `DefaultBatchConcurrency` if zero.
+	MaxConcurrency int
+}
+
+// GetMultipleAccountsChunked returns the account information for any number
+// of Pubkeys, fetched with concurrent calls of at most `MaxGetMultipleAccounts`
+// accounts. The accounts are in the order of `accounts`, nil for the ones
+// that don't exist, and the context is the one of the oldest call.
+func (cl *Client) GetMultipleAccountsChunked(
+	ctx context.Context,
+	accounts []solana.PublicKey,
+	opts *GetMultipleAccountsChunkedOpts,
+) (*GetMultipleAccountsResult, error) {
+	var callOpts *GetMultipleAccountsOpts
+	maxConcurrency := DefaultBatchConcurrency
+	if opts != nil {
+		callOpts = &opts.GetMultipleAccountsOpts
+		if opts.MaxConcurrency > 0 {
+			maxConcurrency = opts.MaxConcurrency
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := solana.PublicKeySlice(accounts).Split(MaxGetMultipleAccounts)
+	results := make([]*GetMultipleAccountsResult, len(chunks))
+	var firstErr error
+	var once sync.Once
+	semaphore := make(chan struct{}, maxConcurrency)
+	wg := sync.WaitGroup{}
+	for i, chunk := range chunks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, chunk solana.PublicKeySlice) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			result, err := cl.GetMultipleAccountsWithOpts(ctx, chunk, callOpts)
+			if err != nil {
+				// The other calls are cancelled, report the original error.
+				once.Do(func() {
+					firstErr = fmt.Errorf("unable to get accounts %d to %d: %w", i*MaxGetMultipleAccounts, i*MaxGetMultipleAccounts+len(chunk)-1, err)
+					cancel()
+				})
+				return
+			}
+			results[i] = result
+		}(i, chunk)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	out := &GetMultipleAccountsResult{Value: make([]*Account, 0, len(accounts))}
+	for i, result := range results {
+		if len(result.Value) != len(chunks[i]) {
+			return nil, fmt.Errorf("expected %d accounts, got %d", len(chunks[i]), len(result.Value))
+		}
+		if i == 0 || result.Context.Slot < out.Context.Slot {
+			out.Context = result.Context
+		}
+		out.Value = append(out.Value, result.Value...)
+	}
+	return out, nil
+}
+
+// AccountDecodeError is an account whose data could not be decoded.
+type AccountDecodeError struct {
+	// Index of the account in the requested accounts.
+	Index   int
+	Account solana.PublicKey
+	Err     error
+}
+
+func (e *AccountDecodeError) Error() string {
+	return fmt.Sprintf("unable to decode account %s: %s", e.Account, e.Err)
+}
+
+func (e *AccountDecodeError) Unwrap() error {
+	return e.Err
+}
+
+type GetMultipleAccountsDataResult struct {
+	RPCContext
+
+	// Missing are the indexes of the accounts that don't exist, their
+	// element of the output being left to its zero value.
+	Missing []int
+
+	// DecodeErrors are the accounts whose data could not be decoded, their
+	// element of the output being left to its zero value.
+	DecodeErrors []*AccountDecodeError
+}
+
+// GetMultipleAccountsDataInto fetches the accounts with
+// `GetMultipleAccountsChunked` and decodes their binary data into `out`, a
+// pointer to a slice of the account type or of pointers to it, e.g.
+// `*[]token.Mint` or `*[]*token.Mint`. The elements are in the order of
+// `accounts`, the missing accounts and the decoding failures being reported
+// in the result.
+func (cl *Client) GetMultipleAccountsDataInto(
+	ctx context.Context,
+	accounts []solana.PublicKey,
+	out interface{},
+	opts *GetMultipleAccountsChunkedOpts,
+) (*GetMultipleAccountsDataResult, error) {
+	return cl.getMultipleAccountsDataInto(ctx, accounts, out, opts, bin.NewBinDecoder)
+}
+
+// GetMultipleAccountsDataBorshInto is `GetMultipleAccountsDataInto` for
+// accounts encoded with Borsh.
+func (cl *Client) GetMultipleAccountsDataBorshInto(
+	ctx context.Context,
+	accounts []solana.PublicKey,
+	out interface{},
+	opts *GetMultipleAccountsChunkedOpts,
+) (*GetMultipleAccountsDataResult, error) {
+	return cl.getMultipleAccountsDataInto(ctx, accounts, out, opts, bin.NewBorshDecoder)
+}
+
+func (cl *Client) getMultipleAccountsDataInto(
+	ctx context.Context,
+	accounts []solana.PublicKey,
+	out interface{},
+	opts *GetMultipleAccountsChunkedOpts,
+	newDecoder func(data []byte) *bin.Decoder,
+) (*GetMultipleAccountsDataResult, error) {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a pointer to a slice, got %T", out)
+	}
+
+	fetchOpts := GetMultipleAccountsChunkedOpts{}
+	if opts != nil {
+		fetchOpts = *opts
+	}
+	switch fetchOpts.Encoding {
+	case "":
+		fetchOpts.Encoding = solana.EncodingBase64
+	case solana.EncodingJSONParsed, solana.EncodingJSON:
+		return nil, fmt.Errorf("cannot decode accounts with encoding %s", fetchOpts.Encoding)
+	}
+
+	res, err := cl.GetMultipleAccountsChunked(ctx, accounts, &fetchOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	slice := reflect.MakeSlice(rv.Elem().Type(), len(accounts), len(accounts))
+	elemType := slice.Type().Elem()
+	result := &GetMultipleAccountsDataResult{RPCContext: res.RPCContext}
+	for i, account := range res.Value {
+		if account == nil {
+			result.Missing = append(result.Missing, i)
+			continue
+		}
+
+		var value reflect.Value
+		if elemType.Kind() == reflect.Ptr {
+			value = reflect.New(elemType.Elem())
+		} else {
+			value = reflect.New(elemType)
+		}
+		if err := newDecoder(account.Data.GetBinary()).Decode(value.Interface()); err != nil {
+			result.DecodeErrors = append(result.DecodeErrors, &AccountDecodeError{Index: i, Account: accounts[i], Err: err})
+			continue
+		}
+		if elemType.Kind() != reflect.Ptr {
+			value = value.Elem()
+		}
+		slice.Index(i).Set(value)
+	}
+	rv.Elem().Set(slice)
+
+	return result, nil
+}
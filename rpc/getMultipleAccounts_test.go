@@ -0,0 +1,175 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+type accountsServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	sizes       []int
+	inFlight    int
+	maxInFlight int
+}
+
+func newAccountsServer(t *testing.T, data map[string][]byte, failing string) *accountsServer {
+	srv := &accountsServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request struct {
+			Params []interface{} `json:"params"`
+			ID     int           `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		keys := request.Params[0].([]interface{})
+		assert.Equal(t, "base64", request.Params[1].(map[string]interface{})["encoding"])
+
+		srv.mu.Lock()
+		srv.sizes = append(srv.sizes, len(keys))
+		srv.inFlight++
+		if srv.inFlight > srv.maxInFlight {
+			srv.maxInFlight = srv.inFlight
+		}
+		srv.mu.Unlock()
+		defer func() {
+			srv.mu.Lock()
+			srv.inFlight--
+			srv.mu.Unlock()
+		}()
+
+		var accounts []interface{}
+		for _, key := range keys {
+			if key == failing {
+				require.NoError(t, json.NewEncoder(rw).Encode(M{"jsonrpc": "2.0", "id": request.ID, "error": M{"code": -32005, "message": "Node is behind"}}))
+				return
+			}
+			accountData, ok := data[key.(string)]
+			if !ok {
+				accounts = append(accounts, nil)
+				continue
+			}
+			accounts = append(accounts, M{
+				"lamports": 1,
+				"owner":    solana.SystemProgramID.String(),
+				"data":     []string{base64.StdEncoding.EncodeToString(accountData), "base64"},
+			})
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(M{"jsonrpc": "2.0", "id": request.ID, "result": M{
+			"context": M{"slot": 100 + len(keys)},
+			"value":   accounts,
+		}}))
+	}))
+	return srv
+}
+
+type testAccountState struct {
+	Amount uint64
+	Flags  uint32
+}
+
+func TestGetMultipleAccountsDataInto(t *testing.T) {
+	var keys []solana.PublicKey
+	data := map[string][]byte{}
+	for i := 0; i < 250; i++ {
+		key := solana.NewWallet().PublicKey()
+		keys = append(keys, key)
+
+		switch {
+		case i%50 == 7:
+			// Missing account.
+		case i%50 == 9:
+			data[key.String()] = []byte{1, 2, 3}
+		default:
+			buf := make([]byte, 12)
+			binary.LittleEndian.PutUint64(buf, uint64(i))
+			binary.LittleEndian.PutUint32(buf[8:], uint32(i*2))
+			data[key.String()] = buf
+		}
+	}
+
+	srv := newAccountsServer(t, data, "")
+	defer srv.Close()
+	client := New(srv.URL)
+	opts := &GetMultipleAccountsChunkedOpts{MaxConcurrency: 2}
+
+	var states []testAccountState
+	res, err := client.GetMultipleAccountsDataInto(context.Background(), keys, &states, opts)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{100, 100, 50}, srv.sizes)
+	assert.LessOrEqual(t, srv.maxInFlight, 2)
+	assert.Equal(t, uint64(150), res.Context.Slot)
+
+	require.Len(t, states, 250)
+	assert.Equal(t, []int{7, 57, 107, 157, 207}, res.Missing)
+	require.Len(t, res.DecodeErrors, 5)
+	for i, decodeErr := range res.DecodeErrors {
+		assert.Equal(t, i*50+9, decodeErr.Index)
+		assert.Equal(t, keys[i*50+9], decodeErr.Account)
+		assert.Error(t, errors.Unwrap(decodeErr))
+	}
+	for i, state := range states {
+		switch i % 50 {
+		case 7, 9:
+			assert.Equal(t, testAccountState{}, state)
+		default:
+			assert.Equal(t, testAccountState{Amount: uint64(i), Flags: uint32(i * 2)}, state)
+		}
+	}
+
+	var pointers []*testAccountState
+	res, err = client.GetMultipleAccountsDataBorshInto(context.Background(), keys[:10], &pointers, nil)
+	require.NoError(t, err)
+	require.Len(t, pointers, 10)
+	assert.Nil(t, pointers[7])
+	assert.Nil(t, pointers[9])
+	assert.Equal(t, &testAccountState{Amount: 3, Flags: 6}, pointers[3])
+
+	_, err = client.GetMultipleAccountsDataInto(context.Background(), keys, states, nil)
+	assert.Error(t, err)
+	_, err = client.GetMultipleAccountsDataInto(context.Background(), keys, &states, &GetMultipleAccountsChunkedOpts{
+		GetMultipleAccountsOpts: GetMultipleAccountsOpts{Encoding: solana.EncodingJSONParsed},
+	})
+	assert.Error(t, err)
+}
+
+func TestGetMultipleAccountsChunked_Error(t *testing.T) {
+	var keys []solana.PublicKey
+	for i := 0; i < 250; i++ {
+		keys = append(keys, solana.NewWallet().PublicKey())
+	}
+	srv := newAccountsServer(t, nil, keys[120].String())
+	defer srv.Close()
+
+	_, err := New(srv.URL).GetMultipleAccountsChunked(context.Background(), keys, &GetMultipleAccountsChunkedOpts{
+		GetMultipleAccountsOpts: GetMultipleAccountsOpts{Encoding: solana.EncodingBase64},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get accounts 100 to 199")
+	assert.Contains(t, err.Error(), "Node is behind")
+}
@@ -0,0 +1,487 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+const (
+	// DefaultSignaturesPageSize is the number of signatures fetched at once by
+	// a SignatureIterator, the maximum accepted by `getSignaturesForAddress`.
+	DefaultSignaturesPageSize = 1000
+
+	// DefaultBlocksPageSize is the number of blocks fetched at once by a
+	// BlockIterator.
+	DefaultBlocksPageSize = 1000
+)
+
+// prefetch is a page being fetched in the background.
+type prefetch struct {
+	done chan struct{}
+	page interface{}
+	err  error
+}
+
+func startPrefetch(ctx context.Context, fetch func(ctx context.Context) (interface{}, error)) *prefetch {
+	p := &prefetch{done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		p.page, p.err = fetch(ctx)
+	}()
+	return p
+}
+
+func (p *prefetch) wait(ctx context.Context) (interface{}, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.done:
+		return p.page, p.err
+	}
+}
+
+// SignatureCursor is the position of a SignatureIterator in the history of
+// an address. It can be saved to resume the iteration later.
+type SignatureCursor struct {
+	// Before is the newest end of the range, exclusive, the most recent
+	// signature if zero.
+	Before solana.Signature `json:"before,omitempty"`
+
+	// Until is the oldest end of the range, exclusive, the first signature of
+	// the address if zero.
+	Until solana.Signature `json:"until,omitempty"`
+
+	// Forward walks the range from the oldest signature to the newest one,
+	// instead of from the newest to the oldest.
+	Forward bool `json:"forward,omitempty"`
+}
+
+// SignatureIteratorOpts are the options of `Client.NewSignatureIterator`, a
+// nil `*SignatureIteratorOpts` is valid.
+type SignatureIteratorOpts struct {
+	// PageSize is the number of signatures fetched at once, between 1 and
+	// 1,000, `DefaultSignaturesPageSize` if zero.
+	PageSize int
+
+	// MinSlot and MaxSlot limit the iteration to the signatures of the slots
+	// in that range, inclusive.
+	MinSlot *uint64
+	MaxSlot *uint64
+
+	// Commitment; "processed" is not supported.
+	Commitment CommitmentType
+}
+
+// SignatureIterator walks the history of an address, fetching the signatures
+// by pages. The next page is fetched while the current one is consumed.
+//
+// The node only returns signatures newest first, so walking forward first
+// walks the whole range backward, keeping only the newest and oldest
+// signatures of each page, then fetches the pages again, oldest first. It
+// costs twice the requests of walking backward, and the first signature is
+// only returned once the range has been walked, but the memory stays bounded
+// by a page plus two signatures per page of the range.
+type SignatureIterator struct {
+	client  *Client
+	ctx     context.Context
+	account solana.PublicKey
+	cursor  SignatureCursor
+	opts    SignatureIteratorOpts
+
+	buf       []*TransactionSignature
+	next      *prefetch
+	exhausted bool
+
+	// windows are the pages of a forward iteration, newest first.
+	windows []signatureWindow
+	indexed bool
+
+	value *TransactionSignature
+	err   error
+}
+
+// NewSignatureIterator returns an iterator over the signatures of the
+// transactions involving `account`, from `cursor`. The pages are fetched
+// with `ctx`, cancelling it stops the iteration.
+//
+//	it := client.NewSignatureIterator(ctx, account, rpc.SignatureCursor{}, nil)
+//	for it.Next() {
+//		fmt.Println(it.Signature().Signature)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Resume later from it.Cursor().
+//	}
+func (cl *Client) NewSignatureIterator(
+	ctx context.Context,
+	account solana.PublicKey,
+	cursor SignatureCursor,
+	opts *SignatureIteratorOpts,
+) *SignatureIterator {
+	it := &SignatureIterator{
+		client:  cl,
+		ctx:     ctx,
+		account: account,
+		cursor:  cursor,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize <= 0 {
+		it.opts.PageSize = DefaultSignaturesPageSize
+	}
+	it.next = it.prefetch(cursor.Before)
+	return it
+}
+
+func (it *SignatureIterator) prefetch(before solana.Signature) *prefetch {
+	limit, until := it.opts.PageSize, it.cursor.Until
+	return startPrefetch(it.ctx, func(ctx context.Context) (interface{}, error) {
+		return it.client.GetSignaturesForAddressWithOpts(ctx, it.account, &GetSignaturesForAddressOpts{
+			Limit:      &limit,
+			Before:     before,
+			Until:      until,
+			Commitment: it.opts.Commitment,
+		})
+	})
+}
+
+// nextPage returns the next page of signatures, newest first, and starts
+// fetching the following one.
+func (it *SignatureIterator) nextPage() ([]*TransactionSignature, error) {
+	if it.next == nil {
+		return nil, nil
+	}
+	res, err := it.next.wait(it.ctx)
+	it.next = nil
+	if err != nil {
+		return nil, err
+	}
+
+	page := res.([]*TransactionSignature)
+	if len(page) == 0 {
+		return nil, nil
+	}
+	last := page[len(page)-1]
+	if len(page) >= it.opts.PageSize && !it.belowMinSlot(last) {
+		it.next = it.prefetch(last.Signature)
+	}
+	return page, nil
+}
+
+func (it *SignatureIterator) belowMinSlot(sig *TransactionSignature) bool {
+	return it.opts.MinSlot != nil && sig.Slot < *it.opts.MinSlot
+}
+
+func (it *SignatureIterator) aboveMaxSlot(sig *TransactionSignature) bool {
+	return it.opts.MaxSlot != nil && sig.Slot > *it.opts.MaxSlot
+}
+
+// signatureWindow is a page of a forward iteration, known by its newest and
+// oldest signatures; the ones in between are fetched when it is walked.
+type signatureWindow struct {
+	newest, oldest *TransactionSignature
+	size           int
+}
+
+// index walks the range backward and keeps the windows in the slot range.
+func (it *SignatureIterator) index() error {
+	for {
+		page, err := it.nextPage()
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			break
+		}
+		w := signatureWindow{newest: page[0], oldest: page[len(page)-1], size: len(page)}
+		if !it.belowMinSlot(w.newest) && !it.aboveMaxSlot(w.oldest) {
+			it.windows = append(it.windows, w)
+		}
+	}
+	if len(it.windows) > 0 {
+		it.next = it.prefetchWindow(it.windows[len(it.windows)-1])
+	}
+	return nil
+}
+
+// prefetchWindow fetches the signatures strictly between the newest and
+// oldest ones of `w`, or returns nil if there are none.
+func (it *SignatureIterator) prefetchWindow(w signatureWindow) *prefetch {
+	if w.size <= 2 {
+		return nil
+	}
+	limit := w.size - 2
+	return startPrefetch(it.ctx, func(ctx context.Context) (interface{}, error) {
+		return it.client.GetSignaturesForAddressWithOpts(ctx, it.account, &GetSignaturesForAddressOpts{
+			Limit:      &limit,
+			Before:     w.newest.Signature,
+			Until:      w.oldest.Signature,
+			Commitment: it.opts.Commitment,
+		})
+	})
+}
+
+// nextWindow returns the signatures of the oldest window left, oldest first,
+// and starts fetching the following one.
+func (it *SignatureIterator) nextWindow() ([]*TransactionSignature, error) {
+	if len(it.windows) == 0 {
+		return nil, nil
+	}
+	w := it.windows[len(it.windows)-1]
+	it.windows = it.windows[:len(it.windows)-1]
+	inner := it.next
+	it.next = nil
+	if len(it.windows) > 0 {
+		it.next = it.prefetchWindow(it.windows[len(it.windows)-1])
+	}
+
+	page := []*TransactionSignature{w.oldest}
+	if inner != nil {
+		res, err := inner.wait(it.ctx)
+		if err != nil {
+			return nil, err
+		}
+		between := res.([]*TransactionSignature)
+		for i := len(between) - 1; i >= 0; i-- {
+			page = append(page, between[i])
+		}
+	}
+	if w.size > 1 {
+		page = append(page, w.newest)
+	}
+	return page, nil
+}
+
+// Next advances to the next signature, it returns false when the range is
+// exhausted or on error, see `Err`.
+func (it *SignatureIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	if it.cursor.Forward && !it.indexed {
+		if err := it.index(); err != nil {
+			it.err = err
+			return false
+		}
+		it.indexed = true
+	}
+
+	for {
+		if len(it.buf) == 0 {
+			if it.exhausted {
+				return false
+			}
+			var page []*TransactionSignature
+			var err error
+			if it.cursor.Forward {
+				page, err = it.nextWindow()
+			} else {
+				page, err = it.nextPage()
+			}
+			if err != nil {
+				it.err = err
+				return false
+			}
+			if page == nil {
+				it.exhausted = true
+				return false
+			}
+			it.buf = page
+		}
+
+		sig := it.buf[0]
+		it.buf = it.buf[1:]
+		if it.cursor.Forward {
+			if it.belowMinSlot(sig) {
+				continue
+			}
+			if it.aboveMaxSlot(sig) {
+				it.buf, it.windows, it.next, it.exhausted = nil, nil, nil, true
+				return false
+			}
+		} else {
+			if it.belowMinSlot(sig) {
+				it.buf, it.next, it.exhausted = nil, nil, true
+				return false
+			}
+			if it.aboveMaxSlot(sig) {
+				it.cursor.Before = sig.Signature
+				continue
+			}
+		}
+
+		it.value = sig
+		if it.cursor.Forward {
+			it.cursor.Until = sig.Signature
+		} else {
+			it.cursor.Before = sig.Signature
+		}
+		return true
+	}
+}
+
+// Signature returns the current signature.
+func (it *SignatureIterator) Signature() *TransactionSignature {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration.
+func (it *SignatureIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the position of the iterator, after the current signature.
+func (it *SignatureIterator) Cursor() SignatureCursor {
+	return it.cursor
+}
+
+// BlockCursor is the position of a BlockIterator. It can be saved to resume
+// the iteration later.
+type BlockCursor struct {
+	// StartSlot is the first slot of the range, inclusive.
+	StartSlot uint64 `json:"startSlot"`
+
+	// EndSlot is the last slot of the range, inclusive, nil to walk up to the
+	// latest confirmed block.
+	EndSlot *uint64 `json:"endSlot,omitempty"`
+}
+
+// BlockIteratorOpts are the options of `Client.NewBlockIterator`, a nil
+// `*BlockIteratorOpts` is valid.
+type BlockIteratorOpts struct {
+	// PageSize is the number of blocks fetched at once, at most 500,000,
+	// `DefaultBlocksPageSize` if zero.
+	PageSize uint64
+
+	// Commitment; "processed" is not supported.
+	Commitment CommitmentType
+}
+
+// BlockIterator walks the confirmed blocks of a range of slots, in
+// ascending order, the skipped slots being left out. The next page is
+// fetched while the current one is consumed.
+type BlockIterator struct {
+	client *Client
+	ctx    context.Context
+	cursor BlockCursor
+	opts   BlockIteratorOpts
+
+	buf  []uint64
+	next *prefetch
+
+	value uint64
+	err   error
+}
+
+// NewBlockIterator returns an iterator over the confirmed blocks from
+// `cursor`. The pages are fetched with `ctx`, cancelling it stops the
+// iteration.
+func (cl *Client) NewBlockIterator(ctx context.Context, cursor BlockCursor, opts *BlockIteratorOpts) *BlockIterator {
+	it := &BlockIterator{
+		client: cl,
+		ctx:    ctx,
+		cursor: cursor,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize == 0 {
+		it.opts.PageSize = DefaultBlocksPageSize
+	}
+	if !it.pastEnd(cursor.StartSlot) {
+		it.next = it.prefetch(cursor.StartSlot)
+	}
+	return it
+}
+
+func (it *BlockIterator) pastEnd(slot uint64) bool {
+	return it.cursor.EndSlot != nil && slot > *it.cursor.EndSlot
+}
+
+func (it *BlockIterator) prefetch(startSlot uint64) *prefetch {
+	return startPrefetch(it.ctx, func(ctx context.Context) (interface{}, error) {
+		return it.client.GetBlocksWithLimit(ctx, startSlot, it.opts.PageSize, it.opts.Commitment)
+	})
+}
+
+// Next advances to the next confirmed block, it returns false when the
+// range is exhausted or on error, see `Err`.
+func (it *BlockIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	if len(it.buf) == 0 {
+		if it.next == nil {
+			return false
+		}
+		res, err := it.next.wait(it.ctx)
+		it.next = nil
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		var page []uint64
+		if blocks := res.(*BlocksResult); blocks != nil {
+			page = *blocks
+		}
+		if len(page) == 0 {
+			return false
+		}
+		last := page[len(page)-1]
+		if uint64(len(page)) >= it.opts.PageSize && !it.pastEnd(last+1) {
+			it.next = it.prefetch(last + 1)
+		}
+		it.buf = page
+	}
+
+	slot := it.buf[0]
+	it.buf = it.buf[1:]
+	if it.pastEnd(slot) {
+		it.buf, it.next = nil, nil
+		return false
+	}
+	it.value = slot
+	it.cursor.StartSlot = slot + 1
+	return true
+}
+
+// Slot returns the slot of the current block.
+func (it *BlockIterator) Slot() uint64 {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the position of the iterator, after the current block.
+func (it *BlockIterator) Cursor() BlockCursor {
+	return it.cursor
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// historyServer serves the history of an address, newest first, and a
+// chain of blocks with skipped slots.
+type historyServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newHistoryServer(t *testing.T, history []*TransactionSignature, blocks []uint64) *historyServer {
+	srv := &historyServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+			ID     int           `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		srv.mu.Lock()
+		srv.requests++
+		srv.mu.Unlock()
+
+		var result interface{}
+		switch request.Method {
+		case "getSignaturesForAddress":
+			opts := request.Params[1].(map[string]interface{})
+			limit := int(opts["limit"].(float64))
+			before, _ := opts["before"].(string)
+			until, _ := opts["until"].(string)
+
+			out := []*TransactionSignature{}
+			started := before == ""
+			for _, sig := range history {
+				if !started {
+					started = sig.Signature.String() == before
+					continue
+				}
+				if sig.Signature.String() == until || len(out) == limit {
+					break
+				}
+				out = append(out, sig)
+			}
+			result = out
+		case "getBlocksWithLimit":
+			start, limit := uint64(request.Params[0].(float64)), int(request.Params[1].(float64))
+			out := []uint64{}
+			for _, slot := range blocks {
+				if slot >= start && len(out) < limit {
+					out = append(out, slot)
+				}
+			}
+			result = out
+		}
+		require.NoError(t, json.NewEncoder(rw).Encode(M{"jsonrpc": "2.0", "id": request.ID, "result": result}))
+	}))
+	return srv
+}
+
+func testHistory() (history []*TransactionSignature) {
+	for i := 0; i < 25; i++ {
+		var sig solana.Signature
+		sig[0] = byte(i + 1)
+		history = append(history, &TransactionSignature{Signature: sig, Slot: uint64(100 - i)})
+	}
+	return
+}
+
+func collectSignatures(it *SignatureIterator, max int) (out []*TransactionSignature) {
+	for len(out) < max && it.Next() {
+		out = append(out, it.Signature())
+	}
+	return
+}
+
+func TestSignatureIterator(t *testing.T) {
+	history := testHistory()
+	srv := newHistoryServer(t, history, nil)
+	defer srv.Close()
+	client := New(srv.URL)
+	ctx := context.Background()
+	account := solana.SystemProgramID
+	opts := &SignatureIteratorOpts{PageSize: 10}
+
+	t.Run("backward", func(t *testing.T) {
+		it := client.NewSignatureIterator(ctx, account, SignatureCursor{}, opts)
+		got := collectSignatures(it, 12)
+		require.NoError(t, it.Err())
+		assert.Equal(t, history[:12], got)
+		assert.Equal(t, SignatureCursor{Before: history[11].Signature}, it.Cursor())
+
+		// Resume from the cursor.
+		it = client.NewSignatureIterator(ctx, account, it.Cursor(), opts)
+		got = collectSignatures(it, 100)
+		require.NoError(t, it.Err())
+		assert.Equal(t, history[12:], got)
+		assert.False(t, it.Next())
+	})
+
+	t.Run("forward", func(t *testing.T) {
+		cursor := SignatureCursor{Before: history[3].Signature, Until: history[20].Signature, Forward: true}
+		it := client.NewSignatureIterator(ctx, account, cursor, opts)
+		got := collectSignatures(it, 5)
+		require.NoError(t, it.Err())
+		assert.Equal(t, []*TransactionSignature{history[19], history[18], history[17], history[16], history[15]}, got)
+		assert.Equal(t, history[15].Signature, it.Cursor().Until)
+
+		it = client.NewSignatureIterator(ctx, account, it.Cursor(), opts)
+		got = collectSignatures(it, 100)
+		require.NoError(t, it.Err())
+		assert.Equal(t, []*TransactionSignature{
+			history[14], history[13], history[12], history[11], history[10],
+			history[9], history[8], history[7], history[6], history[5], history[4],
+		}, got)
+	})
+
+	t.Run("forward bounded", func(t *testing.T) {
+		pageOpts := &SignatureIteratorOpts{PageSize: 4}
+		srv.requests = 0
+		it := client.NewSignatureIterator(ctx, account, SignatureCursor{Forward: true}, pageOpts)
+		var got []*TransactionSignature
+		for it.Next() {
+			// At most a page and the bounds of the pages are held.
+			assert.LessOrEqual(t, len(it.buf), pageOpts.PageSize)
+			assert.LessOrEqual(t, len(it.windows), 7)
+			got = append(got, it.Signature())
+		}
+		require.NoError(t, it.Err())
+		require.Len(t, got, len(history))
+		for i, sig := range got {
+			assert.Equal(t, history[len(history)-1-i], sig)
+		}
+		// 7 pages backward, then the inner signatures of 6 full pages.
+		assert.Equal(t, 13, srv.requests)
+	})
+
+	t.Run("slot range", func(t *testing.T) {
+		minSlot, maxSlot := uint64(80), uint64(95)
+		slotOpts := &SignatureIteratorOpts{PageSize: 4, MinSlot: &minSlot, MaxSlot: &maxSlot}
+
+		srv.requests = 0
+		it := client.NewSignatureIterator(ctx, account, SignatureCursor{}, slotOpts)
+		got := collectSignatures(it, 100)
+		require.NoError(t, it.Err())
+		assert.Equal(t, history[5:21], got)
+		// The pages past the minimum slot are not fetched.
+		assert.Equal(t, 6, srv.requests)
+
+		it = client.NewSignatureIterator(ctx, account, SignatureCursor{Forward: true}, slotOpts)
+		got = collectSignatures(it, 100)
+		require.NoError(t, it.Err())
+		require.Len(t, got, 16)
+		assert.Equal(t, history[20], got[0])
+		assert.Equal(t, history[5], got[15])
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		it := client.NewSignatureIterator(ctx, account, SignatureCursor{}, opts)
+		require.True(t, it.Next())
+		cancel()
+		assert.False(t, it.Next())
+		assert.Equal(t, context.Canceled, it.Err())
+		assert.Equal(t, history[0].Signature, it.Cursor().Before)
+	})
+}
+
+func TestBlockIterator(t *testing.T) {
+	var blocks []uint64
+	for slot := uint64(0); slot < 60; slot++ {
+		if slot%7 != 0 {
+			blocks = append(blocks, slot)
+		}
+	}
+	srv := newHistoryServer(t, nil, blocks)
+	defer srv.Close()
+	client := New(srv.URL)
+	ctx := context.Background()
+	opts := &BlockIteratorOpts{PageSize: 10}
+
+	collect := func(it *BlockIterator, max int) (out []uint64) {
+		for len(out) < max && it.Next() {
+			out = append(out, it.Slot())
+		}
+		return
+	}
+
+	endSlot := uint64(40)
+	it := client.NewBlockIterator(ctx, BlockCursor{StartSlot: 5, EndSlot: &endSlot}, opts)
+	got := collect(it, 10)
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint64{5, 6, 8, 9, 10, 11, 12, 13, 15, 16}, got)
+	assert.Equal(t, BlockCursor{StartSlot: 17, EndSlot: &endSlot}, it.Cursor())
+
+	it = client.NewBlockIterator(ctx, it.Cursor(), opts)
+	got = collect(it, 100)
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint64{17, 18, 19, 20, 22, 23, 24, 25, 26, 27, 29, 30, 31, 32, 33, 34, 36, 37, 38, 39, 40}, got)
+
+	// Up to the latest block.
+	it = client.NewBlockIterator(ctx, BlockCursor{StartSlot: 50}, opts)
+	got = collect(it, 100)
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint64{50, 51, 52, 53, 54, 55, 57, 58, 59}, got)
+	assert.Equal(t, uint64(60), it.Cursor().StartSlot)
+
+	it = client.NewBlockIterator(ctx, BlockCursor{StartSlot: 50, EndSlot: &endSlot}, opts)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
@@ -132,6 +132,9 @@ func WaitForConfirmation(
 			}
 			if resp.Value.Err != nil {
 				// The transaction was confirmed, but it failed while executing (one of the instructions failed).
+				if txErr, err := resp.TransactionError(); err == nil {
+					return true, fmt.Errorf("confirmed transaction with execution error: %w", txErr)
+				}
 				return true, fmt.Errorf("confirmed transaction with execution error: %v", resp.Value.Err)
 			} else {
 				// Success! Confirmed! And there was no error while executing the transaction.
@@ -36,6 +36,19 @@ type LogResult struct {
 	} `json:"value"`
 }
 
+// TransactionError returns the error of the transaction, nil if it succeeded.
+// The program that raised the error is resolved from the logs.
+func (r *LogResult) TransactionError() (*rpc.TransactionError, error) {
+	txErr, err := rpc.ParseTransactionError(r.Value.Err)
+	if err != nil || txErr == nil {
+		return nil, err
+	}
+	if err := txErr.ResolveProgram(nil, r.Value.Logs); err != nil {
+		return nil, err
+	}
+	return txErr, nil
+}
+
 type LogsSubscribeFilterType string
 
 const (
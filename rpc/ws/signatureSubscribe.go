@@ -31,6 +31,11 @@ type SignatureResult struct {
 	} `json:"value"`
 }
 
+// TransactionError returns the error of the transaction, nil if it succeeded.
+func (r *SignatureResult) TransactionError() (*rpc.TransactionError, error) {
+	return rpc.ParseTransactionError(r.Value.Err)
+}
+
 // SignatureSubscribe subscribes to a transaction signature to receive
 // notification when the transaction is confirmed On signatureNotification,
 // the subscription is automatically cancelled
@@ -81,6 +81,10 @@ func (e *Encoder) encode(rv reflect.Value, option *Option) (err error) {
 		return enc.TextEncode(e, option)
 	}
 
+	if s, ok := scalarString(rv.Interface()); ok {
+		return e.ToWriter(s, option.indent, option.fgColor)
+	}
+
 	//if sr, ok := rv.Interface().(fmt.Stringer); ok {
 	//	return e.ToWriter(sr.String(), option.indent, option.fgColor)
 	//}
@@ -236,7 +240,7 @@ func (e *Encoder) encodeStruct(rt reflect.Type, rv reflect.Value, option *Option
 			continue
 		}
 
-		if err := e.ToWriter(structField.Name+": ", !option.linear, EncoderColorGreen); err != nil {
+		if err := e.ToWriter(fieldTag.name(structField)+": ", !option.linear, EncoderColorGreen); err != nil {
 			return err
 		}
 
@@ -0,0 +1,305 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/ryanuber/columnize"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of Render.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatTable Format = "table"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(s)); format {
+	case FormatText, FormatJSON, FormatYAML, FormatTable:
+		return format, nil
+	case "":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, expected one of text, json, yaml or table", s)
+}
+
+// Render writes `v` to `w` in the given format. The JSON, YAML and table
+// formats are built from ToValue, so they hold the same fields as the
+// text format.
+func Render(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case FormatText, "":
+		if err := NewEncoder(w).Encode(v, nil); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w)
+		return err
+	case FormatJSON:
+		cnt, err := json.MarshalIndent(ToValue(v), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(cnt))
+		return err
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(ToValue(v)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatTable:
+		_, err := fmt.Fprintln(w, columnize.Format(tableLines(ToValue(v)), nil))
+		return err
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// Field is a named value of Fields.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// Fields are the fields of a struct, or the entries of a map, in order.
+// They marshal to JSON objects and YAML mappings.
+type Fields []Field
+
+func (f Fields) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	for i, field := range f {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (f Fields) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range f {
+		value := &yaml.Node{}
+		if err := value.Encode(field.Value); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field.Name}, value)
+	}
+	return node, nil
+}
+
+// ToValue converts `v` into nil, a bool, a number, a string, a []interface{}
+// or Fields, following the same rules as the text Encoder: fields tagged
+// `text:"-"` and unexported fields are skipped, a `text` tag label renames
+// a field, and public keys, signatures, hashes and 128 bits integers are
+// strings (see scalarString).
+func ToValue(v interface{}) interface{} {
+	return toValue(reflect.ValueOf(v))
+}
+
+func toValue(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+	}
+	if rv.CanInterface() {
+		if s, ok := scalarString(rv.Interface()); ok {
+			return s
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return toValue(rv.Elem())
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = toValue(rv.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		out := make(Fields, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			out = append(out, Field{Name: fmt.Sprint(toValue(key)), Value: toValue(rv.MapIndex(key))})
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+		return out
+	case reflect.Struct:
+		rt := rv.Type()
+		out := Fields{}
+		for i := 0; i < rv.NumField(); i++ {
+			structField := rt.Field(i)
+			fieldTag := parseFieldTag(structField.Tag)
+			if fieldTag.Skip || !rv.Field(i).CanInterface() {
+				continue
+			}
+			out = append(out, Field{Name: fieldTag.name(structField), Value: toValue(rv.Field(i))})
+		}
+		return out
+	}
+	return fmt.Sprintf("%v", rv)
+}
+
+// scalarString returns the string representation of the values rendered
+// as a single string by both the text Encoder and ToValue.
+func scalarString(v interface{}) (string, bool) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	switch x := v.(type) {
+	case bin.Uint128:
+		return x.DecimalString(), true
+	case bin.Int128:
+		return x.DecimalString(), true
+	case bin.Float128:
+		return bin.Uint128(x).DecimalString(), true
+	case Uint128:
+		return x.DecimalString(), true
+	case Int128:
+		return x.DecimalString(), true
+	case Float128:
+		return Uint128(x).DecimalString(), true
+	case HexBytes:
+		return hex.EncodeToString(x), true
+	case encoding.TextMarshaler:
+		// Public keys, signatures and hashes are base58.
+		cnt, err := x.MarshalText()
+		if err != nil {
+			return "", false
+		}
+		return string(cnt), true
+	}
+	return "", false
+}
+
+// tableLines lays a value out for columnize: a list of structs or maps
+// is one row per element, a struct or map one row per field, and nested
+// values are written as compact JSON.
+func tableLines(v interface{}) []string {
+	switch x := v.(type) {
+	case []interface{}:
+		var header []string
+		seen := map[string]bool{}
+		for _, elem := range x {
+			if fields, ok := elem.(Fields); ok {
+				for _, field := range fields {
+					if !seen[field.Name] {
+						seen[field.Name] = true
+						header = append(header, field.Name)
+					}
+				}
+			}
+		}
+		if len(header) == 0 {
+			var out []string
+			for _, elem := range x {
+				out = append(out, tableCell(elem))
+			}
+			return out
+		}
+
+		out := []string{strings.Join(header, " | ")}
+		for _, elem := range x {
+			values := map[string]interface{}{}
+			if fields, ok := elem.(Fields); ok {
+				for _, field := range fields {
+					values[field.Name] = field.Value
+				}
+			}
+			row := make([]string, len(header))
+			for i, name := range header {
+				row[i] = tableCell(values[name])
+			}
+			out = append(out, strings.Join(row, " | "))
+		}
+		return out
+	case Fields:
+		out := []string{"Field | Value"}
+		for _, field := range x {
+			out = append(out, field.Name+" | "+tableCell(field.Value))
+		}
+		return out
+	}
+	return []string{tableCell(v)}
+}
+
+func tableCell(v interface{}) string {
+	var s string
+	switch x := v.(type) {
+	case nil:
+		s = "-"
+	case string:
+		s = x
+	case Fields, []interface{}:
+		cnt, err := json.Marshal(x)
+		if err != nil {
+			s = err.Error()
+		} else {
+			s = string(cnt)
+		}
+	default:
+		s = fmt.Sprintf("%v", x)
+	}
+	// The column separator of columnize.
+	return strings.ReplaceAll(s, "|", "¦")
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type base58Key [4]byte
+
+func (k base58Key) MarshalText() ([]byte, error) {
+	return []byte("2VfUX"), nil
+}
+
+type structuredTestStruct struct {
+	Key      base58Key
+	Owner    *base58Key
+	Amount   bin.Uint128
+	Renamed  uint8 `text:"decimals"`
+	Hidden   string `text:"-"`
+	hidden   string
+	Children []*nested
+	Tags     map[string]int
+}
+
+func newStructuredTestStruct() *structuredTestStruct {
+	return &structuredTestStruct{
+		Amount:   bin.Uint128{Lo: 10, Hi: 1},
+		Renamed:  6,
+		Hidden:   "hidden",
+		hidden:   "hidden",
+		Children: []*nested{{F1: "a", F2: "b"}},
+		Tags:     map[string]int{"z": 1, "a": 2},
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Render(buf, FormatJSON, newStructuredTestStruct()))
+	assert.JSONEq(t, `{
+		"Key": "2VfUX",
+		"Owner": null,
+		"Amount": "18446744073709551626",
+		"decimals": 6,
+		"Children": [{"F1": "a", "F2": "b"}],
+		"Tags": {"a": 2, "z": 1}
+	}`, buf.String())
+	// Fields keep their declaration order.
+	assert.Regexp(t, `(?s)"Key".*"Owner".*"Amount".*"decimals".*"Children".*"Tags"`, buf.String())
+}
+
+func TestRender_YAML(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Render(buf, FormatYAML, newStructuredTestStruct()))
+	assert.Equal(t, `Key: 2VfUX
+Owner: null
+Amount: "18446744073709551626"
+decimals: 6
+Children:
+  - F1: a
+    F2: b
+Tags:
+  a: 2
+  z: 1
+`, buf.String())
+}
+
+func TestRender_Table(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Render(buf, FormatTable, []*nested{{F1: "a", F2: "b"}, {F1: "c", F2: "d|e"}}))
+	assert.Equal(t, "F1  F2\na   b\nc   d¦e\n", buf.String())
+}
+
+func TestRender_TextMatchesStructuredFields(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Render(buf, FormatText, newStructuredTestStruct()))
+	out := buf.String()
+	assert.Contains(t, out, "Key: 2VfUX")
+	assert.Contains(t, out, "Amount: 18446744073709551626")
+	assert.Contains(t, out, "decimals: 6")
+	assert.NotContains(t, out, "Hidden")
+	assert.NotContains(t, out, "Renamed")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("JSON")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatText, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
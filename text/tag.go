@@ -45,3 +45,11 @@ func parseFieldTag(tag reflect.StructTag) *fieldTag {
 	}
 	return t
 }
+
+// name returns the name of `field` in encoded output, its label if set.
+func (t *fieldTag) name(field reflect.StructField) string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return field.Name
+}
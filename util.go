@@ -16,6 +16,7 @@ package solana
 
 import (
 	"math/big"
+	"strings"
 )
 
 var _10b = big.NewInt(10)
@@ -60,3 +61,20 @@ func DecimalsInBigInt(decimal uint32) *big.Int {
 //	gcd := new(big.Int).GCD(nil, nil, remainder, denomiator)
 //
 //}
+
+// FormatUiAmount formats a raw token amount with `decimals` decimals, the
+// way the RPC formats `uiAmountString`: 1500000000 with 9 decimals is "1.5".
+func FormatUiAmount(amount *big.Int, decimals uint8) string {
+	abs := new(big.Int).Abs(amount).String()
+	if len(abs) <= int(decimals) {
+		abs = strings.Repeat("0", int(decimals)-len(abs)+1) + abs
+	}
+	out := abs[:len(abs)-int(decimals)]
+	if fraction := strings.TrimRight(abs[len(abs)-int(decimals):], "0"); fraction != "" {
+		out += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		out = "-" + out
+	}
+	return out
+}
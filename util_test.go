@@ -0,0 +1,43 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUiAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		decimals uint8
+		expected string
+	}{
+		{0, 9, "0"},
+		{1500000000, 9, "1.5"},
+		{5000, 9, "0.000005"},
+		{10000000, 6, "10"},
+		{-50000000, 9, "-0.05"},
+		{42, 0, "42"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatUiAmount(big.NewInt(test.amount), test.decimals))
+		})
+	}
+}
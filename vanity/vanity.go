@@ -0,0 +1,313 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vanity searches for keypairs and `CreateWithSeed` addresses
+// whose base58 representation matches a chosen prefix and/or suffix.
+package vanity
+
+import (
+	"context"
+	"crypto/ed25519"
+	crypto_rand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Matcher selects the addresses to keep.
+type Matcher struct {
+	Prefix     string
+	Suffix     string
+	IgnoreCase bool
+}
+
+// Validate returns an error if no base58 address can ever match.
+func (m Matcher) Validate() error {
+	if m.Prefix == "" && m.Suffix == "" {
+		return fmt.Errorf("a prefix or a suffix is required")
+	}
+	if len(m.Prefix)+len(m.Suffix) > 44 {
+		return fmt.Errorf("prefix and suffix are longer than an address")
+	}
+	for _, c := range m.Prefix + m.Suffix {
+		if m.IgnoreCase {
+			if !strings.ContainsRune(base58Alphabet, unicode.ToLower(c)) && !strings.ContainsRune(base58Alphabet, unicode.ToUpper(c)) {
+				return fmt.Errorf("invalid character %q: not in the base58 alphabet", c)
+			}
+		} else if !strings.ContainsRune(base58Alphabet, c) {
+			return fmt.Errorf("invalid character %q: not in the base58 alphabet", c)
+		}
+	}
+	return nil
+}
+
+// Match returns true if `address` has the prefix and the suffix of the matcher.
+func (m Matcher) Match(address string) bool {
+	if m.IgnoreCase {
+		return len(address) >= len(m.Prefix)+len(m.Suffix) &&
+			strings.EqualFold(address[:len(m.Prefix)], m.Prefix) &&
+			strings.EqualFold(address[len(address)-len(m.Suffix):], m.Suffix)
+	}
+	return strings.HasPrefix(address, m.Prefix) && strings.HasSuffix(address, m.Suffix)
+}
+
+// ExpectedAttempts is a rough estimate of the number of addresses to
+// generate for one match.
+func (m Matcher) ExpectedAttempts() float64 {
+	out := 1.0
+	for _, c := range m.Prefix + m.Suffix {
+		choices := 1.0
+		if m.IgnoreCase && unicode.ToLower(c) != unicode.ToUpper(c) &&
+			strings.ContainsRune(base58Alphabet, unicode.ToLower(c)) && strings.ContainsRune(base58Alphabet, unicode.ToUpper(c)) {
+			choices = 2
+		}
+		out *= float64(len(base58Alphabet)) / choices
+	}
+	return out
+}
+
+// Progress is reported periodically while grinding.
+type Progress struct {
+	Attempts uint64
+	Found    int
+	Elapsed  time.Duration
+}
+
+// Rate returns the number of addresses generated per second.
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Attempts) / p.Elapsed.Seconds()
+}
+
+// Options configure a search.
+type Options struct {
+	Matcher
+
+	// Count is the number of matches to find, defaults to 1.
+	Count int
+	// Workers is the number of goroutines searching, defaults to the number of CPUs.
+	Workers int
+	// OnProgress, if set, is called every ProgressInterval (defaults to 1 second),
+	// and once more when the search ends.
+	OnProgress       func(Progress)
+	ProgressInterval time.Duration
+}
+
+// SeedResult is an address found by GrindSeeds, as created by
+// `solana.CreateWithSeed(base, Seed, owner)`.
+type SeedResult struct {
+	Seed    string
+	Address solana.PublicKey
+}
+
+// GrindKeypairs searches random keypairs until `opts.Count` match. If `ctx`
+// is canceled first, the keypairs found so far are returned with the
+// context error.
+func GrindKeypairs(ctx context.Context, opts Options) ([]solana.PrivateKey, error) {
+	var out []solana.PrivateKey
+	err := grind(ctx, opts, func(worker int) func() (bool, interface{}, error) {
+		seed := make([]byte, ed25519.SeedSize)
+		return func() (bool, interface{}, error) {
+			if _, err := crypto_rand.Read(seed); err != nil {
+				return false, nil, err
+			}
+			privateKey := ed25519.NewKeyFromSeed(seed)
+			if !opts.Match(base58.Encode(privateKey[ed25519.SeedSize:])) {
+				return false, nil, nil
+			}
+			return true, solana.PrivateKey(privateKey), nil
+		}
+	}, func(v interface{}) {
+		out = append(out, v.(solana.PrivateKey))
+	})
+	return out, err
+}
+
+// GrindSeeds searches random seeds until `opts.Count` addresses created
+// with `solana.CreateWithSeed(base, seed, owner)` match. If `ctx` is canceled
+// first, the addresses found so far are returned with the context error.
+func GrindSeeds(ctx context.Context, base, owner solana.PublicKey, opts Options) ([]SeedResult, error) {
+	var out []SeedResult
+	err := grind(ctx, opts, func(worker int) func() (bool, interface{}, error) {
+		var rngSeed [8]byte
+		if _, err := crypto_rand.Read(rngSeed[:]); err != nil {
+			return func() (bool, interface{}, error) { return false, nil, err }
+		}
+		// Seeds don't need to be secret, a fast RNG will do.
+		rng := rand.New(rand.NewSource(int64(binary.LittleEndian.Uint64(rngSeed[:]))))
+		seed := make([]byte, solana.MaxSeedLength)
+		return func() (bool, interface{}, error) {
+			for i := range seed {
+				seed[i] = base58Alphabet[rng.Intn(len(base58Alphabet))]
+			}
+			address, err := solana.CreateWithSeed(base, string(seed), owner)
+			if err != nil {
+				return false, nil, err
+			}
+			if !opts.Match(address.String()) {
+				return false, nil, nil
+			}
+			return true, SeedResult{Seed: string(seed), Address: address}, nil
+		}
+	}, func(v interface{}) {
+		out = append(out, v.(SeedResult))
+	})
+	return out, err
+}
+
+// grind runs `opts.Workers` goroutines, each calling the attempt returned
+// by `newAttempt` in a loop, and passes each match to `onMatch` until
+// `opts.Count` were found.
+func grind(
+	parent context.Context,
+	opts Options,
+	newAttempt func(worker int) func() (bool, interface{}, error),
+	onMatch func(interface{}),
+) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	count := opts.Count
+	if count <= 0 {
+		count = 1
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var attempts uint64
+	var lock sync.Mutex
+	var found int
+	var firstErr error
+	start := time.Now()
+
+	progress := func() Progress {
+		lock.Lock()
+		defer lock.Unlock()
+		return Progress{
+			Attempts: atomic.LoadUint64(&attempts),
+			Found:    found,
+			Elapsed:  time.Since(start),
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			attempt := newAttempt(worker)
+			for {
+				// Checking the context on every attempt is too costly.
+				for i := 0; i < 64; i++ {
+					ok, match, err := attempt()
+					if err != nil {
+						lock.Lock()
+						if firstErr == nil {
+							firstErr = err
+						}
+						lock.Unlock()
+						cancel()
+						return
+					}
+					if ok {
+						lock.Lock()
+						if found < count {
+							found++
+							onMatch(match)
+							if found == count {
+								cancel()
+							}
+						}
+						lock.Unlock()
+					}
+				}
+				atomic.AddUint64(&attempts, 64)
+
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			if opts.OnProgress != nil {
+				opts.OnProgress(progress())
+			}
+			if firstErr != nil {
+				return firstErr
+			}
+			if found < count {
+				return parent.Err()
+			}
+			return nil
+		case <-ticker.C:
+			if opts.OnProgress != nil {
+				opts.OnProgress(progress())
+			}
+		}
+	}
+}
+
+// EstimatedDuration returns how long finding one match would take at `rate`
+// addresses per second, on average.
+func (m Matcher) EstimatedDuration(rate float64) time.Duration {
+	if rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	seconds := m.ExpectedAttempts() / rate
+	if seconds > float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vanity
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func TestMatcher(t *testing.T) {
+	assert.True(t, Matcher{Prefix: "So1"}.Match("So11111111111111111111111111111111111111112"))
+	assert.False(t, Matcher{Prefix: "so1"}.Match("So11111111111111111111111111111111111111112"))
+	assert.True(t, Matcher{Prefix: "so1", IgnoreCase: true}.Match("So11111111111111111111111111111111111111112"))
+	assert.True(t, Matcher{Prefix: "So", Suffix: "112"}.Match("So11111111111111111111111111111111111111112"))
+	assert.False(t, Matcher{Suffix: "111"}.Match("So11111111111111111111111111111111111111112"))
+
+	require.Error(t, Matcher{}.Validate())
+	require.Error(t, Matcher{Prefix: "0"}.Validate())
+	require.Error(t, Matcher{Prefix: "I"}.Validate())
+	require.NoError(t, Matcher{Prefix: "I", IgnoreCase: true}.Validate())
+
+	assert.Equal(t, 58.0*58.0, Matcher{Prefix: "a", Suffix: "1"}.ExpectedAttempts())
+	assert.Equal(t, 29.0*58.0, Matcher{Prefix: "a", Suffix: "1", IgnoreCase: true}.ExpectedAttempts())
+}
+
+func TestGrindKeypairs(t *testing.T) {
+	var progress []Progress
+	opts := Options{
+		Matcher:    Matcher{Prefix: "a", IgnoreCase: true},
+		Count:      3,
+		Workers:    2,
+		OnProgress: func(p Progress) { progress = append(progress, p) },
+	}
+	keys, err := GrindKeypairs(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	for _, key := range keys {
+		assert.True(t, strings.HasPrefix(strings.ToLower(key.PublicKey().String()), "a"))
+	}
+	require.NotEmpty(t, progress)
+	assert.Equal(t, 3, progress[len(progress)-1].Found)
+	assert.NotZero(t, progress[len(progress)-1].Attempts)
+}
+
+func TestGrindSeeds(t *testing.T) {
+	base := solana.MustPublicKeyFromBase58("9wFFyRfZBsuAha4YcuxcXLKwMxJR43S7fPfQLusDBzvT")
+	owner := solana.SystemProgramID
+
+	results, err := GrindSeeds(context.Background(), base, owner, Options{Matcher: Matcher{Suffix: "z"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	address, err := solana.CreateWithSeed(base, results[0].Seed, owner)
+	require.NoError(t, err)
+	assert.Equal(t, address, results[0].Address)
+	assert.True(t, strings.HasSuffix(address.String(), "z"))
+}
+
+func TestGrind_Canceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Practically impossible to find.
+	keys, err := GrindKeypairs(ctx, Options{Matcher: Matcher{Prefix: "zzzzzzzzzz"}})
+	require.Equal(t, context.DeadlineExceeded, err)
+	assert.Empty(t, keys)
+}
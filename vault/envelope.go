@@ -0,0 +1,243 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// KeyWrapper wraps and unwraps data encryption keys (DEKs) with a master
+// key that never leaves the key management service.
+type KeyWrapper interface {
+	WrapDEK(plainDEK []byte) (wrappedDEK string, err error)
+	UnwrapDEK(wrappedDEK string) (plainDEK []byte, err error)
+}
+
+// EnvelopeManager is a KMSManager doing envelope encryption: data is
+// encrypted locally with a random DEK, and the DEK is stored alongside
+// the data, wrapped by a KeyWrapper. Plain DEKs are cached, so the
+// KeyWrapper is called once per distinct wrapped DEK when decrypting.
+// Encrypting reuses the first DEK the manager created or unwrapped, so
+// reopening a vault and sealing it again only unwraps its DEK.
+type EnvelopeManager struct {
+	wrapper KeyWrapper
+
+	dekCache        map[string][32]byte
+	dekCacheLock    sync.Mutex
+	localDEK        [32]byte
+	localWrappedDEK string
+}
+
+func NewEnvelopeManager(wrapper KeyWrapper) *EnvelopeManager {
+	return &EnvelopeManager{
+		wrapper: wrapper,
+	}
+}
+
+func (k *EnvelopeManager) setupEncryption() error {
+	k.dekCacheLock.Lock()
+	defer k.dekCacheLock.Unlock()
+
+	if k.localWrappedDEK != "" {
+		return nil
+	}
+
+	var plainDEK [32]byte
+	_, err := io.ReadFull(rand.Reader, plainDEK[:])
+	if err != nil {
+		return err
+	}
+
+	wrappedDEK, err := k.wrapper.WrapDEK(plainDEK[:])
+	if err != nil {
+		return fmt.Errorf("wrap dek: %w", err)
+	}
+
+	k.localDEK = plainDEK
+	k.localWrappedDEK = wrappedDEK
+	if k.dekCache == nil {
+		k.dekCache = map[string][32]byte{}
+	}
+	k.dekCache[wrappedDEK] = plainDEK
+
+	return nil
+}
+
+func (k *EnvelopeManager) fetchPlainDEK(wrappedDEK string) (out [32]byte, err error) {
+	k.dekCacheLock.Lock()
+	defer k.dekCacheLock.Unlock()
+
+	if cachedKey, found := k.dekCache[wrappedDEK]; found {
+		return cachedKey, nil
+	}
+
+	plainKey, err := k.wrapper.UnwrapDEK(wrappedDEK)
+	if err != nil {
+		return out, fmt.Errorf("unwrap dek: %w", err)
+	}
+	if len(plainKey) != len(out) {
+		return out, fmt.Errorf("invalid dek length: %d", len(plainKey))
+	}
+
+	copy(out[:], plainKey)
+
+	if k.dekCache == nil {
+		k.dekCache = map[string][32]byte{}
+	}
+	k.dekCache[wrappedDEK] = out
+	if k.localWrappedDEK == "" {
+		k.localDEK = out
+		k.localWrappedDEK = wrappedDEK
+	}
+
+	return
+}
+
+type BlobV1 struct {
+	Version       int      `bson:"version"`
+	WrappedDEK    string   `bson:"wrapped_dek"`
+	Nonce         [24]byte `bson:"nonce"`
+	EncryptedData []byte   `bson:"data"`
+}
+
+func (k *EnvelopeManager) Encrypt(in []byte) ([]byte, error) {
+	if err := k.setupEncryption(); err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, err
+	}
+
+	var sealedMsg []byte
+	sealedMsg = secretbox.Seal(sealedMsg, in, &nonce, &k.localDEK)
+
+	blob := &BlobV1{
+		Version:       1,
+		WrappedDEK:    k.localWrappedDEK,
+		Nonce:         nonce,
+		EncryptedData: sealedMsg,
+	}
+
+	cereal, err := json.Marshal(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return cereal, nil
+}
+
+func (k *EnvelopeManager) Decrypt(in []byte) ([]byte, error) {
+	var blob BlobV1
+	err := json.Unmarshal(in, &blob)
+	if err != nil {
+		return nil, err
+	}
+
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("unsupported blob version: %d", blob.Version)
+	}
+
+	plainDEK, err := k.fetchPlainDEK(blob.WrappedDEK)
+	if err != nil {
+		return nil, err
+	}
+
+	plainData, ok := secretbox.Open(nil, blob.EncryptedData, &blob.Nonce, &plainDEK)
+	if !ok {
+		return nil, fmt.Errorf("failed decrypting data, that's all we know")
+	}
+
+	return plainData, nil
+}
+
+///
+/// Boxer implementation.
+///
+
+// KMSBoxer seals the vault with the KMSManager returned by `newManager`,
+// which is called once, on the first Seal or Open, and then reused.
+type KMSBoxer struct {
+	wrapType   string
+	newManager func() (KMSManager, error)
+
+	managerLock sync.Mutex
+	manager     KMSManager
+}
+
+func NewKMSBoxer(wrapType string, newManager func() (KMSManager, error)) *KMSBoxer {
+	return &KMSBoxer{
+		wrapType:   wrapType,
+		newManager: newManager,
+	}
+}
+
+func (b *KMSBoxer) getManager() (KMSManager, error) {
+	b.managerLock.Lock()
+	defer b.managerLock.Unlock()
+
+	if b.manager == nil {
+		mgr, err := b.newManager()
+		if err != nil {
+			return nil, fmt.Errorf("new %s manager, %s", b.wrapType, err)
+		}
+		b.manager = mgr
+	}
+	return b.manager, nil
+}
+
+func (b *KMSBoxer) Seal(in []byte) (string, error) {
+	mgr, err := b.getManager()
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := mgr.Encrypt(in)
+	if err != nil {
+		return "", fmt.Errorf("kms encryption, %s", err)
+	}
+
+	return base64.RawStdEncoding.EncodeToString(encrypted), nil
+}
+
+func (b *KMSBoxer) Open(in string) ([]byte, error) {
+	mgr, err := b.getManager()
+	if err != nil {
+		return []byte{}, err
+	}
+	data, err := base64.RawStdEncoding.DecodeString(in)
+	if err != nil {
+		return []byte{}, fmt.Errorf("base 64 decode, %s", err)
+	}
+	out, err := mgr.Decrypt(data)
+	if err != nil {
+		return []byte{}, fmt.Errorf("kms decryption, %s", err)
+	}
+	return out, nil
+}
+
+func (b *KMSBoxer) WrapType() string {
+	return b.wrapType
+}
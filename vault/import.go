@@ -0,0 +1,90 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+// ImportResult reports what ImportKeygenDirectory did with each file.
+type ImportResult struct {
+	// Imported are the public keys of the keypairs added to the vault.
+	Imported []solana.PublicKey
+	// Duplicates are the files holding a keypair already in the vault.
+	Duplicates []string
+	// Skipped are the files that are not valid keypairs, with the reason.
+	Skipped map[string]error
+}
+
+// ImportKeygenDirectory adds to the KeyBag every keypair found in the
+// `*.json` files of `dir`, as written by `solana-keygen`. Files that are
+// not valid keypairs are skipped and reported in the result, and keys
+// already in the vault are not added twice. Files are processed in
+// lexical order. It does NOT save the wallet.
+func (v *Vault) ImportKeygenDirectory(dir string) (*ImportResult, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list keygen files: %w", err)
+	}
+	sort.Strings(files)
+
+	known := map[solana.PublicKey]bool{}
+	for _, key := range v.KeyBag {
+		known[key.PublicKey()] = true
+	}
+
+	out := &ImportResult{Skipped: map[string]error{}}
+	for _, file := range files {
+		privateKey, err := solana.PrivateKeyFromSolanaKeygenFile(file)
+		if err != nil {
+			out.Skipped[file] = err
+			continue
+		}
+		if err := validateKeypair(privateKey); err != nil {
+			out.Skipped[file] = err
+			continue
+		}
+
+		pubKey := privateKey.PublicKey()
+		if known[pubKey] {
+			out.Duplicates = append(out.Duplicates, file)
+			continue
+		}
+		known[pubKey] = true
+		out.Imported = append(out.Imported, v.AddPrivateKey(privateKey))
+	}
+	return out, nil
+}
+
+// validateKeypair checks that the key is a 64 bytes ed25519 keypair
+// whose public half matches its seed.
+func validateKeypair(privateKey solana.PrivateKey) error {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid keypair length: expected %d bytes, got %d", ed25519.PrivateKeySize, len(privateKey))
+	}
+	derived := ed25519.NewKeyFromSeed(privateKey[:ed25519.SeedSize])
+	if !solana.PublicKeyFromBytes(derived[ed25519.SeedSize:]).Equals(privateKey.PublicKey()) {
+		return fmt.Errorf("public key does not match private key")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+)
+
+func writeKeygenFile(t *testing.T, path string, key []byte) {
+	values := make([]int, len(key))
+	for i, b := range key {
+		values[i] = int(b)
+	}
+	cnt, err := json.Marshal(values)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, cnt, 0600))
+}
+
+func TestImportKeygenDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	second, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	mismatched := append(solana.PrivateKey{}, first[:32]...)
+	mismatched = append(mismatched, second[32:]...)
+
+	writeKeygenFile(t, filepath.Join(dir, "a.json"), first)
+	writeKeygenFile(t, filepath.Join(dir, "b.json"), second)
+	writeKeygenFile(t, filepath.Join(dir, "c.json"), first[:32])
+	writeKeygenFile(t, filepath.Join(dir, "d.json"), mismatched)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "e.json"), []byte(`{"not": "a keypair"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0600))
+
+	v := NewVault()
+	v.AddPrivateKey(second)
+
+	res, err := v.ImportKeygenDirectory(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []solana.PublicKey{first.PublicKey()}, res.Imported)
+	assert.Equal(t, []string{filepath.Join(dir, "b.json")}, res.Duplicates)
+	assert.Len(t, res.Skipped, 3)
+	assert.Contains(t, res.Skipped, filepath.Join(dir, "c.json"))
+	assert.Contains(t, res.Skipped, filepath.Join(dir, "d.json"))
+	assert.Contains(t, res.Skipped, filepath.Join(dir, "e.json"))
+	assert.Len(t, v.KeyBag, 2)
+}
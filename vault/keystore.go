@@ -0,0 +1,293 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	crypto_rand "crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Scrypt parameters of Web3 keystores. The standard ones are the defaults
+// of most Ethereum wallets; the light ones trade security for speed.
+const (
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	LightScryptN    = 1 << 12
+	LightScryptP    = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+)
+
+// Limits on the key derivation parameters read from a keystore, so that a
+// crafted file cannot make DecryptKeystore exhaust memory or CPU. They are
+// well above the cost of the standard parameters.
+const (
+	maxScryptMemory     = 1 << 30 // 128 * N * r bytes
+	maxScryptWork       = 1 << 24 // N * r * p
+	maxPBKDF2Iterations = 10_000_000
+	maxKeystoreDKLen    = 64
+)
+
+// KeystoreV3 is the Web3 Secret Storage (version 3) JSON format.
+type KeystoreV3 struct {
+	Crypto  KeystoreCrypto `json:"crypto"`
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+}
+
+type KeystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams KeystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type KeystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// EncryptKeystore encrypts `data` with a key derived from `passphrase` using scrypt,
+// and returns it as a Web3 Secret Storage (version 3) JSON document.
+func EncryptKeystore(data []byte, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(crypto_rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("scrypt: %w", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(crypto_rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], data, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&KeystoreV3{
+		Crypto: KeystoreCrypto{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: KeystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derivedKey, cipherText)),
+		},
+		ID:      id,
+		Version: 3,
+	})
+}
+
+// DecryptKeystore decrypts a Web3 Secret Storage (version 3) JSON document,
+// using either the `scrypt` or the `pbkdf2` key derivation function.
+func DecryptKeystore(keystoreJSON []byte, passphrase string) ([]byte, error) {
+	var keystore KeystoreV3
+	if err := json.Unmarshal(keystoreJSON, &keystore); err != nil {
+		return nil, fmt.Errorf("unmarshal keystore: %w", err)
+	}
+	if keystore.Version != 3 {
+		return nil, fmt.Errorf("unsupported keystore version: %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported keystore cipher: %q", keystore.Crypto.Cipher)
+	}
+
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("decode mac: %w", err)
+	}
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("decode iv: %w", err)
+	}
+	// The MAC doesn't cover the IV, check it before it reaches the cipher.
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid keystore iv length: expected %d bytes, got %d", aes.BlockSize, len(iv))
+	}
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+
+	derivedKey, err := keystore.Crypto.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(keystoreMAC(derivedKey, cipherText), mac) != 1 {
+		return nil, fmt.Errorf("failed to decrypt: invalid passphrase or corrupted keystore")
+	}
+
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+func (c *KeystoreCrypto) deriveKey(passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(c.kdfString("salt"))
+	if err != nil {
+		return nil, fmt.Errorf("decode salt: %w", err)
+	}
+	dkLen := c.kdfInt("dklen")
+	if dkLen < 32 || dkLen > maxKeystoreDKLen {
+		return nil, fmt.Errorf("invalid keystore dklen: %d", dkLen)
+	}
+
+	switch c.KDF {
+	case "scrypt":
+		n, r, p := c.kdfInt("n"), c.kdfInt("r"), c.kdfInt("p")
+		if err := checkScryptParams(n, r, p); err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	case "pbkdf2":
+		if prf := c.kdfString("prf"); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf: %q", prf)
+		}
+		iterations := c.kdfInt("c")
+		if iterations <= 0 || iterations > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("invalid pbkdf2 iteration count: %d (max %d)", iterations, maxPBKDF2Iterations)
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, iterations, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported keystore kdf: %q", c.KDF)
+	}
+}
+
+func checkScryptParams(n, r, p int) error {
+	if n <= 1 || r <= 0 || p <= 0 {
+		return fmt.Errorf("invalid scrypt parameters: n=%d, r=%d, p=%d", n, r, p)
+	}
+	// Checked with divisions, as the products may overflow.
+	if r > maxScryptMemory/128 || n > maxScryptMemory/(128*r) {
+		return fmt.Errorf("scrypt parameters n=%d, r=%d need more than %d MiB", n, r, maxScryptMemory>>20)
+	}
+	if p > maxScryptWork/(n*r) {
+		return fmt.Errorf("scrypt parameters n=%d, r=%d, p=%d are too expensive", n, r, p)
+	}
+	return nil
+}
+
+func (c *KeystoreCrypto) kdfInt(name string) int {
+	// JSON numbers are decoded as float64.
+	if v, ok := c.KDFParams[name].(float64); ok {
+		return int(v)
+	}
+	if v, ok := c.KDFParams[name].(int); ok {
+		return v
+	}
+	return 0
+}
+
+func (c *KeystoreCrypto) kdfString(name string) string {
+	v, _ := c.KDFParams[name].(string)
+	return v
+}
+
+func keystoreMAC(derivedKey []byte, cipherText []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(derivedKey[16:32])
+	hash.Write(cipherText)
+	return hash.Sum(nil)
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(crypto_rand.Reader, u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+///
+/// Boxer implementation.
+///
+
+// KeystoreBoxer seals the vault as a Web3 Secret Storage (version 3)
+// JSON document, encrypted with a scrypt-derived key.
+type KeystoreBoxer struct {
+	passphrase string
+	scryptN    int
+	scryptP    int
+}
+
+func NewKeystoreBoxer(passphrase string) *KeystoreBoxer {
+	return NewKeystoreBoxerWithParams(passphrase, StandardScryptN, StandardScryptP)
+}
+
+func NewKeystoreBoxerWithParams(passphrase string, scryptN, scryptP int) *KeystoreBoxer {
+	return &KeystoreBoxer{
+		passphrase: passphrase,
+		scryptN:    scryptN,
+		scryptP:    scryptP,
+	}
+}
+
+func (b *KeystoreBoxer) WrapType() string {
+	return "keystore-scrypt"
+}
+
+func (b *KeystoreBoxer) Seal(in []byte) (string, error) {
+	out, err := EncryptKeystore(in, b.passphrase, b.scryptN, b.scryptP)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (b *KeystoreBoxer) Open(in string) ([]byte, error) {
+	return DecryptKeystore(bytes.TrimSpace([]byte(in)), b.passphrase)
+}
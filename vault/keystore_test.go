@@ -0,0 +1,169 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from the Web3 Secret Storage Definition.
+func TestDecryptKeystore_Vectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		keystore string
+	}{
+		{
+			name: "scrypt",
+			keystore: `{
+				"crypto": {
+					"cipher": "aes-128-ctr",
+					"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+					"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+					"kdf": "scrypt",
+					"kdfparams": {
+						"dklen": 32,
+						"n": 262144,
+						"r": 1,
+						"p": 8,
+						"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+					},
+					"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+				},
+				"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version": 3
+			}`,
+		},
+		{
+			name: "pbkdf2",
+			keystore: `{
+				"crypto": {
+					"cipher": "aes-128-ctr",
+					"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+					"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+					"kdf": "pbkdf2",
+					"kdfparams": {
+						"c": 262144,
+						"dklen": 32,
+						"prf": "hmac-sha256",
+						"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+					},
+					"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+				},
+				"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version": 3
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := DecryptKeystore([]byte(test.keystore), "testpassword")
+			require.NoError(t, err)
+			assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(out))
+
+			_, err = DecryptKeystore([]byte(test.keystore), "wrongpassword")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDecryptKeystore_Limits(t *testing.T) {
+	keystore := func(kdf string, params string) []byte {
+		return []byte(`{
+			"crypto": {
+				"cipher": "aes-128-ctr",
+				"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+				"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+				"kdf": "` + kdf + `",
+				"kdfparams": {` + params + `, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+				"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+			},
+			"version": 3
+		}`)
+	}
+
+	tests := []struct {
+		name   string
+		kdf    string
+		params string
+	}{
+		{"scrypt memory", "scrypt", `"dklen": 32, "n": 1073741824, "r": 8, "p": 1`},
+		{"scrypt huge r", "scrypt", `"dklen": 32, "n": 2, "r": 9223372036854775807, "p": 1`},
+		{"scrypt work", "scrypt", `"dklen": 32, "n": 262144, "r": 8, "p": 1000`},
+		{"scrypt missing n", "scrypt", `"dklen": 32, "r": 8, "p": 1`},
+		{"pbkdf2 iterations", "pbkdf2", `"dklen": 32, "c": 1000000000, "prf": "hmac-sha256"`},
+		{"dklen", "scrypt", `"dklen": 1000000000, "n": 2, "r": 1, "p": 1`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := DecryptKeystore(keystore(test.kdf, test.params), "testpassword")
+			require.Error(t, err)
+			assert.NotContains(t, err.Error(), "invalid passphrase")
+		})
+	}
+}
+
+func TestDecryptKeystore_InvalidIV(t *testing.T) {
+	// The pbkdf2 vector with a 15-byte IV: the MAC, which only covers the
+	// ciphertext, is still valid.
+	keystore := `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"version": 3
+	}`
+
+	_, err := DecryptKeystore([]byte(keystore), "testpassword")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "iv length")
+}
+
+func TestKeystoreBoxer(t *testing.T) {
+	boxer := NewKeystoreBoxerWithParams("secret", LightScryptN, LightScryptP)
+	assert.Equal(t, "keystore-scrypt", boxer.WrapType())
+
+	sealed, err := boxer.Seal([]byte("hello world"))
+	require.NoError(t, err)
+
+	var keystore KeystoreV3
+	require.NoError(t, json.Unmarshal([]byte(sealed), &keystore))
+	assert.Equal(t, 3, keystore.Version)
+	assert.Equal(t, "scrypt", keystore.Crypto.KDF)
+	assert.Len(t, keystore.ID, 36)
+
+	opened, err := boxer.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), opened)
+
+	_, err = NewKeystoreBoxerWithParams("other", LightScryptN, LightScryptP).Open(sealed)
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingKeyWrapper struct {
+	KeyWrapper
+	wraps   int
+	unwraps int
+}
+
+func (w *countingKeyWrapper) WrapDEK(plainDEK []byte) (string, error) {
+	w.wraps++
+	return w.KeyWrapper.WrapDEK(plainDEK)
+}
+
+func (w *countingKeyWrapper) UnwrapDEK(wrappedDEK string) ([]byte, error) {
+	w.unwraps++
+	return w.KeyWrapper.UnwrapDEK(wrappedDEK)
+}
+
+func TestEnvelopeManager_DEKCache(t *testing.T) {
+	local, err := NewLocalKeyManager(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	wrapper := &countingKeyWrapper{KeyWrapper: local}
+	mgr := NewEnvelopeManager(wrapper)
+
+	first, err := mgr.Encrypt([]byte("first"))
+	require.NoError(t, err)
+	second, err := mgr.Encrypt([]byte("second"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, wrapper.wraps)
+
+	out, err := mgr.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), out)
+	assert.Equal(t, 0, wrapper.unwraps)
+
+	// A fresh manager unwraps the DEK once.
+	wrapper = &countingKeyWrapper{KeyWrapper: local}
+	mgr = NewEnvelopeManager(wrapper)
+	for _, blob := range [][]byte{first, second} {
+		_, err := mgr.Decrypt(blob)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, wrapper.unwraps)
+
+	// And encrypts again with the unwrapped DEK.
+	third, err := mgr.Encrypt([]byte("third"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, wrapper.wraps)
+	var firstBlob, thirdBlob BlobV1
+	require.NoError(t, json.Unmarshal(first, &firstBlob))
+	require.NoError(t, json.Unmarshal(third, &thirdBlob))
+	assert.Equal(t, firstBlob.WrappedDEK, thirdBlob.WrappedDEK)
+}
+
+func TestKMSBoxer_ReusesManager(t *testing.T) {
+	local, err := NewLocalKeyManager(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	sealed, err := NewKMSBoxer("test", func() (KMSManager, error) {
+		return NewEnvelopeManager(local), nil
+	}).Seal([]byte("hello"))
+	require.NoError(t, err)
+
+	// Opening then sealing again unwraps the DEK, and wraps nothing.
+	wrapper := &countingKeyWrapper{KeyWrapper: local}
+	managers := 0
+	boxer := NewKMSBoxer("test", func() (KMSManager, error) {
+		managers++
+		return NewEnvelopeManager(wrapper), nil
+	})
+	out, err := boxer.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), out)
+	_, err = boxer.Seal(out)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, managers)
+	assert.Equal(t, 1, wrapper.unwraps)
+	assert.Equal(t, 0, wrapper.wraps)
+}
+
+func TestKMSLocalBoxer(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, GenerateLocalMasterKeyFile(keyFile))
+	require.Error(t, GenerateLocalMasterKeyFile(keyFile))
+
+	v := NewVault()
+	pubKey, err := v.NewKeyPair()
+	require.NoError(t, err)
+
+	boxer := NewKMSLocalBoxer(keyFile)
+	require.NoError(t, v.Seal(boxer))
+	assert.Equal(t, "kms-local", v.SecretBoxWrap)
+
+	reopened := &Vault{SecretBoxCiphertext: v.SecretBoxCiphertext}
+	require.NoError(t, reopened.Open(boxer))
+	require.Len(t, reopened.KeyBag, 1)
+	assert.Equal(t, pubKey, reopened.KeyBag[0].PublicKey())
+
+	otherKeyFile := filepath.Join(t.TempDir(), "other.key")
+	require.NoError(t, GenerateLocalMasterKeyFile(otherKeyFile))
+	err = reopened.Open(NewKMSLocalBoxer(otherKeyFile))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was wrapped with master key")
+}
+
+func TestVaultTransitManager(t *testing.T) {
+	local, err := NewLocalKeyManager(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "s.token", req.Header.Get("X-Vault-Token"))
+
+		var params map[string]string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+
+		var data map[string]string
+		switch req.URL.Path {
+		case "/v1/secrets/transit/encrypt/vault-key":
+			plain, err := base64.StdEncoding.DecodeString(params["plaintext"])
+			require.NoError(t, err)
+			wrapped, err := local.WrapDEK(plain)
+			require.NoError(t, err)
+			data = map[string]string{"ciphertext": "vault:v1:" + wrapped}
+		case "/v1/secrets/transit/decrypt/vault-key":
+			plain, err := local.UnwrapDEK(strings.TrimPrefix(params["ciphertext"], "vault:v1:"))
+			if err != nil {
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+				return
+			}
+			data = map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plain)}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		json.NewEncoder(rw).Encode(map[string]interface{}{"data": data})
+	}))
+	defer srv.Close()
+
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "s.token")
+
+	mgr, err := NewVaultTransitManagerFromEnv("secrets/transit/vault-key")
+	require.NoError(t, err)
+	blob, err := mgr.Encrypt([]byte("hello"))
+	require.NoError(t, err)
+
+	mgr, err = NewVaultTransitManagerFromEnv("secrets/transit/vault-key")
+	require.NoError(t, err)
+	out, err := mgr.Decrypt(blob)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), out)
+
+	mgr, err = NewVaultTransitManagerFromEnv("unknown-key")
+	require.NoError(t, err)
+	_, err = mgr.Decrypt(blob)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestKMSAWSManager(t *testing.T) {
+	local, err := NewLocalKeyManager(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Contains(t, req.Header.Get("Authorization"), "Credential=AKID/")
+		assert.Contains(t, req.Header.Get("Authorization"), "/us-east-1/kms/aws4_request")
+
+		var params struct {
+			KeyId          string
+			Plaintext      []byte
+			CiphertextBlob []byte
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&params))
+		assert.Equal(t, "alias/vault", params.KeyId)
+
+		switch req.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			wrapped, err := local.WrapDEK(params.Plaintext)
+			require.NoError(t, err)
+			json.NewEncoder(rw).Encode(map[string]interface{}{"CiphertextBlob": []byte(wrapped), "KeyId": params.KeyId})
+		case "TrentService.Decrypt":
+			plain, err := local.UnwrapDEK(string(params.CiphertextBlob))
+			require.NoError(t, err)
+			json.NewEncoder(rw).Encode(map[string]interface{}{"Plaintext": plain, "KeyId": params.KeyId})
+		}
+	}))
+	defer srv.Close()
+
+	credentials := AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}
+	blob, err := NewKMSAWSManager("alias/vault", "us-east-1", srv.URL, credentials).Encrypt([]byte("hello"))
+	require.NoError(t, err)
+
+	out, err := NewKMSAWSManager("alias/vault", "us-east-1", srv.URL, credentials).Decrypt(blob)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), out)
+}
+
+// Example from the AWS Signature Version 4 documentation.
+func TestSignAWSRequestV4(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signAWSRequestV4(req, nil, AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "iam", now)
+
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"),
+	)
+}
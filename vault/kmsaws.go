@@ -0,0 +1,288 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+///
+/// Boxer implementation.
+///
+
+// NewKMSAWSBoxer returns a boxer doing envelope encryption with the AWS
+// KMS key `keyID`, see NewKMSAWSManagerFromEnv.
+func NewKMSAWSBoxer(keyID string) *KMSBoxer {
+	return NewKMSBoxer("kms-aws", func() (KMSManager, error) {
+		return NewKMSAWSManagerFromEnv(keyID)
+	})
+}
+
+///
+/// Manager implementation
+///
+
+// AWSCredentials are the static credentials used to sign AWS KMS requests.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// KMSAWSManager is a KMSManager wrapping its data encryption keys with
+// an AWS KMS symmetric key. Requests are signed with AWS Signature V4.
+type KMSAWSManager struct {
+	*EnvelopeManager
+	keyID       string
+	region      string
+	endpoint    string
+	credentials AWSCredentials
+	client      *http.Client
+}
+
+// NewKMSAWSManager creates a manager for the KMS key `keyID` (key ID, key
+// ARN, alias name or alias ARN) in `region`. An empty `endpoint` uses the
+// regional AWS KMS endpoint.
+func NewKMSAWSManager(keyID, region, endpoint string, credentials AWSCredentials) *KMSAWSManager {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", region)
+	}
+	manager := &KMSAWSManager{
+		keyID:       keyID,
+		region:      region,
+		endpoint:    strings.TrimRight(endpoint, "/"),
+		credentials: credentials,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}
+	manager.EnvelopeManager = NewEnvelopeManager(manager)
+	return manager
+}
+
+// NewKMSAWSManagerFromEnv creates a manager for the KMS key `keyID`, reading
+// the region and static credentials from the standard AWS_REGION (or
+// AWS_DEFAULT_REGION), AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables. AWS_KMS_ENDPOINT overrides the endpoint.
+func NewKMSAWSManagerFromEnv(keyID string) (*KMSAWSManager, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("missing kms-aws key id")
+	}
+
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		// The region of an ARN is its fourth field.
+		if parts := strings.Split(keyID, ":"); len(parts) > 3 && parts[0] == "arn" {
+			region = parts[3]
+		}
+	}
+	if region == "" {
+		return nil, fmt.Errorf("missing AWS_REGION environment variable")
+	}
+
+	credentials := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("missing AWS_ACCESS_KEY_ID or AWS_SECRET_ACCESS_KEY environment variable")
+	}
+
+	return NewKMSAWSManager(keyID, region, os.Getenv("AWS_KMS_ENDPOINT"), credentials), nil
+}
+
+func (k *KMSAWSManager) WrapDEK(plainDEK []byte) (string, error) {
+	var resp struct {
+		CiphertextBlob []byte `json:"CiphertextBlob"`
+	}
+	err := k.call("Encrypt", map[string]interface{}{
+		"KeyId":     k.keyID,
+		"Plaintext": plainDEK,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	// Stored the same way the AWS CLI prints it.
+	return base64.StdEncoding.EncodeToString(resp.CiphertextBlob), nil
+}
+
+func (k *KMSAWSManager) UnwrapDEK(wrappedDEK string) ([]byte, error) {
+	ciphertextBlob, err := base64.StdEncoding.DecodeString(wrappedDEK)
+	if err != nil {
+		return nil, fmt.Errorf("base 64 decode, %s", err)
+	}
+
+	var resp struct {
+		Plaintext []byte `json:"Plaintext"`
+	}
+	err = k.call("Decrypt", map[string]interface{}{
+		"KeyId":          k.keyID,
+		"CiphertextBlob": ciphertextBlob,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Plaintext, nil
+}
+
+func (k *KMSAWSManager) call(operation string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, k.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+operation)
+	signAWSRequestV4(req, body, k.credentials, k.region, "kms", time.Now().UTC())
+
+	httpResp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("aws kms %s: %w", operation, err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("aws kms %s: read response: %w", operation, err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var awsErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &awsErr)
+		return fmt.Errorf("aws kms %s: status %d: %s: %s", operation, httpResp.StatusCode, awsErr.Type, awsErr.Message)
+	}
+
+	return json.Unmarshal(respBody, out)
+}
+
+// signAWSRequestV4 adds the AWS Signature Version 4 headers to `req`.
+// See https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+func signAWSRequestV4(req *http.Request, body []byte, credentials AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	var headerNames []string
+	headers := map[string]string{}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		headerNames = append(headerNames, lower)
+		headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQueryString(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalQueryString(values url.Values) string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		vals := append([]string{}, values[key]...)
+		sort.Strings(vals)
+		for _, val := range vals {
+			parts = append(parts, awsURIEncode(key)+"="+awsURIEncode(val))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func awsURIEncode(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
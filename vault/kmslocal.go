@@ -0,0 +1,160 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+///
+/// Boxer implementation.
+///
+
+// NewKMSLocalBoxer returns a boxer doing envelope encryption with the
+// master key stored in the file at `keyFile`, see NewLocalKeyManagerFromFile.
+func NewKMSLocalBoxer(keyFile string) *KMSBoxer {
+	return NewKMSBoxer("kms-local", func() (KMSManager, error) {
+		return NewLocalKeyManagerFromFile(keyFile)
+	})
+}
+
+///
+/// Manager implementation
+///
+
+const localWrappedDEKPrefix = "local:v1:"
+
+// LocalKeyManager is a KMSManager wrapping its data encryption keys
+// with a 32 bytes master key held in memory. It works fully offline.
+type LocalKeyManager struct {
+	*EnvelopeManager
+	masterKey [32]byte
+	keyID     string
+}
+
+func NewLocalKeyManager(masterKey []byte) (*LocalKeyManager, error) {
+	if len(masterKey) != keyLength {
+		return nil, fmt.Errorf("invalid master key length: expected %d bytes, got %d", keyLength, len(masterKey))
+	}
+
+	manager := &LocalKeyManager{}
+	copy(manager.masterKey[:], masterKey)
+	// The key ID identifies the master key a DEK was wrapped with,
+	// without revealing anything about the key.
+	keyIDHash := sha256.Sum256(append([]byte(localWrappedDEKPrefix), masterKey...))
+	manager.keyID = hex.EncodeToString(keyIDHash[:8])
+	manager.EnvelopeManager = NewEnvelopeManager(manager)
+
+	return manager, nil
+}
+
+// NewLocalKeyManagerFromFile reads a base64-encoded 32 bytes master
+// key from `keyFile`, as written by GenerateLocalMasterKeyFile.
+func NewLocalKeyManagerFromFile(keyFile string) (*LocalKeyManager, error) {
+	cnt, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read master key file: %w", err)
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(cnt)))
+	if err != nil {
+		return nil, fmt.Errorf("decode master key file: %w", err)
+	}
+
+	return NewLocalKeyManager(masterKey)
+}
+
+// GenerateLocalMasterKeyFile writes a new random master key to `keyFile`,
+// which must not exist.
+func GenerateLocalMasterKeyFile(keyFile string) error {
+	masterKey := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, masterKey); err != nil {
+		return err
+	}
+
+	fl, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fl.WriteString(base64.StdEncoding.EncodeToString(masterKey) + "\n")
+	if err != nil {
+		fl.Close()
+		return err
+	}
+
+	return fl.Close()
+}
+
+// KeyID returns the identifier of the master key, which prefixes the DEKs it wraps.
+func (k *LocalKeyManager) KeyID() string {
+	return k.keyID
+}
+
+func (k *LocalKeyManager) WrapDEK(plainDEK []byte) (string, error) {
+	var nonce [nonceLength]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", err
+	}
+
+	sealed := secretbox.Seal(nonce[:], plainDEK, &nonce, &k.masterKey)
+
+	return localWrappedDEKPrefix + k.keyID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (k *LocalKeyManager) UnwrapDEK(wrappedDEK string) ([]byte, error) {
+	if !strings.HasPrefix(wrappedDEK, localWrappedDEKPrefix) {
+		return nil, fmt.Errorf("not a locally wrapped dek")
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(wrappedDEK, localWrappedDEKPrefix), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed locally wrapped dek")
+	}
+	if parts[0] != k.keyID {
+		return nil, fmt.Errorf("dek was wrapped with master key %s, not %s", parts[0], k.keyID)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("base 64 decode, %s", err)
+	}
+	if len(sealed) < nonceLength {
+		return nil, fmt.Errorf("malformed locally wrapped dek")
+	}
+
+	var nonce [nonceLength]byte
+	copy(nonce[:], sealed[:nonceLength])
+	plainDEK, ok := secretbox.Open(nil, sealed[nonceLength:], &nonce, &k.masterKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to unwrap dek")
+	}
+
+	return plainDEK, nil
+}
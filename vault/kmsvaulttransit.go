@@ -0,0 +1,175 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+///
+/// Boxer implementation.
+///
+
+// NewVaultTransitBoxer returns a boxer doing envelope encryption with a
+// HashiCorp Vault Transit key, see NewVaultTransitManagerFromEnv.
+func NewVaultTransitBoxer(keyPath string) *KMSBoxer {
+	return NewKMSBoxer("vault-transit", func() (KMSManager, error) {
+		return NewVaultTransitManagerFromEnv(keyPath)
+	})
+}
+
+///
+/// Manager implementation
+///
+
+const defaultVaultTransitMount = "transit"
+
+// VaultTransitManager is a KMSManager wrapping its data encryption keys
+// with a HashiCorp Vault Transit secrets engine key.
+type VaultTransitManager struct {
+	*EnvelopeManager
+	address   string
+	token     string
+	namespace string
+	mount     string
+	keyName   string
+	client    *http.Client
+}
+
+func NewVaultTransitManager(address, token, mount, keyName string) *VaultTransitManager {
+	if mount == "" {
+		mount = defaultVaultTransitMount
+	}
+	manager := &VaultTransitManager{
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		keyName: keyName,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+	manager.EnvelopeManager = NewEnvelopeManager(manager)
+	return manager
+}
+
+// NewVaultTransitManagerFromEnv creates a manager for `keyPath`, either
+// `<key name>` on the default `transit` mount or `<mount>/<key name>`.
+// The server address, token and optional namespace are read from the
+// standard VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables.
+func NewVaultTransitManagerFromEnv(keyPath string) (*VaultTransitManager, error) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		return nil, fmt.Errorf("missing VAULT_ADDR environment variable")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("missing VAULT_TOKEN environment variable")
+	}
+
+	mount, keyName := defaultVaultTransitMount, strings.Trim(keyPath, "/")
+	if idx := strings.LastIndex(keyName, "/"); idx != -1 {
+		mount, keyName = keyName[:idx], keyName[idx+1:]
+	}
+	if keyName == "" {
+		return nil, fmt.Errorf("missing vault transit key name")
+	}
+
+	manager := NewVaultTransitManager(address, token, mount, keyName)
+	manager.namespace = os.Getenv("VAULT_NAMESPACE")
+	return manager, nil
+}
+
+func (k *VaultTransitManager) WrapDEK(plainDEK []byte) (string, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	err := k.call("encrypt", map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(plainDEK),
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Ciphertext, nil
+}
+
+func (k *VaultTransitManager) UnwrapDEK(wrappedDEK string) ([]byte, error) {
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	err := k.call("decrypt", map[string]string{
+		"ciphertext": wrappedDEK,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+func (k *VaultTransitManager) call(operation string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", k.address, k.mount, operation, k.keyName)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", k.token)
+	if k.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.namespace)
+	}
+
+	httpResp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit %s: %w", operation, err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("vault transit %s: read response: %w", operation, err)
+	}
+
+	var resp struct {
+		Data   stdjson.RawMessage `json:"data"`
+		Errors []string           `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("vault transit %s: status %d: decode response: %w", operation, httpResp.StatusCode, err)
+	}
+	if httpResp.StatusCode != http.StatusOK || len(resp.Errors) != 0 {
+		return fmt.Errorf("vault transit %s: status %d: %s", operation, httpResp.StatusCode, strings.Join(resp.Errors, "; "))
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return fmt.Errorf("vault transit %s: empty response", operation)
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
@@ -35,20 +35,48 @@ func SecretBoxerForType(boxerType string, keypath string) (SecretBoxer, error) {
 			return nil, errors.New("missing kms-gcp keypath")
 		}
 		return NewKMSGCPBoxer(keypath), nil
+	case "kms-aws":
+		if keypath == "" {
+			return nil, errors.New("missing kms-aws key id")
+		}
+		return NewKMSAWSBoxer(keypath), nil
+	case "vault-transit":
+		if keypath == "" {
+			return nil, errors.New("missing vault-transit key")
+		}
+		return NewVaultTransitBoxer(keypath), nil
+	case "kms-local":
+		if keypath == "" {
+			return nil, errors.New("missing kms-local master key file")
+		}
+		return NewKMSLocalBoxer(keypath), nil
 	case "passphrase":
-		var password string
-		var err error
-		if envVal := os.Getenv("SLNC_GLOBAL_INSECURE_VAULT_PASSPHRASE"); envVal != "" {
-			password = envVal
-		} else {
-			password, err = cli.GetDecryptPassphrase()
-			if err != nil {
-				return nil, err
-			}
+		password, err := decryptPassphrase()
+		if err != nil {
+			return nil, err
 		}
 
 		return NewPassphraseBoxer(password), nil
+	case "keystore-scrypt":
+		password, err := decryptPassphrase()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewKeystoreBoxer(password), nil
+	case "age-x25519":
+		if keypath == "" {
+			return nil, errors.New("missing age-x25519 identity file")
+		}
+		return NewX25519BoxerFromFile(keypath)
 	default:
 		return nil, fmt.Errorf("unknown secret boxer: %s", boxerType)
 	}
 }
+
+func decryptPassphrase() (string, error) {
+	if envVal := os.Getenv("SLNC_GLOBAL_INSECURE_VAULT_PASSPHRASE"); envVal != "" {
+		return envVal, nil
+	}
+	return cli.GetDecryptPassphrase()
+}
@@ -0,0 +1,400 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bufio"
+	crypto_rand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// The X25519 boxer follows the construction of age (https://age-encryption.org/v1)
+// X25519 recipients: a random file key is wrapped for each recipient with
+// a key derived from an ephemeral X25519 exchange, and the payload is
+// encrypted with ChaCha20-Poly1305 under a key derived from the file key.
+// Keys use the age encoding, so `age-keygen` can be used to create them.
+// The sealed blob itself is NOT an age file.
+
+const (
+	x25519RecipientHRP = "age"
+	x25519IdentityHRP  = "AGE-SECRET-KEY-"
+	x25519Label        = "age-encryption.org/v1/X25519"
+	x25519FileKeySize  = 16
+	x25519NonceSize    = 16
+)
+
+// X25519Recipient is the public part of an X25519Identity; anything
+// sealed for a recipient can be opened by the matching identity.
+type X25519Recipient struct {
+	theirPublicKey []byte
+}
+
+// X25519Identity is an X25519 private key.
+type X25519Identity struct {
+	secretKey []byte
+	ourPublic []byte
+}
+
+// GenerateX25519Identity creates a new random identity.
+func GenerateX25519Identity() (*X25519Identity, error) {
+	secretKey := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(crypto_rand.Reader, secretKey); err != nil {
+		return nil, err
+	}
+	return newX25519Identity(secretKey)
+}
+
+func newX25519Identity(secretKey []byte) (*X25519Identity, error) {
+	if len(secretKey) != curve25519.ScalarSize {
+		return nil, fmt.Errorf("invalid X25519 secret key length: %d", len(secretKey))
+	}
+	ourPublic, err := curve25519.X25519(secretKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{secretKey: secretKey, ourPublic: ourPublic}, nil
+}
+
+// ParseX25519Identity parses an age-encoded identity ("AGE-SECRET-KEY-1...").
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed X25519 identity: %w", err)
+	}
+	if hrp != x25519IdentityHRP {
+		return nil, fmt.Errorf("malformed X25519 identity: unexpected type %q", hrp)
+	}
+	return newX25519Identity(data)
+}
+
+// ParseX25519Recipient parses an age-encoded recipient ("age1...").
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed X25519 recipient: %w", err)
+	}
+	if hrp != x25519RecipientHRP {
+		return nil, fmt.Errorf("malformed X25519 recipient: unexpected type %q", hrp)
+	}
+	if len(data) != curve25519.PointSize {
+		return nil, fmt.Errorf("malformed X25519 recipient: invalid length %d", len(data))
+	}
+	return &X25519Recipient{theirPublicKey: data}, nil
+}
+
+// Recipient returns the recipient that matches the identity.
+func (i *X25519Identity) Recipient() *X25519Recipient {
+	return &X25519Recipient{theirPublicKey: i.ourPublic}
+}
+
+func (i *X25519Identity) String() string {
+	s, _ := bech32Encode(x25519IdentityHRP, i.secretKey)
+	return s
+}
+
+func (r *X25519Recipient) String() string {
+	s, _ := bech32Encode(x25519RecipientHRP, r.theirPublicKey)
+	return s
+}
+
+// X25519Stanza is a file key wrapped for one recipient.
+type X25519Stanza struct {
+	EphemeralShare []byte `json:"ephemeral_share"`
+	WrappedKey     []byte `json:"wrapped_key"`
+}
+
+func (r *X25519Recipient) wrap(fileKey []byte) (*X25519Stanza, error) {
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(crypto_rand.Reader, ephemeral); err != nil {
+		return nil, err
+	}
+	ourPublicKey, err := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := curve25519.X25519(ephemeral, r.theirPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappingKey, err := x25519WrappingKey(sharedSecret, ourPublicKey, r.theirPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := aeadSeal(wrappingKey, fileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X25519Stanza{
+		EphemeralShare: ourPublicKey,
+		WrappedKey:     wrappedKey,
+	}, nil
+}
+
+var errIncorrectIdentity = errors.New("incorrect identity for recipient stanza")
+
+func (i *X25519Identity) unwrap(stanza *X25519Stanza) ([]byte, error) {
+	if len(stanza.EphemeralShare) != curve25519.PointSize {
+		return nil, fmt.Errorf("invalid X25519 recipient stanza")
+	}
+	sharedSecret, err := curve25519.X25519(i.secretKey, stanza.EphemeralShare)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X25519 recipient: %w", err)
+	}
+
+	wrappingKey, err := x25519WrappingKey(sharedSecret, stanza.EphemeralShare, i.ourPublic)
+	if err != nil {
+		return nil, err
+	}
+	fileKey, err := aeadOpen(wrappingKey, stanza.WrappedKey)
+	if err != nil {
+		return nil, errIncorrectIdentity
+	}
+	return fileKey, nil
+}
+
+func x25519WrappingKey(sharedSecret, ephemeralShare, recipient []byte) ([]byte, error) {
+	salt := make([]byte, 0, len(ephemeralShare)+len(recipient))
+	salt = append(salt, ephemeralShare...)
+	salt = append(salt, recipient...)
+	return hkdfKey(sharedSecret, salt, x25519Label)
+}
+
+func hkdfKey(secret, salt []byte, info string) ([]byte, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// aeadSeal and aeadOpen use an all-zero nonce, which is safe as every key
+// they are used with is freshly derived and used only once.
+func aeadSeal(key, plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	return aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func aeadOpen(key, ciphertext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// X25519BlobV1 is the sealed representation of the X25519 boxer.
+type X25519BlobV1 struct {
+	Version       int             `json:"version"`
+	Recipients    []*X25519Stanza `json:"recipients"`
+	Nonce         []byte          `json:"nonce"`
+	EncryptedData []byte          `json:"data"`
+}
+
+///
+/// Boxer implementation.
+///
+
+// X25519Boxer seals the vault for a set of X25519 recipients, and opens
+// it with any of a set of X25519 identities.
+type X25519Boxer struct {
+	recipients []*X25519Recipient
+	identities []*X25519Identity
+}
+
+// NewX25519Boxer creates a boxer that seals for `recipients` and opens with
+// `identities`. The recipients of the identities are always added to the
+// recipients, so the vault can be reopened by whoever sealed it.
+func NewX25519Boxer(recipients []*X25519Recipient, identities []*X25519Identity) *X25519Boxer {
+	b := &X25519Boxer{identities: identities}
+	seen := map[string]bool{}
+	add := func(r *X25519Recipient) {
+		if !seen[r.String()] {
+			seen[r.String()] = true
+			b.recipients = append(b.recipients, r)
+		}
+	}
+	for _, identity := range identities {
+		add(identity.Recipient())
+	}
+	for _, recipient := range recipients {
+		add(recipient)
+	}
+	return b
+}
+
+// NewX25519BoxerFromFile creates a boxer from a file holding age-encoded
+// identities ("AGE-SECRET-KEY-1...") and/or recipients ("age1..."), one per
+// line. Empty lines and lines starting with '#' are ignored, so files
+// created by `age-keygen` are supported.
+func NewX25519BoxerFromFile(path string) (*X25519Boxer, error) {
+	fl, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+
+	var recipients []*X25519Recipient
+	var identities []*X25519Identity
+	scanner := bufio.NewScanner(fl)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, x25519IdentityHRP+"1"):
+			identity, err := ParseX25519Identity(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+			}
+			identities = append(identities, identity)
+		case strings.HasPrefix(line, x25519RecipientHRP+"1"):
+			recipient, err := ParseX25519Recipient(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+			}
+			recipients = append(recipients, recipient)
+		default:
+			return nil, fmt.Errorf("%s:%d: not an X25519 identity nor recipient", path, lineNum)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 && len(identities) == 0 {
+		return nil, fmt.Errorf("no X25519 identity nor recipient found in %q", path)
+	}
+
+	return NewX25519Boxer(recipients, identities), nil
+}
+
+func (b *X25519Boxer) WrapType() string {
+	return "age-x25519"
+}
+
+func (b *X25519Boxer) Seal(in []byte) (string, error) {
+	if len(b.recipients) == 0 {
+		return "", fmt.Errorf("no X25519 recipient to seal for")
+	}
+
+	fileKey := make([]byte, x25519FileKeySize)
+	if _, err := io.ReadFull(crypto_rand.Reader, fileKey); err != nil {
+		return "", err
+	}
+
+	blob := &X25519BlobV1{
+		Version: 1,
+		Nonce:   make([]byte, x25519NonceSize),
+	}
+	for _, recipient := range b.recipients {
+		stanza, err := recipient.wrap(fileKey)
+		if err != nil {
+			return "", fmt.Errorf("wrap file key for %s: %w", recipient, err)
+		}
+		blob.Recipients = append(blob.Recipients, stanza)
+	}
+
+	if _, err := io.ReadFull(crypto_rand.Reader, blob.Nonce); err != nil {
+		return "", err
+	}
+	payloadKey, err := hkdfKey(fileKey, blob.Nonce, "payload")
+	if err != nil {
+		return "", err
+	}
+	blob.EncryptedData, err = aeadSeal(payloadKey, in)
+	if err != nil {
+		return "", err
+	}
+
+	cereal, err := json.Marshal(blob)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawStdEncoding.EncodeToString(cereal), nil
+}
+
+func (b *X25519Boxer) Open(in string) ([]byte, error) {
+	if len(b.identities) == 0 {
+		return nil, fmt.Errorf("no X25519 identity to open with")
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(in)
+	if err != nil {
+		return nil, fmt.Errorf("base 64 decode, %s", err)
+	}
+	var blob X25519BlobV1
+	if err := json.Unmarshal(data, &blob); err != nil {
+		return nil, err
+	}
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("unsupported X25519 blob version: %d", blob.Version)
+	}
+
+	// A malformed stanza, or one meant for another kind of recipient, must
+	// not prevent trying the other ones: it is only reported if no stanza
+	// can be unwrapped.
+	var fileKey []byte
+	var stanzaErr error
+stanzas:
+	for idx, stanza := range blob.Recipients {
+		for _, identity := range b.identities {
+			key, err := identity.unwrap(stanza)
+			if err == nil {
+				fileKey = key
+				break stanzas
+			}
+			if err != errIncorrectIdentity && stanzaErr == nil {
+				stanzaErr = fmt.Errorf("recipient %d: %w", idx, err)
+			}
+		}
+	}
+	if fileKey == nil {
+		if stanzaErr != nil {
+			return nil, fmt.Errorf("no identity matched any of the %d recipients: %w", len(blob.Recipients), stanzaErr)
+		}
+		return nil, fmt.Errorf("no identity matched any of the %d recipients", len(blob.Recipients))
+	}
+
+	payloadKey, err := hkdfKey(fileKey, blob.Nonce, "payload")
+	if err != nil {
+		return nil, err
+	}
+	plainData, err := aeadOpen(payloadKey, blob.EncryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting data, that's all we know")
+	}
+	return plainData, nil
+}
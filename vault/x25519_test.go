@@ -0,0 +1,134 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBech32(t *testing.T) {
+	// BIP-173 valid checksum vector.
+	hrp, data, err := bech32Decode("A12UEL5L")
+	require.NoError(t, err)
+	assert.Equal(t, "A", hrp)
+	assert.Empty(t, data)
+
+	_, _, err = bech32Decode("A12UEL5X")
+	require.Error(t, err)
+
+	encoded, err := bech32Encode("age", []byte{0x01, 0x02, 0xff})
+	require.NoError(t, err)
+	hrp, data, err = bech32Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "age", hrp)
+	assert.Equal(t, []byte{0x01, 0x02, 0xff}, data)
+}
+
+func TestX25519Identity(t *testing.T) {
+	// Key pair from the age test suite.
+	identity, err := ParseX25519Identity("AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX")
+	require.NoError(t, err)
+	assert.Equal(t, "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj", identity.Recipient().String())
+	assert.Equal(t, "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX", identity.String())
+
+	_, err = ParseX25519Recipient(identity.String())
+	require.Error(t, err)
+}
+
+func TestX25519Boxer(t *testing.T) {
+	alice, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	bob, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	eve, err := GenerateX25519Identity()
+	require.NoError(t, err)
+
+	boxer := NewX25519Boxer([]*X25519Recipient{bob.Recipient()}, []*X25519Identity{alice})
+	assert.Equal(t, "age-x25519", boxer.WrapType())
+
+	sealed, err := boxer.Seal([]byte("hello world"))
+	require.NoError(t, err)
+
+	for _, identity := range []*X25519Identity{alice, bob} {
+		opened, err := NewX25519Boxer(nil, []*X25519Identity{identity}).Open(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), opened)
+	}
+
+	_, err = NewX25519Boxer(nil, []*X25519Identity{eve}).Open(sealed)
+	require.Error(t, err)
+
+	{
+		// A malformed stanza before the one of the identity is skipped:
+		data, err := base64.RawStdEncoding.DecodeString(sealed)
+		require.NoError(t, err)
+		var blob X25519BlobV1
+		require.NoError(t, json.Unmarshal(data, &blob))
+		blob.Recipients = append([]*X25519Stanza{{EphemeralShare: []byte{1, 2, 3}}}, blob.Recipients...)
+		data, err = json.Marshal(blob)
+		require.NoError(t, err)
+		tampered := base64.RawStdEncoding.EncodeToString(data)
+
+		opened, err := NewX25519Boxer(nil, []*X25519Identity{bob}).Open(tampered)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), opened)
+
+		_, err = NewX25519Boxer(nil, []*X25519Identity{eve}).Open(tampered)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid X25519 recipient stanza")
+	}
+}
+
+func TestNewX25519BoxerFromFile(t *testing.T) {
+	identity, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := GenerateX25519Identity()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.txt")
+	content := strings.Join([]string{
+		"# created: 2021-01-01T00:00:00Z",
+		"# public key: " + identity.Recipient().String(),
+		identity.String(),
+		"",
+		other.Recipient().String(),
+	}, "\n")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	boxer, err := NewX25519BoxerFromFile(path)
+	require.NoError(t, err)
+	assert.Len(t, boxer.recipients, 2)
+	assert.Len(t, boxer.identities, 1)
+
+	sealed, err := boxer.Seal([]byte("hello world"))
+	require.NoError(t, err)
+	opened, err := NewX25519Boxer(nil, []*X25519Identity{other}).Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), opened)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("not a key"), 0600))
+	_, err = NewX25519BoxerFromFile(path)
+	require.Error(t, err)
+}
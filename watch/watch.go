@@ -0,0 +1,354 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watch follows the state of a set of accounts and reports the
+// changes between their successive decoded versions as `diff.Event`s.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/diff"
+	"github.com/xmcontinue/solana-go/rpc"
+	"github.com/xmcontinue/solana-go/rpc/ws"
+)
+
+// Options configure a Watcher.
+type Options struct {
+	// Decoder decodes the data of the watched accounts, it defaults to
+	// the account decoder registered for the owner of each account
+	// (see `solana.RegisterAccountDecoder`).
+	Decoder solana.AccountDecoder
+
+	// Patterns, if set, keep only the events whose path matches one of them,
+	// see `diff.Event.Match`; e.g. "Amount" or "ClientIDs[#]". Events about
+	// a whole account being created or closed, which have an empty path,
+	// are always kept.
+	Patterns []string
+
+	// DiffOptions are passed to `diff.Diff`, e.g. to ignore some fields.
+	DiffOptions []diff.Option
+
+	Commitment rpc.CommitmentType
+
+	// PollInterval is the delay between two polls in Poll, defaults to 1 second.
+	PollInterval time.Duration
+}
+
+// Update is a new version of a watched account, with its changes since
+// the previous version.
+type Update struct {
+	Account solana.PublicKey
+	Slot    uint64
+
+	// Previous is nil for the first version of an account, and for an
+	// account created since. Current is nil for an account that doesn't
+	// exist, or was closed.
+	Previous interface{}
+	Current  interface{}
+
+	// Initial is true for the first version of the account seen by the watcher.
+	Initial bool
+
+	Events []diff.Event
+}
+
+// IsEmpty returns true if the update has no events.
+func (u *Update) IsEmpty() bool {
+	return len(u.Events) == 0
+}
+
+// Describe renders `event` along with the type of the account,
+// e.g. "token.Account.Amount changed 100→80".
+func (u *Update) Describe(event diff.Event) string {
+	value := u.Current
+	if value == nil {
+		value = u.Previous
+	}
+	subject := strings.TrimPrefix(fmt.Sprintf("%T", value), "*")
+	if path := event.PathString(); path != "" {
+		subject += "." + path
+	}
+
+	switch event.Kind {
+	case diff.KindAdded:
+		return fmt.Sprintf("%s added %s", subject, valueString(event.New))
+	case diff.KindRemoved:
+		return fmt.Sprintf("%s removed %s", subject, valueString(event.Old))
+	default:
+		return fmt.Sprintf("%s changed %s→%s", subject, valueString(event.Old), valueString(event.New))
+	}
+}
+
+func valueString(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+	if !value.CanInterface() {
+		return fmt.Sprintf("<%s>", value.Type())
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "<nil>"
+		}
+		if stringer, ok := value.Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+		value = value.Elem()
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+type accountState struct {
+	slot  uint64
+	value interface{}
+}
+
+// Watcher keeps the last decoded version of each watched account.
+type Watcher struct {
+	accounts solana.PublicKeySlice
+	opts     Options
+
+	lock   sync.Mutex
+	states map[solana.PublicKey]*accountState
+}
+
+func New(accounts []solana.PublicKey, opts Options) *Watcher {
+	return &Watcher{
+		accounts: solana.PublicKeySlice(accounts).Dedupe(),
+		opts:     opts,
+		states:   map[solana.PublicKey]*accountState{},
+	}
+}
+
+// Accounts returns the watched accounts.
+func (w *Watcher) Accounts() []solana.PublicKey {
+	return w.accounts
+}
+
+// Current returns the last decoded version of `account`, nil if unknown.
+func (w *Watcher) Current(account solana.PublicKey) interface{} {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if state, ok := w.states[account]; ok {
+		return state.value
+	}
+	return nil
+}
+
+// Apply decodes `acct`, the version of `account` at `slot`, replaces the
+// current version with it and returns the changes. A nil or empty `acct`
+// means the account doesn't exist. Versions older than the current one are
+// ignored, and nil is returned for them.
+func (w *Watcher) Apply(account solana.PublicKey, slot uint64, acct *rpc.Account) (*Update, error) {
+	var current interface{}
+	if acct != nil && acct.Lamports != 0 {
+		var err error
+		if current, err = w.decode(acct); err != nil {
+			return nil, fmt.Errorf("unable to decode account %s: %w", account, err)
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	previous, seen := w.states[account]
+	update := &Update{Account: account, Slot: slot, Current: current, Initial: !seen}
+	if seen {
+		if slot < previous.slot {
+			return nil, nil
+		}
+		update.Previous = previous.value
+	}
+	w.states[account] = &accountState{slot: slot, value: current}
+
+	if seen {
+		update.Events = w.diff(update.Previous, current)
+	}
+	return update, nil
+}
+
+func (w *Watcher) decode(acct *rpc.Account) (interface{}, error) {
+	var data []byte
+	if acct.Data != nil {
+		data = acct.Data.GetBinary()
+	}
+	if w.opts.Decoder != nil {
+		return w.opts.Decoder(data)
+	}
+	return solana.DecodeAccount(acct.Owner, data)
+}
+
+func (w *Watcher) diff(previous, current interface{}) (out []diff.Event) {
+	switch {
+	case previous == nil && current == nil:
+		return nil
+	case previous == nil:
+		return []diff.Event{{Kind: diff.KindAdded, New: reflect.ValueOf(current)}}
+	case current == nil:
+		return []diff.Event{{Kind: diff.KindRemoved, Old: reflect.ValueOf(previous)}}
+	}
+
+	diff.Diff(previous, current, append(w.opts.DiffOptions, diff.OnEvent(func(event diff.Event) {
+		if w.keep(&event) {
+			out = append(out, event)
+		}
+	}))...)
+	return out
+}
+
+func (w *Watcher) keep(event *diff.Event) bool {
+	if len(w.opts.Patterns) == 0 || len(event.Path) <= 1 {
+		return true
+	}
+	for _, pattern := range w.opts.Patterns {
+		if match, _ := event.Match(pattern); match {
+			return true
+		}
+	}
+	return false
+}
+
+type notification struct {
+	account solana.PublicKey
+	result  *ws.AccountResult
+	err     error
+}
+
+// Subscribe subscribes to the watched accounts and calls `f` with the
+// changes of each notification, until the context is done, a subscription
+// fails or `f` returns an error. The first version of each account carries
+// no events; afterwards `f` is only called for updates with events.
+func (w *Watcher) Subscribe(ctx context.Context, client *ws.Client, f func(update *Update) error) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	notifications := make(chan *notification)
+	for _, account := range w.accounts {
+		sub, err := client.AccountSubscribe(account, w.opts.Commitment)
+		if err != nil {
+			return fmt.Errorf("unable to subscribe to account %q: %w", account, err)
+		}
+		defer sub.Unsubscribe()
+
+		go func(account solana.PublicKey, sub *ws.AccountSubscription) {
+			for {
+				res, err := sub.Recv()
+				if res == nil && err == nil {
+					// Subscription was closed.
+					return
+				}
+				select {
+				case notifications <- &notification{account: account, result: res, err: err}:
+				case <-done:
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(account, sub)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notif := <-notifications:
+			if notif.err != nil {
+				return fmt.Errorf("received error from account %q subscription: %w", notif.account, notif.err)
+			}
+			if err := w.apply(notif.account, notif.result.Context.Slot, &notif.result.Value.Account, f); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Poll fetches the watched accounts every `PollInterval` with
+// `GetMultipleAccountsChunked`, and calls `f` like Subscribe does, until the
+// context is done, a request fails or `f` returns an error.
+func (w *Watcher) Poll(ctx context.Context, client *rpc.Client, f func(update *Update) error) error {
+	interval := w.opts.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx, client, f); err != nil {
+			if ctx.Err() != nil {
+				// The request was cut short by the context.
+				return ctx.Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context, client *rpc.Client, f func(update *Update) error) error {
+	// The accounts are reported at the slot of the oldest call, so that an
+	// update is never attributed to a slot it is not known to be valid at.
+	resp, err := client.GetMultipleAccountsChunked(ctx, w.accounts, &rpc.GetMultipleAccountsChunkedOpts{
+		GetMultipleAccountsOpts: rpc.GetMultipleAccountsOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: w.opts.Commitment,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get accounts: %w", err)
+	}
+
+	for i, acct := range resp.Value {
+		if err := w.apply(w.accounts[i], resp.Context.Slot, acct, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) apply(account solana.PublicKey, slot uint64, acct *rpc.Account, f func(update *Update) error) error {
+	update, err := w.Apply(account, slot, acct)
+	if err != nil {
+		zlog.Warn("unable to apply account update, skipping",
+			zap.Uint64("slot", slot),
+			zap.Stringer("account", account),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if update == nil || (!update.Initial && update.IsEmpty()) {
+		return nil
+	}
+	return f(update)
+}
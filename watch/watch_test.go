@@ -0,0 +1,184 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xmcontinue/solana-go"
+	"github.com/xmcontinue/solana-go/diff"
+	"github.com/xmcontinue/solana-go/programs/token"
+	"github.com/xmcontinue/solana-go/rpc"
+)
+
+func tokenAccountData(t *testing.T, amount uint64, delegate *solana.PublicKey) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(token.Account{
+		Mint:     solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),
+		Owner:    solana.MustPublicKeyFromBase58("9FRhPDoDk9JrpCqc4r51qTWgdBTxM892TdjexeErQUNs"),
+		Amount:   amount,
+		Delegate: delegate,
+		State:    token.Initialized,
+	}))
+	return buf.Bytes()
+}
+
+func tokenAccount(t *testing.T, amount uint64, delegate *solana.PublicKey) *rpc.Account {
+	return &rpc.Account{
+		Lamports: 2039280,
+		Owner:    solana.TokenProgramID,
+		Data:     rpc.DataBytesOrJSONFromBytes(tokenAccountData(t, amount, delegate)),
+	}
+}
+
+func TestWatcher_Apply(t *testing.T) {
+	account := solana.NewWallet().PublicKey()
+	delegate := solana.NewWallet().PublicKey()
+	w := New([]solana.PublicKey{account}, Options{})
+
+	update, err := w.Apply(account, 10, tokenAccount(t, 100, nil))
+	require.NoError(t, err)
+	assert.True(t, update.Initial)
+	assert.Nil(t, update.Previous)
+	assert.True(t, update.IsEmpty())
+
+	update, err = w.Apply(account, 11, tokenAccount(t, 80, &delegate))
+	require.NoError(t, err)
+	assert.False(t, update.Initial)
+	var descriptions []string
+	for _, event := range update.Events {
+		descriptions = append(descriptions, update.Describe(event))
+	}
+	assert.Equal(t, []string{
+		"token.Account.Amount changed 100→80",
+		"token.Account.Delegate changed <nil>→" + delegate.String(),
+	}, descriptions)
+	match, _ := update.Events[0].Match("Amount")
+	assert.True(t, match)
+
+	// Stale versions are ignored.
+	update, err = w.Apply(account, 9, tokenAccount(t, 1, nil))
+	require.NoError(t, err)
+	assert.Nil(t, update)
+	assert.Equal(t, uint64(80), w.Current(account).(*token.Account).Amount)
+
+	update, err = w.Apply(account, 12, nil)
+	require.NoError(t, err)
+	require.Len(t, update.Events, 1)
+	assert.Equal(t, diff.KindRemoved, update.Events[0].Kind)
+	assert.Equal(t, "", update.Events[0].PathString())
+	assert.Contains(t, update.Describe(update.Events[0]), "token.Account removed {")
+	assert.Nil(t, w.Current(account))
+
+	update, err = w.Apply(account, 13, tokenAccount(t, 5, nil))
+	require.NoError(t, err)
+	require.Len(t, update.Events, 1)
+	assert.Equal(t, diff.KindAdded, update.Events[0].Kind)
+}
+
+func TestWatcher_Patterns(t *testing.T) {
+	account := solana.NewWallet().PublicKey()
+	delegate := solana.NewWallet().PublicKey()
+	w := New([]solana.PublicKey{account}, Options{Patterns: []string{"Delegate"}})
+
+	_, err := w.Apply(account, 10, tokenAccount(t, 100, nil))
+	require.NoError(t, err)
+
+	update, err := w.Apply(account, 11, tokenAccount(t, 80, nil))
+	require.NoError(t, err)
+	assert.True(t, update.IsEmpty())
+
+	update, err = w.Apply(account, 12, tokenAccount(t, 70, &delegate))
+	require.NoError(t, err)
+	require.Len(t, update.Events, 1)
+	assert.Equal(t, "Delegate", update.Events[0].PathString())
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	account := solana.NewWallet().PublicKey()
+	amounts := []uint64{100, 100, 80}
+
+	var lock sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getMultipleAccounts", req.Method)
+
+		lock.Lock()
+		amount := amounts[len(amounts)-1]
+		if polls < len(amounts) {
+			amount = amounts[polls]
+		}
+		polls++
+		slot := polls
+		lock.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": slot},
+				"value": []interface{}{map[string]interface{}{
+					"lamports":   2039280,
+					"owner":      solana.TokenProgramID.String(),
+					"data":       []string{base64.StdEncoding.EncodeToString(tokenAccountData(t, amount, nil)), "base64"},
+					"executable": false,
+					"rentEpoch":  0,
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var updates []*Update
+	w := New([]solana.PublicKey{account}, Options{PollInterval: time.Millisecond})
+	err := w.Poll(ctx, rpc.New(server.URL), func(update *Update) error {
+		updates = append(updates, update)
+		if len(updates) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	require.Len(t, updates, 2)
+	assert.True(t, updates[0].Initial)
+	assert.Equal(t, uint64(1), updates[0].Slot)
+	require.Len(t, updates[1].Events, 1)
+	assert.Equal(t, uint64(3), updates[1].Slot)
+	assert.Equal(t, "token.Account.Amount changed 100→80", updates[1].Describe(updates[1].Events[0]))
+}